@@ -0,0 +1,25 @@
+/*
+Package 初始化顺序：
+
+	package 初始化时，先按依赖关系初始化全局变量，再按文件名顺序执行 init 函数
+	完成下面的 TODO，使 package 初始化之后：
+		* ` Version == "1.0" `，Version 由 version 函数初始化
+		* ` Steps == []string{"version", "init"} `
+*/
+
+//gotour:hint package-global-variables
+//gotour:hint package-init-functions
+//gotour:demo packageScopeAPI
+package initorder
+
+// Steps 记录 package 初始化过程中执行的步骤
+var Steps []string
+
+// TODO: 声明全局变量 Version，使用 version 函数初始化
+
+// TODO: 声明 init 函数，在 Steps 中记录 "init"
+
+func version() string {
+	Steps = append(Steps, "version")
+	return "1.0"
+}
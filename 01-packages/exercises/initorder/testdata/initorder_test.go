@@ -0,0 +1,19 @@
+package initorder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVersion(t *testing.T) {
+	if Version != "1.0" {
+		t.Errorf("Version = %q, want %q", Version, "1.0")
+	}
+}
+
+func TestSteps(t *testing.T) {
+	want := []string{"version", "init"}
+	if !reflect.DeepEqual(Steps, want) {
+		t.Errorf("Steps = %q, want %q: global variables are initialized before init functions run", Steps, want)
+	}
+}
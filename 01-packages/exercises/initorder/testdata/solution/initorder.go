@@ -0,0 +1,15 @@
+package initorder
+
+// Steps 记录 package 初始化过程中执行的步骤
+var Steps []string
+
+var Version = version()
+
+func init() {
+	Steps = append(Steps, "init")
+}
+
+func version() string {
+	Steps = append(Steps, "version")
+	return "1.0"
+}
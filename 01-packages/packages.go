@@ -54,7 +54,7 @@ Go Packages：
 
 	Program execution order（Go 程序执行顺序）：
 		当执行一个 go program 时，会进入 `main package execution` 过程：
-		```go
+		```text
 				go run *.go
 				├── Main package is executed
 					├── All imported packages are initialized
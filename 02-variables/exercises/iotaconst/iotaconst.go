@@ -0,0 +1,35 @@
+/*
+使用 iota 声明常量：
+
+	将下面的常量改写为使用 iota 的括号声明，要求：
+		* Sunday 到 Saturday 依次为 0 到 6
+		* KB、MB、GB、TB 依次为 1 << 10、1 << 20、1 << 30、1 << 40，
+			提示：利用赋值表达式可以为空的规则，只写一次 ` 1 << (10 * iota) `
+*/
+
+//gotour:hint constant-变量
+//gotour:hint iota
+package iotaconst
+
+type Weekday int
+
+// TODO: 使用 iota 改写
+const (
+	Sunday    Weekday = 0
+	Monday    Weekday = 0
+	Tuesday   Weekday = 0
+	Wednesday Weekday = 0
+	Thursday  Weekday = 0
+	Friday    Weekday = 0
+	Saturday  Weekday = 0
+)
+
+type ByteSize int64
+
+// TODO: 使用 iota 改写
+const (
+	KB ByteSize = 0
+	MB ByteSize = 0
+	GB ByteSize = 0
+	TB ByteSize = 0
+)
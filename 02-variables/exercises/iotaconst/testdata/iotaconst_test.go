@@ -0,0 +1,30 @@
+package iotaconst
+
+import "testing"
+
+func TestWeekday(t *testing.T) {
+	days := []Weekday{Sunday, Monday, Tuesday, Wednesday, Thursday, Friday, Saturday}
+	for i, d := range days {
+		if d != Weekday(i) {
+			t.Errorf("day %d = %d, want %d", i, d, i)
+		}
+	}
+}
+
+func TestByteSize(t *testing.T) {
+	tests := []struct {
+		name string
+		got  ByteSize
+		want ByteSize
+	}{
+		{"KB", KB, 1 << 10},
+		{"MB", MB, 1 << 20},
+		{"GB", GB, 1 << 30},
+		{"TB", TB, 1 << 40},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %d, want %d", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -75,7 +75,7 @@ Variables：
 
 		括号声明语法（ Parenthesized const declaration list ）:
 			* 每个变量单独赋值
-			```go
+			```go sketch
 			const (
 				var1 = value1
 				var2, var3 = value2, value3
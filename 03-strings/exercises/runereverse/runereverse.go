@@ -0,0 +1,22 @@
+/*
+反转字符串：
+
+	下面的 Reverse 按字节反转字符串，遇到中文等多字节字符时，会破坏 utf-8 编码，
+	请修改为按字符（rune）反转：
+		* ` Reverse("中国香港") == "港香国中" `
+		* ` Reverse("Hello, 世界") == "界世 ,olleH" `
+*/
+
+//gotour:hint 实际上-字符串是将字符-code-point-进行-utf-8-编码后得到的只读字节-slice
+//gotour:hint rune
+//gotour:hint 用法
+package runereverse
+
+// Reverse 返回字符顺序反转后的字符串
+func Reverse(s string) string {
+	b := []byte(s)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
@@ -0,0 +1,34 @@
+package runereverse
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestReverse(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"go", "og"},
+		{"中国香港", "港香国中"},
+		{"Hello, 世界", "界世 ,olleH"},
+	}
+	for _, tt := range tests {
+		got := Reverse(tt.in)
+		if !utf8.ValidString(got) {
+			t.Errorf("Reverse(%q) = %q, which is not valid utf-8", tt.in, got)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Reverse(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestReverseTwice(t *testing.T) {
+	const s = "\u4e2d\u56fd\u9999\u6e2f, Hong Kong"
+	if got := Reverse(Reverse(s)); got != s {
+		t.Errorf("Reverse(Reverse(%q)) = %q", s, got)
+	}
+}
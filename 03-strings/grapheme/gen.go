@@ -0,0 +1,162 @@
+//go:build ignore
+
+// gen 读取 ucd 目录中的 UCD 文件，生成 tables.go，运行：
+//
+//	go generate ./03-strings/grapheme
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 与 grapheme.go 中 property 常量的名字一一对应
+var breakProperties = map[string]string{
+	"Prepend":            "prepend",
+	"CR":                 "cr",
+	"LF":                 "lf",
+	"Control":            "control",
+	"Extend":             "extend",
+	"Regional_Indicator": "regionalIndicator",
+	"SpacingMark":        "spacingMark",
+	"L":                  "hangulL",
+	"V":                  "hangulV",
+	"T":                  "hangulT",
+	"LV":                 "hangulLV",
+	"LVT":                "hangulLVT",
+	"ZWJ":                "zwj",
+}
+
+var versionPattern = regexp.MustCompile(`^# Unicode (\d+\.\d+\.\d+)`)
+
+type entry struct {
+	lo, hi uint32
+	value  string
+}
+
+// parse 读取 UCD 文件，返回按 code point 排序的条目，以及文件头中声明的 Unicode 版本
+func parse(name string) ([]entry, string) {
+	f, err := os.Open(filepath.Join("ucd", name))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	var entries []entry
+	version := ""
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := s.Text()
+		if m := versionPattern.FindStringSubmatch(line); m != nil {
+			version = m[1]
+		}
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, ";")
+		if len(fields) < 2 {
+			log.Fatalf("%s: invalid line %q", name, s.Text())
+		}
+		lo, hi := codePoints(strings.TrimSpace(fields[0]))
+		entries = append(entries, entry{lo, hi, strings.TrimSpace(fields[1])})
+	}
+	if err := s.Err(); err != nil {
+		log.Fatal(err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].lo < entries[j].lo })
+	return entries, version
+}
+
+func codePoints(field string) (uint32, uint32) {
+	lo, hi, ok := strings.Cut(field, "..")
+	if !ok {
+		hi = lo
+	}
+	l, err := strconv.ParseUint(lo, 16, 32)
+	if err != nil {
+		log.Fatal(err)
+	}
+	h, err := strconv.ParseUint(hi, 16, 32)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return uint32(l), uint32(h)
+}
+
+// merge 合并相邻且值相同的条目
+func merge(entries []entry) []entry {
+	var out []entry
+	for _, e := range entries {
+		if n := len(out); n > 0 && out[n-1].hi+1 == e.lo && out[n-1].value == e.value {
+			out[n-1].hi = e.hi
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+func filter(entries []entry, keep func(string) bool) []entry {
+	var out []entry
+	for _, e := range entries {
+		if keep(e.value) {
+			out = append(out, entry{e.lo, e.hi, ""})
+		}
+	}
+	return merge(out)
+}
+
+func main() {
+	breaks, version := parse("GraphemeBreakProperty.txt")
+	emoji, _ := parse("emoji-data.txt")
+	widths, _ := parse("EastAsianWidth.txt")
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gen.go from ucd/*.txt; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package grapheme\n\n")
+	fmt.Fprintf(&buf, "// UnicodeVersion 为生成规则表所使用的 UCD 版本\n")
+	fmt.Fprintf(&buf, "const UnicodeVersion = %q\n\n", version)
+
+	fmt.Fprintf(&buf, "// breakTable 为 Grapheme_Cluster_Break 属性，未列出的 code point 为 Other\n")
+	fmt.Fprintf(&buf, "var breakTable = []propertyRange{\n")
+	for _, e := range merge(breaks) {
+		name, ok := breakProperties[e.value]
+		if !ok {
+			log.Fatalf("unknown Grapheme_Cluster_Break value %q", e.value)
+		}
+		fmt.Fprintf(&buf, "\t{0x%04X, 0x%04X, %s},\n", e.lo, e.hi, name)
+	}
+	fmt.Fprintf(&buf, "}\n\n")
+
+	table := func(name, doc string, entries []entry) {
+		fmt.Fprintf(&buf, "// %s %s\n", name, doc)
+		fmt.Fprintf(&buf, "var %s = []runeRange{\n", name)
+		for _, e := range entries {
+			fmt.Fprintf(&buf, "\t{0x%04X, 0x%04X},\n", e.lo, e.hi)
+		}
+		fmt.Fprintf(&buf, "}\n\n")
+	}
+	table("extendedPictographic", "为 Extended_Pictographic 属性", filter(emoji, func(v string) bool { return v == "Extended_Pictographic" }))
+	table("emojiPresentation", "为 Emoji_Presentation 属性，默认以 emoji 形式显示的字符", filter(emoji, func(v string) bool { return v == "Emoji_Presentation" }))
+	table("wide", "为 East_Asian_Width 属性为 W、F 的字符，在终端中占两列", filter(widths, func(v string) bool { return v == "W" || v == "F" }))
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("tables.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
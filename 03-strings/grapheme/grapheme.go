@@ -0,0 +1,268 @@
+/*
+Package grapheme 将字符串切分为用户感知的字符，即 extended grapheme cluster（UAX #29），参考 03-strings 中的 graphemeCount
+
+	一个 rune 不一定是一个"字符"：
+		* "é" 可以写作 e + U+0301（组合重音符号），两个 rune
+		* 国旗 🇨🇳 由两个 Regional Indicator 组成
+		* 👨‍👩‍👧 由三个 emoji 以及两个 ZWJ（U+200D）组成，五个 rune
+		* 👍🏽 为 emoji + 肤色修饰符，两个 rune
+
+	切分规则为 UAX #29 中的 GB3 ~ GB999，所需的属性表（tables.go）由 gen.go 根据 ucd 目录中的 UCD 文件生成：
+		* GraphemeBreakProperty.txt：Grapheme_Cluster_Break 属性
+		* emoji-data.txt：Extended_Pictographic、Emoji_Presentation 属性
+		* EastAsianWidth.txt：East_Asian_Width 属性，用于计算显示宽度
+
+	无效的 utf-8 字节与 range 的处理方式一致，每个字节视为一个 U+FFFD，属性为 Other，与普通字符一样参与切分：
+	之后的 Extend、ZWJ、SpacingMark 会与它组成同一个 cluster，比如 "a\xff\u0308b" 切分为 "a"、"\xff\u0308"、"b"；
+	cluster 中保留原始字节
+*/
+package grapheme
+
+//go:generate go run gen.go
+
+import (
+	"iter"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// property 为 Grapheme_Cluster_Break 属性值
+type property uint8
+
+const (
+	other property = iota
+	prepend
+	cr
+	lf
+	control
+	extend
+	regionalIndicator
+	spacingMark
+	hangulL
+	hangulV
+	hangulT
+	hangulLV
+	hangulLVT
+	zwj
+)
+
+// variationSelector16 为 VS16（U+FE0F），要求前一个字符以 emoji 形式显示，比如 ❤️
+const variationSelector16 = 0xFE0F
+
+type propertyRange struct {
+	lo, hi uint32
+	prop   property
+}
+
+type runeRange struct {
+	lo, hi uint32
+}
+
+func lookup(r rune) property {
+	i := sort.Search(len(breakTable), func(i int) bool { return breakTable[i].hi >= uint32(r) })
+	if i < len(breakTable) && breakTable[i].lo <= uint32(r) {
+		return breakTable[i].prop
+	}
+	return other
+}
+
+func in(table []runeRange, r rune) bool {
+	i := sort.Search(len(table), func(i int) bool { return table[i].hi >= uint32(r) })
+	return i < len(table) && table[i].lo <= uint32(r)
+}
+
+// First 返回 s 中的第一个 grapheme cluster，以及剩余的字符串
+func First(s string) (cluster, rest string) {
+	n := firstLen(s)
+	return s[:n], s[n:]
+}
+
+// firstLen 返回第一个 grapheme cluster 的字节长度
+func firstLen(s string) int {
+	if s == "" {
+		return 0
+	}
+	// 可打印的 ASCII 字符后面跟着 ASCII 字符时总是可以断开
+	if len(s) > 1 && s[0] >= 0x20 && s[0] < 0x7f && s[1] < utf8.RuneSelf {
+		return 1
+	}
+
+	r, i := utf8.DecodeRuneInString(s)
+	prev := lookup(r)
+	pict := in(extendedPictographic, r) // 到目前为止为 ExtPict Extend*，用于 GB11
+	pictZWJ := false                    // 到目前为止为 ExtPict Extend* ZWJ
+	ri := 0                             // 末尾连续的 Regional Indicator 数量，用于 GB12、GB13
+	if prev == regionalIndicator {
+		ri = 1
+	}
+
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		next := lookup(r)
+		nextPict := in(extendedPictographic, r)
+		if !joins(prev, next, nextPict && pictZWJ, ri) {
+			break
+		}
+
+		pictZWJ = pict && next == zwj
+		pict = nextPict || pict && next == extend
+		if next == regionalIndicator {
+			ri++
+		} else {
+			ri = 0
+		}
+		prev = next
+		i += size
+	}
+	return i
+}
+
+// joins 表示 prev、next 之间没有边界，emoji 表示 next 为 ExtPict Extend* ZWJ 之后的 ExtPict
+func joins(prev, next property, emoji bool, ri int) bool {
+	switch {
+	case prev == cr && next == lf: // GB3
+		return true
+	case prev == control || prev == cr || prev == lf: // GB4
+		return false
+	case next == control || next == cr || next == lf: // GB5
+		return false
+	case prev == hangulL && (next == hangulL || next == hangulV || next == hangulLV || next == hangulLVT): // GB6
+		return true
+	case (prev == hangulLV || prev == hangulV) && (next == hangulV || next == hangulT): // GB7
+		return true
+	case (prev == hangulLVT || prev == hangulT) && next == hangulT: // GB8
+		return true
+	case next == extend || next == zwj: // GB9
+		return true
+	case next == spacingMark: // GB9a
+		return true
+	case prev == prepend: // GB9b
+		return true
+	case prev == zwj && emoji: // GB11
+		return true
+	case prev == regionalIndicator && next == regionalIndicator: // GB12、GB13：两两配对
+		return ri%2 == 1
+	}
+	return false // GB999
+}
+
+// All 依次返回每个 grapheme cluster 的字节偏移以及内容
+func All(s string) iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		for offset := 0; offset < len(s); {
+			n := firstLen(s[offset:])
+			if !yield(offset, s[offset:offset+n]) {
+				return
+			}
+			offset += n
+		}
+	}
+}
+
+// Split 将 s 切分为 grapheme cluster
+func Split(s string) []string {
+	var clusters []string
+	for _, c := range All(s) {
+		clusters = append(clusters, c)
+	}
+	return clusters
+}
+
+// Count 返回 grapheme cluster 的数量，即用户感知的字符数量
+func Count(s string) int {
+	n := 0
+	for s != "" {
+		s = s[firstLen(s):]
+		n++
+	}
+	return n
+}
+
+// Index 返回 substr 在 s 中第一次出现的字节索引，且首尾均在 grapheme cluster 的边界上，没有出现时返回 -1
+//
+// 与 strings.Index 不同，"e" 不会匹配 "é" 中的 e
+func Index(s, substr string) int {
+	if substr == "" {
+		return 0
+	}
+	for offset := 0; offset < len(s); offset += firstLen(s[offset:]) {
+		if !strings.HasPrefix(s[offset:], substr) {
+			continue
+		}
+		// 边界之后的切分结果与从头切分一致，可以从 offset 开始检查末尾是否在边界上
+		end := offset
+		for end < offset+len(substr) {
+			end += firstLen(s[end:])
+		}
+		if end == offset+len(substr) {
+			return offset
+		}
+	}
+	return -1
+}
+
+// Reverse 按 grapheme cluster 反转字符串，组合字符、emoji 序列等保持不变
+func Reverse(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	clusters := Split(s)
+	for i := len(clusters) - 1; i >= 0; i-- {
+		b.WriteString(clusters[i])
+	}
+	return b.String()
+}
+
+// ClusterWidth 返回一个 grapheme cluster 在等宽终端中占用的列数：
+//   - 控制字符，以及以组合字符开头的 cluster 为 0
+//   - 包含 East_Asian_Width 为 W、F 的字符，或者以 emoji 形式显示（Emoji_Presentation，或者带有 VS16）为 2
+//   - 其他为 1
+func ClusterWidth(cluster string) int {
+	if cluster == "" {
+		return 0
+	}
+	r, _ := utf8.DecodeRuneInString(cluster)
+	switch lookup(r) {
+	case control, cr, lf, extend, zwj:
+		return 0
+	}
+	for _, r := range cluster {
+		if r == variationSelector16 || in(wide, r) || in(emojiPresentation, r) {
+			return 2
+		}
+	}
+	return 1
+}
+
+// Width 返回字符串在等宽终端中占用的列数，即每个 grapheme cluster 的 ClusterWidth 之和
+func Width(s string) int {
+	w := 0
+	for _, c := range All(s) {
+		w += ClusterWidth(c)
+	}
+	return w
+}
+
+// Truncate 将 s 截断到最多 width 列，截断时在末尾加上 tail（比如 "…"），tail 的宽度计算在内；
+// 不会切开 grapheme cluster，width 小于 tail 的宽度时只返回截断后的 tail
+func Truncate(s string, width int, tail string) string {
+	if Width(s) <= width {
+		return s
+	}
+	tw := Width(tail)
+	if tw > width {
+		return Truncate(tail, width, "")
+	}
+	width -= tw
+
+	end := 0
+	for offset, c := range All(s) {
+		w := ClusterWidth(c)
+		if w > width {
+			break
+		}
+		width -= w
+		end = offset + len(c)
+	}
+	return s[:end] + tail
+}
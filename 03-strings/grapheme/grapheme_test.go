@@ -0,0 +1,223 @@
+package grapheme
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const (
+	decomposed = "e\u0301"                                    // é = e + 组合重音符号
+	family     = "\U0001F468\u200D\U0001F469\u200D\U0001F467" // 👨‍👩‍👧
+	flagCN     = "\U0001F1E8\U0001F1F3"                       // 🇨🇳
+	thumbsUp   = "\U0001F44D\U0001F3FD"                       // 👍🏽
+	heart      = "\u2764\uFE0F"                               // ❤️
+)
+
+// TestBreakTest 使用 Unicode 14.0.0 UCD 中的 auxiliary/GraphemeBreakTest.txt（testdata/GraphemeBreakTest.txt）检查切分规则，
+// ÷ 为边界，× 为非边界
+func TestBreakTest(t *testing.T) {
+	f, err := os.Open("testdata/GraphemeBreakTest.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	cases := 0
+	s := bufio.NewScanner(f)
+	for line := 1; s.Scan(); line++ {
+		text, _, _ := strings.Cut(s.Text(), "#")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		var input strings.Builder
+		var want []string
+		cluster := ""
+		for _, field := range strings.Fields(text) {
+			switch field {
+			case "÷":
+				if cluster != "" {
+					want = append(want, cluster)
+					cluster = ""
+				}
+			case "×":
+			default:
+				cp, err := strconv.ParseUint(field, 16, 32)
+				if err != nil {
+					t.Fatalf("line %d: %v", line, err)
+				}
+				cluster += string(rune(cp))
+				input.WriteRune(rune(cp))
+			}
+		}
+
+		got := Split(input.String())
+		if strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("line %d: Split(%+q) = %+q, want %+q", line, input.String(), got, want)
+		}
+		if n := Count(input.String()); n != len(want) {
+			t.Errorf("line %d: Count(%+q) = %d, want %d", line, input.String(), n, len(want))
+		}
+		cases++
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if cases != 602 {
+		t.Fatalf("%d test cases, want the 602 cases of Unicode 14.0.0", cases)
+	}
+}
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"hello", 5},
+		{"中国香港", 4},
+		{"\r\n", 1},
+		{decomposed, 1},
+		{family, 1},
+		{flagCN, 1},
+		{flagCN + flagCN + "\U0001F1E8", 3}, // 落单的 Regional Indicator
+		{thumbsUp, 1},
+		{heart, 1},
+		{"한국어", 3},
+		{"\u1100\u1161\u11A8", 1}, // 组合形式的 각
+		{"a\xffb", 3},
+		{"a\xff\u0308b", 3}, // 无效字节之后的 Extend 与它组成同一个 cluster
+	}
+	for _, tt := range tests {
+		if got := Count(tt.s); got != tt.want {
+			t.Errorf("Count(%+q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestFirst(t *testing.T) {
+	cluster, rest := First(family + "!")
+	if cluster != family || rest != "!" {
+		t.Errorf("First = %+q, %+q, want %+q, %q", cluster, rest, family, "!")
+	}
+	if cluster, rest := First(""); cluster != "" || rest != "" {
+		t.Errorf("First(\"\") = %q, %q", cluster, rest)
+	}
+}
+
+// TestSplitInvalid 检查无效字节按 U+FFFD 参与切分，并保留原始字节
+func TestSplitInvalid(t *testing.T) {
+	got := Split("a\xff\u0308b")
+	if want := []string{"a", "\xff\u0308", "b"}; strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Split(%+q) = %+q, want %+q", "a\xff\u0308b", got, want)
+	}
+}
+
+func TestIndex(t *testing.T) {
+	tests := []struct {
+		s, substr string
+		want      int
+	}{
+		{"cafe", "e", 3},
+		{"caf" + decomposed, "e", -1},
+		{"caf" + decomposed, decomposed, 3},
+		{"caf" + decomposed + "e", "e", 6},
+		{family, "\U0001F468", -1},
+		{"a" + flagCN + flagCN, flagCN, 1},
+		{"\U0001F1FA" + flagCN + "\U0001F1F3", flagCN, -1}, // 🇺🇨 🇳🇳，不是 🇨🇳
+		{"abc", "", 0},
+		{"abc", "x", -1},
+	}
+	for _, tt := range tests {
+		if got := Index(tt.s, tt.substr); got != tt.want {
+			t.Errorf("Index(%+q, %+q) = %d, want %d", tt.s, tt.substr, got, tt.want)
+		}
+	}
+}
+
+func TestReverse(t *testing.T) {
+	tests := []struct {
+		s, want string
+	}{
+		{"", ""},
+		{"abc", "cba"},
+		{"中国香港", "港香国中"},
+		{"a" + decomposed + "b", "b" + decomposed + "a"},
+		{family + thumbsUp + flagCN, flagCN + thumbsUp + family},
+		{"a\r\nb", "b\r\na"},
+		{"a\xffb", "b\xffa"},
+	}
+	for _, tt := range tests {
+		if got := Reverse(tt.s); got != tt.want {
+			t.Errorf("Reverse(%+q) = %+q, want %+q", tt.s, got, tt.want)
+		}
+		if got := Reverse(Reverse(tt.s)); got != tt.s {
+			t.Errorf("Reverse(Reverse(%+q)) = %+q", tt.s, got)
+		}
+	}
+}
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"hello", 5},
+		{"中国香港", 8},
+		{"ｈｉ", 4}, // 全角字符
+		{decomposed, 1},
+		{family, 2},
+		{flagCN, 2},
+		{thumbsUp, 2},
+		{heart, 2},
+		{"\u2764", 1}, // 没有 VS16 时以文字形式显示
+		{"a\tb", 2},
+	}
+	for _, tt := range tests {
+		if got := Width(tt.s); got != tt.want {
+			t.Errorf("Width(%+q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		tail  string
+		want  string
+	}{
+		{"hello", 5, "…", "hello"},
+		{"hello, world", 8, "…", "hello, …"},
+		{"中国香港", 5, "…", "中国…"},
+		{"中国香港", 4, "…", "中…"},
+		{"caf" + decomposed + "s", 5, "…", "caf" + decomposed + "s"},
+		{"caf" + decomposed + "s!", 5, "…", "caf" + decomposed + "…"},
+		{family + family, 3, "", family},
+		{family + family, 3, "…", family + "…"},
+		{family + family, 2, "…", "…"},
+		{"hello", 2, "...", ".."},
+		{"hello", 0, "…", ""},
+	}
+	for _, tt := range tests {
+		got := Truncate(tt.s, tt.width, tt.tail)
+		if got != tt.want {
+			t.Errorf("Truncate(%+q, %d, %q) = %+q, want %+q", tt.s, tt.width, tt.tail, got, tt.want)
+		}
+		if Width(got) > tt.width {
+			t.Errorf("Width(Truncate(%+q, %d, %q)) = %d", tt.s, tt.width, tt.tail, Width(got))
+		}
+	}
+}
+
+func BenchmarkCount(b *testing.B) {
+	s := strings.Repeat("hello, 世界 "+family+flagCN+decomposed, 100)
+	b.SetBytes(int64(len(s)))
+	for b.Loop() {
+		Count(s)
+	}
+}
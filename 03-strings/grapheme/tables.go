@@ -0,0 +1,1659 @@
+// Code generated by gen.go from ucd/*.txt; DO NOT EDIT.
+
+package grapheme
+
+// UnicodeVersion 为生成规则表所使用的 UCD 版本
+const UnicodeVersion = "14.0.0"
+
+// breakTable 为 Grapheme_Cluster_Break 属性，未列出的 code point 为 Other
+var breakTable = []propertyRange{
+	{0x0000, 0x0009, control},
+	{0x000A, 0x000A, lf},
+	{0x000B, 0x000C, control},
+	{0x000D, 0x000D, cr},
+	{0x000E, 0x001F, control},
+	{0x007F, 0x009F, control},
+	{0x00AD, 0x00AD, control},
+	{0x0300, 0x036F, extend},
+	{0x0483, 0x0489, extend},
+	{0x0591, 0x05BD, extend},
+	{0x05BF, 0x05BF, extend},
+	{0x05C1, 0x05C2, extend},
+	{0x05C4, 0x05C5, extend},
+	{0x05C7, 0x05C7, extend},
+	{0x0600, 0x0605, prepend},
+	{0x0610, 0x061A, extend},
+	{0x061C, 0x061C, control},
+	{0x064B, 0x065F, extend},
+	{0x0670, 0x0670, extend},
+	{0x06D6, 0x06DC, extend},
+	{0x06DD, 0x06DD, prepend},
+	{0x06DF, 0x06E4, extend},
+	{0x06E7, 0x06E8, extend},
+	{0x06EA, 0x06ED, extend},
+	{0x070F, 0x070F, prepend},
+	{0x0711, 0x0711, extend},
+	{0x0730, 0x074A, extend},
+	{0x07A6, 0x07B0, extend},
+	{0x07EB, 0x07F3, extend},
+	{0x07FD, 0x07FD, extend},
+	{0x0816, 0x0819, extend},
+	{0x081B, 0x0823, extend},
+	{0x0825, 0x0827, extend},
+	{0x0829, 0x082D, extend},
+	{0x0859, 0x085B, extend},
+	{0x0890, 0x0891, prepend},
+	{0x0898, 0x089F, extend},
+	{0x08CA, 0x08E1, extend},
+	{0x08E2, 0x08E2, prepend},
+	{0x08E3, 0x0902, extend},
+	{0x0903, 0x0903, spacingMark},
+	{0x093A, 0x093A, extend},
+	{0x093B, 0x093B, spacingMark},
+	{0x093C, 0x093C, extend},
+	{0x093E, 0x0940, spacingMark},
+	{0x0941, 0x0948, extend},
+	{0x0949, 0x094C, spacingMark},
+	{0x094D, 0x094D, extend},
+	{0x094E, 0x094F, spacingMark},
+	{0x0951, 0x0957, extend},
+	{0x0962, 0x0963, extend},
+	{0x0981, 0x0981, extend},
+	{0x0982, 0x0983, spacingMark},
+	{0x09BC, 0x09BC, extend},
+	{0x09BE, 0x09BE, extend},
+	{0x09BF, 0x09C0, spacingMark},
+	{0x09C1, 0x09C4, extend},
+	{0x09C7, 0x09C8, spacingMark},
+	{0x09CB, 0x09CC, spacingMark},
+	{0x09CD, 0x09CD, extend},
+	{0x09D7, 0x09D7, extend},
+	{0x09E2, 0x09E3, extend},
+	{0x09FE, 0x09FE, extend},
+	{0x0A01, 0x0A02, extend},
+	{0x0A03, 0x0A03, spacingMark},
+	{0x0A3C, 0x0A3C, extend},
+	{0x0A3E, 0x0A40, spacingMark},
+	{0x0A41, 0x0A42, extend},
+	{0x0A47, 0x0A48, extend},
+	{0x0A4B, 0x0A4D, extend},
+	{0x0A51, 0x0A51, extend},
+	{0x0A70, 0x0A71, extend},
+	{0x0A75, 0x0A75, extend},
+	{0x0A81, 0x0A82, extend},
+	{0x0A83, 0x0A83, spacingMark},
+	{0x0ABC, 0x0ABC, extend},
+	{0x0ABE, 0x0AC0, spacingMark},
+	{0x0AC1, 0x0AC5, extend},
+	{0x0AC7, 0x0AC8, extend},
+	{0x0AC9, 0x0AC9, spacingMark},
+	{0x0ACB, 0x0ACC, spacingMark},
+	{0x0ACD, 0x0ACD, extend},
+	{0x0AE2, 0x0AE3, extend},
+	{0x0AFA, 0x0AFF, extend},
+	{0x0B01, 0x0B01, extend},
+	{0x0B02, 0x0B03, spacingMark},
+	{0x0B3C, 0x0B3C, extend},
+	{0x0B3E, 0x0B3F, extend},
+	{0x0B40, 0x0B40, spacingMark},
+	{0x0B41, 0x0B44, extend},
+	{0x0B47, 0x0B48, spacingMark},
+	{0x0B4B, 0x0B4C, spacingMark},
+	{0x0B4D, 0x0B4D, extend},
+	{0x0B55, 0x0B57, extend},
+	{0x0B62, 0x0B63, extend},
+	{0x0B82, 0x0B82, extend},
+	{0x0BBE, 0x0BBE, extend},
+	{0x0BBF, 0x0BBF, spacingMark},
+	{0x0BC0, 0x0BC0, extend},
+	{0x0BC1, 0x0BC2, spacingMark},
+	{0x0BC6, 0x0BC8, spacingMark},
+	{0x0BCA, 0x0BCC, spacingMark},
+	{0x0BCD, 0x0BCD, extend},
+	{0x0BD7, 0x0BD7, extend},
+	{0x0C00, 0x0C00, extend},
+	{0x0C01, 0x0C03, spacingMark},
+	{0x0C04, 0x0C04, extend},
+	{0x0C3C, 0x0C3C, extend},
+	{0x0C3E, 0x0C40, extend},
+	{0x0C41, 0x0C44, spacingMark},
+	{0x0C46, 0x0C48, extend},
+	{0x0C4A, 0x0C4D, extend},
+	{0x0C55, 0x0C56, extend},
+	{0x0C62, 0x0C63, extend},
+	{0x0C81, 0x0C81, extend},
+	{0x0C82, 0x0C83, spacingMark},
+	{0x0CBC, 0x0CBC, extend},
+	{0x0CBE, 0x0CBE, spacingMark},
+	{0x0CBF, 0x0CBF, extend},
+	{0x0CC0, 0x0CC1, spacingMark},
+	{0x0CC2, 0x0CC2, extend},
+	{0x0CC3, 0x0CC4, spacingMark},
+	{0x0CC6, 0x0CC6, extend},
+	{0x0CC7, 0x0CC8, spacingMark},
+	{0x0CCA, 0x0CCB, spacingMark},
+	{0x0CCC, 0x0CCD, extend},
+	{0x0CD5, 0x0CD6, extend},
+	{0x0CE2, 0x0CE3, extend},
+	{0x0D00, 0x0D01, extend},
+	{0x0D02, 0x0D03, spacingMark},
+	{0x0D3B, 0x0D3C, extend},
+	{0x0D3E, 0x0D3E, extend},
+	{0x0D3F, 0x0D40, spacingMark},
+	{0x0D41, 0x0D44, extend},
+	{0x0D46, 0x0D48, spacingMark},
+	{0x0D4A, 0x0D4C, spacingMark},
+	{0x0D4D, 0x0D4D, extend},
+	{0x0D4E, 0x0D4E, prepend},
+	{0x0D57, 0x0D57, extend},
+	{0x0D62, 0x0D63, extend},
+	{0x0D81, 0x0D81, extend},
+	{0x0D82, 0x0D83, spacingMark},
+	{0x0DCA, 0x0DCA, extend},
+	{0x0DCF, 0x0DCF, extend},
+	{0x0DD0, 0x0DD1, spacingMark},
+	{0x0DD2, 0x0DD4, extend},
+	{0x0DD6, 0x0DD6, extend},
+	{0x0DD8, 0x0DDE, spacingMark},
+	{0x0DDF, 0x0DDF, extend},
+	{0x0DF2, 0x0DF3, spacingMark},
+	{0x0E31, 0x0E31, extend},
+	{0x0E33, 0x0E33, spacingMark},
+	{0x0E34, 0x0E3A, extend},
+	{0x0E47, 0x0E4E, extend},
+	{0x0EB1, 0x0EB1, extend},
+	{0x0EB3, 0x0EB3, spacingMark},
+	{0x0EB4, 0x0EBC, extend},
+	{0x0EC8, 0x0ECD, extend},
+	{0x0F18, 0x0F19, extend},
+	{0x0F35, 0x0F35, extend},
+	{0x0F37, 0x0F37, extend},
+	{0x0F39, 0x0F39, extend},
+	{0x0F3E, 0x0F3F, spacingMark},
+	{0x0F71, 0x0F7E, extend},
+	{0x0F7F, 0x0F7F, spacingMark},
+	{0x0F80, 0x0F84, extend},
+	{0x0F86, 0x0F87, extend},
+	{0x0F8D, 0x0F97, extend},
+	{0x0F99, 0x0FBC, extend},
+	{0x0FC6, 0x0FC6, extend},
+	{0x102D, 0x1030, extend},
+	{0x1031, 0x1031, spacingMark},
+	{0x1032, 0x1037, extend},
+	{0x1039, 0x103A, extend},
+	{0x103B, 0x103C, spacingMark},
+	{0x103D, 0x103E, extend},
+	{0x1056, 0x1057, spacingMark},
+	{0x1058, 0x1059, extend},
+	{0x105E, 0x1060, extend},
+	{0x1071, 0x1074, extend},
+	{0x1082, 0x1082, extend},
+	{0x1084, 0x1084, spacingMark},
+	{0x1085, 0x1086, extend},
+	{0x108D, 0x108D, extend},
+	{0x109D, 0x109D, extend},
+	{0x1100, 0x115F, hangulL},
+	{0x1160, 0x11A7, hangulV},
+	{0x11A8, 0x11FF, hangulT},
+	{0x135D, 0x135F, extend},
+	{0x1712, 0x1714, extend},
+	{0x1715, 0x1715, spacingMark},
+	{0x1732, 0x1733, extend},
+	{0x1734, 0x1734, spacingMark},
+	{0x1752, 0x1753, extend},
+	{0x1772, 0x1773, extend},
+	{0x17B4, 0x17B5, extend},
+	{0x17B6, 0x17B6, spacingMark},
+	{0x17B7, 0x17BD, extend},
+	{0x17BE, 0x17C5, spacingMark},
+	{0x17C6, 0x17C6, extend},
+	{0x17C7, 0x17C8, spacingMark},
+	{0x17C9, 0x17D3, extend},
+	{0x17DD, 0x17DD, extend},
+	{0x180B, 0x180D, extend},
+	{0x180E, 0x180E, control},
+	{0x180F, 0x180F, extend},
+	{0x1885, 0x1886, extend},
+	{0x18A9, 0x18A9, extend},
+	{0x1920, 0x1922, extend},
+	{0x1923, 0x1926, spacingMark},
+	{0x1927, 0x1928, extend},
+	{0x1929, 0x192B, spacingMark},
+	{0x1930, 0x1931, spacingMark},
+	{0x1932, 0x1932, extend},
+	{0x1933, 0x1938, spacingMark},
+	{0x1939, 0x193B, extend},
+	{0x1A17, 0x1A18, extend},
+	{0x1A19, 0x1A1A, spacingMark},
+	{0x1A1B, 0x1A1B, extend},
+	{0x1A55, 0x1A55, spacingMark},
+	{0x1A56, 0x1A56, extend},
+	{0x1A57, 0x1A57, spacingMark},
+	{0x1A58, 0x1A5E, extend},
+	{0x1A60, 0x1A60, extend},
+	{0x1A62, 0x1A62, extend},
+	{0x1A65, 0x1A6C, extend},
+	{0x1A6D, 0x1A72, spacingMark},
+	{0x1A73, 0x1A7C, extend},
+	{0x1A7F, 0x1A7F, extend},
+	{0x1AB0, 0x1ACE, extend},
+	{0x1B00, 0x1B03, extend},
+	{0x1B04, 0x1B04, spacingMark},
+	{0x1B34, 0x1B3A, extend},
+	{0x1B3B, 0x1B3B, spacingMark},
+	{0x1B3C, 0x1B3C, extend},
+	{0x1B3D, 0x1B41, spacingMark},
+	{0x1B42, 0x1B42, extend},
+	{0x1B43, 0x1B44, spacingMark},
+	{0x1B6B, 0x1B73, extend},
+	{0x1B80, 0x1B81, extend},
+	{0x1B82, 0x1B82, spacingMark},
+	{0x1BA1, 0x1BA1, spacingMark},
+	{0x1BA2, 0x1BA5, extend},
+	{0x1BA6, 0x1BA7, spacingMark},
+	{0x1BA8, 0x1BA9, extend},
+	{0x1BAA, 0x1BAA, spacingMark},
+	{0x1BAB, 0x1BAD, extend},
+	{0x1BE6, 0x1BE6, extend},
+	{0x1BE7, 0x1BE7, spacingMark},
+	{0x1BE8, 0x1BE9, extend},
+	{0x1BEA, 0x1BEC, spacingMark},
+	{0x1BED, 0x1BED, extend},
+	{0x1BEE, 0x1BEE, spacingMark},
+	{0x1BEF, 0x1BF1, extend},
+	{0x1BF2, 0x1BF3, spacingMark},
+	{0x1C24, 0x1C2B, spacingMark},
+	{0x1C2C, 0x1C33, extend},
+	{0x1C34, 0x1C35, spacingMark},
+	{0x1C36, 0x1C37, extend},
+	{0x1CD0, 0x1CD2, extend},
+	{0x1CD4, 0x1CE0, extend},
+	{0x1CE1, 0x1CE1, spacingMark},
+	{0x1CE2, 0x1CE8, extend},
+	{0x1CED, 0x1CED, extend},
+	{0x1CF4, 0x1CF4, extend},
+	{0x1CF7, 0x1CF7, spacingMark},
+	{0x1CF8, 0x1CF9, extend},
+	{0x1DC0, 0x1DFF, extend},
+	{0x200B, 0x200B, control},
+	{0x200C, 0x200C, extend},
+	{0x200D, 0x200D, zwj},
+	{0x200E, 0x200F, control},
+	{0x2028, 0x202E, control},
+	{0x2060, 0x206F, control},
+	{0x20D0, 0x20F0, extend},
+	{0x2CEF, 0x2CF1, extend},
+	{0x2D7F, 0x2D7F, extend},
+	{0x2DE0, 0x2DFF, extend},
+	{0x302A, 0x302F, extend},
+	{0x3099, 0x309A, extend},
+	{0xA66F, 0xA672, extend},
+	{0xA674, 0xA67D, extend},
+	{0xA69E, 0xA69F, extend},
+	{0xA6F0, 0xA6F1, extend},
+	{0xA802, 0xA802, extend},
+	{0xA806, 0xA806, extend},
+	{0xA80B, 0xA80B, extend},
+	{0xA823, 0xA824, spacingMark},
+	{0xA825, 0xA826, extend},
+	{0xA827, 0xA827, spacingMark},
+	{0xA82C, 0xA82C, extend},
+	{0xA880, 0xA881, spacingMark},
+	{0xA8B4, 0xA8C3, spacingMark},
+	{0xA8C4, 0xA8C5, extend},
+	{0xA8E0, 0xA8F1, extend},
+	{0xA8FF, 0xA8FF, extend},
+	{0xA926, 0xA92D, extend},
+	{0xA947, 0xA951, extend},
+	{0xA952, 0xA953, spacingMark},
+	{0xA960, 0xA97C, hangulL},
+	{0xA980, 0xA982, extend},
+	{0xA983, 0xA983, spacingMark},
+	{0xA9B3, 0xA9B3, extend},
+	{0xA9B4, 0xA9B5, spacingMark},
+	{0xA9B6, 0xA9B9, extend},
+	{0xA9BA, 0xA9BB, spacingMark},
+	{0xA9BC, 0xA9BD, extend},
+	{0xA9BE, 0xA9C0, spacingMark},
+	{0xA9E5, 0xA9E5, extend},
+	{0xAA29, 0xAA2E, extend},
+	{0xAA2F, 0xAA30, spacingMark},
+	{0xAA31, 0xAA32, extend},
+	{0xAA33, 0xAA34, spacingMark},
+	{0xAA35, 0xAA36, extend},
+	{0xAA43, 0xAA43, extend},
+	{0xAA4C, 0xAA4C, extend},
+	{0xAA4D, 0xAA4D, spacingMark},
+	{0xAA7C, 0xAA7C, extend},
+	{0xAAB0, 0xAAB0, extend},
+	{0xAAB2, 0xAAB4, extend},
+	{0xAAB7, 0xAAB8, extend},
+	{0xAABE, 0xAABF, extend},
+	{0xAAC1, 0xAAC1, extend},
+	{0xAAEB, 0xAAEB, spacingMark},
+	{0xAAEC, 0xAAED, extend},
+	{0xAAEE, 0xAAEF, spacingMark},
+	{0xAAF5, 0xAAF5, spacingMark},
+	{0xAAF6, 0xAAF6, extend},
+	{0xABE3, 0xABE4, spacingMark},
+	{0xABE5, 0xABE5, extend},
+	{0xABE6, 0xABE7, spacingMark},
+	{0xABE8, 0xABE8, extend},
+	{0xABE9, 0xABEA, spacingMark},
+	{0xABEC, 0xABEC, spacingMark},
+	{0xABED, 0xABED, extend},
+	{0xAC00, 0xAC00, hangulLV},
+	{0xAC01, 0xAC1B, hangulLVT},
+	{0xAC1C, 0xAC1C, hangulLV},
+	{0xAC1D, 0xAC37, hangulLVT},
+	{0xAC38, 0xAC38, hangulLV},
+	{0xAC39, 0xAC53, hangulLVT},
+	{0xAC54, 0xAC54, hangulLV},
+	{0xAC55, 0xAC6F, hangulLVT},
+	{0xAC70, 0xAC70, hangulLV},
+	{0xAC71, 0xAC8B, hangulLVT},
+	{0xAC8C, 0xAC8C, hangulLV},
+	{0xAC8D, 0xACA7, hangulLVT},
+	{0xACA8, 0xACA8, hangulLV},
+	{0xACA9, 0xACC3, hangulLVT},
+	{0xACC4, 0xACC4, hangulLV},
+	{0xACC5, 0xACDF, hangulLVT},
+	{0xACE0, 0xACE0, hangulLV},
+	{0xACE1, 0xACFB, hangulLVT},
+	{0xACFC, 0xACFC, hangulLV},
+	{0xACFD, 0xAD17, hangulLVT},
+	{0xAD18, 0xAD18, hangulLV},
+	{0xAD19, 0xAD33, hangulLVT},
+	{0xAD34, 0xAD34, hangulLV},
+	{0xAD35, 0xAD4F, hangulLVT},
+	{0xAD50, 0xAD50, hangulLV},
+	{0xAD51, 0xAD6B, hangulLVT},
+	{0xAD6C, 0xAD6C, hangulLV},
+	{0xAD6D, 0xAD87, hangulLVT},
+	{0xAD88, 0xAD88, hangulLV},
+	{0xAD89, 0xADA3, hangulLVT},
+	{0xADA4, 0xADA4, hangulLV},
+	{0xADA5, 0xADBF, hangulLVT},
+	{0xADC0, 0xADC0, hangulLV},
+	{0xADC1, 0xADDB, hangulLVT},
+	{0xADDC, 0xADDC, hangulLV},
+	{0xADDD, 0xADF7, hangulLVT},
+	{0xADF8, 0xADF8, hangulLV},
+	{0xADF9, 0xAE13, hangulLVT},
+	{0xAE14, 0xAE14, hangulLV},
+	{0xAE15, 0xAE2F, hangulLVT},
+	{0xAE30, 0xAE30, hangulLV},
+	{0xAE31, 0xAE4B, hangulLVT},
+	{0xAE4C, 0xAE4C, hangulLV},
+	{0xAE4D, 0xAE67, hangulLVT},
+	{0xAE68, 0xAE68, hangulLV},
+	{0xAE69, 0xAE83, hangulLVT},
+	{0xAE84, 0xAE84, hangulLV},
+	{0xAE85, 0xAE9F, hangulLVT},
+	{0xAEA0, 0xAEA0, hangulLV},
+	{0xAEA1, 0xAEBB, hangulLVT},
+	{0xAEBC, 0xAEBC, hangulLV},
+	{0xAEBD, 0xAED7, hangulLVT},
+	{0xAED8, 0xAED8, hangulLV},
+	{0xAED9, 0xAEF3, hangulLVT},
+	{0xAEF4, 0xAEF4, hangulLV},
+	{0xAEF5, 0xAF0F, hangulLVT},
+	{0xAF10, 0xAF10, hangulLV},
+	{0xAF11, 0xAF2B, hangulLVT},
+	{0xAF2C, 0xAF2C, hangulLV},
+	{0xAF2D, 0xAF47, hangulLVT},
+	{0xAF48, 0xAF48, hangulLV},
+	{0xAF49, 0xAF63, hangulLVT},
+	{0xAF64, 0xAF64, hangulLV},
+	{0xAF65, 0xAF7F, hangulLVT},
+	{0xAF80, 0xAF80, hangulLV},
+	{0xAF81, 0xAF9B, hangulLVT},
+	{0xAF9C, 0xAF9C, hangulLV},
+	{0xAF9D, 0xAFB7, hangulLVT},
+	{0xAFB8, 0xAFB8, hangulLV},
+	{0xAFB9, 0xAFD3, hangulLVT},
+	{0xAFD4, 0xAFD4, hangulLV},
+	{0xAFD5, 0xAFEF, hangulLVT},
+	{0xAFF0, 0xAFF0, hangulLV},
+	{0xAFF1, 0xB00B, hangulLVT},
+	{0xB00C, 0xB00C, hangulLV},
+	{0xB00D, 0xB027, hangulLVT},
+	{0xB028, 0xB028, hangulLV},
+	{0xB029, 0xB043, hangulLVT},
+	{0xB044, 0xB044, hangulLV},
+	{0xB045, 0xB05F, hangulLVT},
+	{0xB060, 0xB060, hangulLV},
+	{0xB061, 0xB07B, hangulLVT},
+	{0xB07C, 0xB07C, hangulLV},
+	{0xB07D, 0xB097, hangulLVT},
+	{0xB098, 0xB098, hangulLV},
+	{0xB099, 0xB0B3, hangulLVT},
+	{0xB0B4, 0xB0B4, hangulLV},
+	{0xB0B5, 0xB0CF, hangulLVT},
+	{0xB0D0, 0xB0D0, hangulLV},
+	{0xB0D1, 0xB0EB, hangulLVT},
+	{0xB0EC, 0xB0EC, hangulLV},
+	{0xB0ED, 0xB107, hangulLVT},
+	{0xB108, 0xB108, hangulLV},
+	{0xB109, 0xB123, hangulLVT},
+	{0xB124, 0xB124, hangulLV},
+	{0xB125, 0xB13F, hangulLVT},
+	{0xB140, 0xB140, hangulLV},
+	{0xB141, 0xB15B, hangulLVT},
+	{0xB15C, 0xB15C, hangulLV},
+	{0xB15D, 0xB177, hangulLVT},
+	{0xB178, 0xB178, hangulLV},
+	{0xB179, 0xB193, hangulLVT},
+	{0xB194, 0xB194, hangulLV},
+	{0xB195, 0xB1AF, hangulLVT},
+	{0xB1B0, 0xB1B0, hangulLV},
+	{0xB1B1, 0xB1CB, hangulLVT},
+	{0xB1CC, 0xB1CC, hangulLV},
+	{0xB1CD, 0xB1E7, hangulLVT},
+	{0xB1E8, 0xB1E8, hangulLV},
+	{0xB1E9, 0xB203, hangulLVT},
+	{0xB204, 0xB204, hangulLV},
+	{0xB205, 0xB21F, hangulLVT},
+	{0xB220, 0xB220, hangulLV},
+	{0xB221, 0xB23B, hangulLVT},
+	{0xB23C, 0xB23C, hangulLV},
+	{0xB23D, 0xB257, hangulLVT},
+	{0xB258, 0xB258, hangulLV},
+	{0xB259, 0xB273, hangulLVT},
+	{0xB274, 0xB274, hangulLV},
+	{0xB275, 0xB28F, hangulLVT},
+	{0xB290, 0xB290, hangulLV},
+	{0xB291, 0xB2AB, hangulLVT},
+	{0xB2AC, 0xB2AC, hangulLV},
+	{0xB2AD, 0xB2C7, hangulLVT},
+	{0xB2C8, 0xB2C8, hangulLV},
+	{0xB2C9, 0xB2E3, hangulLVT},
+	{0xB2E4, 0xB2E4, hangulLV},
+	{0xB2E5, 0xB2FF, hangulLVT},
+	{0xB300, 0xB300, hangulLV},
+	{0xB301, 0xB31B, hangulLVT},
+	{0xB31C, 0xB31C, hangulLV},
+	{0xB31D, 0xB337, hangulLVT},
+	{0xB338, 0xB338, hangulLV},
+	{0xB339, 0xB353, hangulLVT},
+	{0xB354, 0xB354, hangulLV},
+	{0xB355, 0xB36F, hangulLVT},
+	{0xB370, 0xB370, hangulLV},
+	{0xB371, 0xB38B, hangulLVT},
+	{0xB38C, 0xB38C, hangulLV},
+	{0xB38D, 0xB3A7, hangulLVT},
+	{0xB3A8, 0xB3A8, hangulLV},
+	{0xB3A9, 0xB3C3, hangulLVT},
+	{0xB3C4, 0xB3C4, hangulLV},
+	{0xB3C5, 0xB3DF, hangulLVT},
+	{0xB3E0, 0xB3E0, hangulLV},
+	{0xB3E1, 0xB3FB, hangulLVT},
+	{0xB3FC, 0xB3FC, hangulLV},
+	{0xB3FD, 0xB417, hangulLVT},
+	{0xB418, 0xB418, hangulLV},
+	{0xB419, 0xB433, hangulLVT},
+	{0xB434, 0xB434, hangulLV},
+	{0xB435, 0xB44F, hangulLVT},
+	{0xB450, 0xB450, hangulLV},
+	{0xB451, 0xB46B, hangulLVT},
+	{0xB46C, 0xB46C, hangulLV},
+	{0xB46D, 0xB487, hangulLVT},
+	{0xB488, 0xB488, hangulLV},
+	{0xB489, 0xB4A3, hangulLVT},
+	{0xB4A4, 0xB4A4, hangulLV},
+	{0xB4A5, 0xB4BF, hangulLVT},
+	{0xB4C0, 0xB4C0, hangulLV},
+	{0xB4C1, 0xB4DB, hangulLVT},
+	{0xB4DC, 0xB4DC, hangulLV},
+	{0xB4DD, 0xB4F7, hangulLVT},
+	{0xB4F8, 0xB4F8, hangulLV},
+	{0xB4F9, 0xB513, hangulLVT},
+	{0xB514, 0xB514, hangulLV},
+	{0xB515, 0xB52F, hangulLVT},
+	{0xB530, 0xB530, hangulLV},
+	{0xB531, 0xB54B, hangulLVT},
+	{0xB54C, 0xB54C, hangulLV},
+	{0xB54D, 0xB567, hangulLVT},
+	{0xB568, 0xB568, hangulLV},
+	{0xB569, 0xB583, hangulLVT},
+	{0xB584, 0xB584, hangulLV},
+	{0xB585, 0xB59F, hangulLVT},
+	{0xB5A0, 0xB5A0, hangulLV},
+	{0xB5A1, 0xB5BB, hangulLVT},
+	{0xB5BC, 0xB5BC, hangulLV},
+	{0xB5BD, 0xB5D7, hangulLVT},
+	{0xB5D8, 0xB5D8, hangulLV},
+	{0xB5D9, 0xB5F3, hangulLVT},
+	{0xB5F4, 0xB5F4, hangulLV},
+	{0xB5F5, 0xB60F, hangulLVT},
+	{0xB610, 0xB610, hangulLV},
+	{0xB611, 0xB62B, hangulLVT},
+	{0xB62C, 0xB62C, hangulLV},
+	{0xB62D, 0xB647, hangulLVT},
+	{0xB648, 0xB648, hangulLV},
+	{0xB649, 0xB663, hangulLVT},
+	{0xB664, 0xB664, hangulLV},
+	{0xB665, 0xB67F, hangulLVT},
+	{0xB680, 0xB680, hangulLV},
+	{0xB681, 0xB69B, hangulLVT},
+	{0xB69C, 0xB69C, hangulLV},
+	{0xB69D, 0xB6B7, hangulLVT},
+	{0xB6B8, 0xB6B8, hangulLV},
+	{0xB6B9, 0xB6D3, hangulLVT},
+	{0xB6D4, 0xB6D4, hangulLV},
+	{0xB6D5, 0xB6EF, hangulLVT},
+	{0xB6F0, 0xB6F0, hangulLV},
+	{0xB6F1, 0xB70B, hangulLVT},
+	{0xB70C, 0xB70C, hangulLV},
+	{0xB70D, 0xB727, hangulLVT},
+	{0xB728, 0xB728, hangulLV},
+	{0xB729, 0xB743, hangulLVT},
+	{0xB744, 0xB744, hangulLV},
+	{0xB745, 0xB75F, hangulLVT},
+	{0xB760, 0xB760, hangulLV},
+	{0xB761, 0xB77B, hangulLVT},
+	{0xB77C, 0xB77C, hangulLV},
+	{0xB77D, 0xB797, hangulLVT},
+	{0xB798, 0xB798, hangulLV},
+	{0xB799, 0xB7B3, hangulLVT},
+	{0xB7B4, 0xB7B4, hangulLV},
+	{0xB7B5, 0xB7CF, hangulLVT},
+	{0xB7D0, 0xB7D0, hangulLV},
+	{0xB7D1, 0xB7EB, hangulLVT},
+	{0xB7EC, 0xB7EC, hangulLV},
+	{0xB7ED, 0xB807, hangulLVT},
+	{0xB808, 0xB808, hangulLV},
+	{0xB809, 0xB823, hangulLVT},
+	{0xB824, 0xB824, hangulLV},
+	{0xB825, 0xB83F, hangulLVT},
+	{0xB840, 0xB840, hangulLV},
+	{0xB841, 0xB85B, hangulLVT},
+	{0xB85C, 0xB85C, hangulLV},
+	{0xB85D, 0xB877, hangulLVT},
+	{0xB878, 0xB878, hangulLV},
+	{0xB879, 0xB893, hangulLVT},
+	{0xB894, 0xB894, hangulLV},
+	{0xB895, 0xB8AF, hangulLVT},
+	{0xB8B0, 0xB8B0, hangulLV},
+	{0xB8B1, 0xB8CB, hangulLVT},
+	{0xB8CC, 0xB8CC, hangulLV},
+	{0xB8CD, 0xB8E7, hangulLVT},
+	{0xB8E8, 0xB8E8, hangulLV},
+	{0xB8E9, 0xB903, hangulLVT},
+	{0xB904, 0xB904, hangulLV},
+	{0xB905, 0xB91F, hangulLVT},
+	{0xB920, 0xB920, hangulLV},
+	{0xB921, 0xB93B, hangulLVT},
+	{0xB93C, 0xB93C, hangulLV},
+	{0xB93D, 0xB957, hangulLVT},
+	{0xB958, 0xB958, hangulLV},
+	{0xB959, 0xB973, hangulLVT},
+	{0xB974, 0xB974, hangulLV},
+	{0xB975, 0xB98F, hangulLVT},
+	{0xB990, 0xB990, hangulLV},
+	{0xB991, 0xB9AB, hangulLVT},
+	{0xB9AC, 0xB9AC, hangulLV},
+	{0xB9AD, 0xB9C7, hangulLVT},
+	{0xB9C8, 0xB9C8, hangulLV},
+	{0xB9C9, 0xB9E3, hangulLVT},
+	{0xB9E4, 0xB9E4, hangulLV},
+	{0xB9E5, 0xB9FF, hangulLVT},
+	{0xBA00, 0xBA00, hangulLV},
+	{0xBA01, 0xBA1B, hangulLVT},
+	{0xBA1C, 0xBA1C, hangulLV},
+	{0xBA1D, 0xBA37, hangulLVT},
+	{0xBA38, 0xBA38, hangulLV},
+	{0xBA39, 0xBA53, hangulLVT},
+	{0xBA54, 0xBA54, hangulLV},
+	{0xBA55, 0xBA6F, hangulLVT},
+	{0xBA70, 0xBA70, hangulLV},
+	{0xBA71, 0xBA8B, hangulLVT},
+	{0xBA8C, 0xBA8C, hangulLV},
+	{0xBA8D, 0xBAA7, hangulLVT},
+	{0xBAA8, 0xBAA8, hangulLV},
+	{0xBAA9, 0xBAC3, hangulLVT},
+	{0xBAC4, 0xBAC4, hangulLV},
+	{0xBAC5, 0xBADF, hangulLVT},
+	{0xBAE0, 0xBAE0, hangulLV},
+	{0xBAE1, 0xBAFB, hangulLVT},
+	{0xBAFC, 0xBAFC, hangulLV},
+	{0xBAFD, 0xBB17, hangulLVT},
+	{0xBB18, 0xBB18, hangulLV},
+	{0xBB19, 0xBB33, hangulLVT},
+	{0xBB34, 0xBB34, hangulLV},
+	{0xBB35, 0xBB4F, hangulLVT},
+	{0xBB50, 0xBB50, hangulLV},
+	{0xBB51, 0xBB6B, hangulLVT},
+	{0xBB6C, 0xBB6C, hangulLV},
+	{0xBB6D, 0xBB87, hangulLVT},
+	{0xBB88, 0xBB88, hangulLV},
+	{0xBB89, 0xBBA3, hangulLVT},
+	{0xBBA4, 0xBBA4, hangulLV},
+	{0xBBA5, 0xBBBF, hangulLVT},
+	{0xBBC0, 0xBBC0, hangulLV},
+	{0xBBC1, 0xBBDB, hangulLVT},
+	{0xBBDC, 0xBBDC, hangulLV},
+	{0xBBDD, 0xBBF7, hangulLVT},
+	{0xBBF8, 0xBBF8, hangulLV},
+	{0xBBF9, 0xBC13, hangulLVT},
+	{0xBC14, 0xBC14, hangulLV},
+	{0xBC15, 0xBC2F, hangulLVT},
+	{0xBC30, 0xBC30, hangulLV},
+	{0xBC31, 0xBC4B, hangulLVT},
+	{0xBC4C, 0xBC4C, hangulLV},
+	{0xBC4D, 0xBC67, hangulLVT},
+	{0xBC68, 0xBC68, hangulLV},
+	{0xBC69, 0xBC83, hangulLVT},
+	{0xBC84, 0xBC84, hangulLV},
+	{0xBC85, 0xBC9F, hangulLVT},
+	{0xBCA0, 0xBCA0, hangulLV},
+	{0xBCA1, 0xBCBB, hangulLVT},
+	{0xBCBC, 0xBCBC, hangulLV},
+	{0xBCBD, 0xBCD7, hangulLVT},
+	{0xBCD8, 0xBCD8, hangulLV},
+	{0xBCD9, 0xBCF3, hangulLVT},
+	{0xBCF4, 0xBCF4, hangulLV},
+	{0xBCF5, 0xBD0F, hangulLVT},
+	{0xBD10, 0xBD10, hangulLV},
+	{0xBD11, 0xBD2B, hangulLVT},
+	{0xBD2C, 0xBD2C, hangulLV},
+	{0xBD2D, 0xBD47, hangulLVT},
+	{0xBD48, 0xBD48, hangulLV},
+	{0xBD49, 0xBD63, hangulLVT},
+	{0xBD64, 0xBD64, hangulLV},
+	{0xBD65, 0xBD7F, hangulLVT},
+	{0xBD80, 0xBD80, hangulLV},
+	{0xBD81, 0xBD9B, hangulLVT},
+	{0xBD9C, 0xBD9C, hangulLV},
+	{0xBD9D, 0xBDB7, hangulLVT},
+	{0xBDB8, 0xBDB8, hangulLV},
+	{0xBDB9, 0xBDD3, hangulLVT},
+	{0xBDD4, 0xBDD4, hangulLV},
+	{0xBDD5, 0xBDEF, hangulLVT},
+	{0xBDF0, 0xBDF0, hangulLV},
+	{0xBDF1, 0xBE0B, hangulLVT},
+	{0xBE0C, 0xBE0C, hangulLV},
+	{0xBE0D, 0xBE27, hangulLVT},
+	{0xBE28, 0xBE28, hangulLV},
+	{0xBE29, 0xBE43, hangulLVT},
+	{0xBE44, 0xBE44, hangulLV},
+	{0xBE45, 0xBE5F, hangulLVT},
+	{0xBE60, 0xBE60, hangulLV},
+	{0xBE61, 0xBE7B, hangulLVT},
+	{0xBE7C, 0xBE7C, hangulLV},
+	{0xBE7D, 0xBE97, hangulLVT},
+	{0xBE98, 0xBE98, hangulLV},
+	{0xBE99, 0xBEB3, hangulLVT},
+	{0xBEB4, 0xBEB4, hangulLV},
+	{0xBEB5, 0xBECF, hangulLVT},
+	{0xBED0, 0xBED0, hangulLV},
+	{0xBED1, 0xBEEB, hangulLVT},
+	{0xBEEC, 0xBEEC, hangulLV},
+	{0xBEED, 0xBF07, hangulLVT},
+	{0xBF08, 0xBF08, hangulLV},
+	{0xBF09, 0xBF23, hangulLVT},
+	{0xBF24, 0xBF24, hangulLV},
+	{0xBF25, 0xBF3F, hangulLVT},
+	{0xBF40, 0xBF40, hangulLV},
+	{0xBF41, 0xBF5B, hangulLVT},
+	{0xBF5C, 0xBF5C, hangulLV},
+	{0xBF5D, 0xBF77, hangulLVT},
+	{0xBF78, 0xBF78, hangulLV},
+	{0xBF79, 0xBF93, hangulLVT},
+	{0xBF94, 0xBF94, hangulLV},
+	{0xBF95, 0xBFAF, hangulLVT},
+	{0xBFB0, 0xBFB0, hangulLV},
+	{0xBFB1, 0xBFCB, hangulLVT},
+	{0xBFCC, 0xBFCC, hangulLV},
+	{0xBFCD, 0xBFE7, hangulLVT},
+	{0xBFE8, 0xBFE8, hangulLV},
+	{0xBFE9, 0xC003, hangulLVT},
+	{0xC004, 0xC004, hangulLV},
+	{0xC005, 0xC01F, hangulLVT},
+	{0xC020, 0xC020, hangulLV},
+	{0xC021, 0xC03B, hangulLVT},
+	{0xC03C, 0xC03C, hangulLV},
+	{0xC03D, 0xC057, hangulLVT},
+	{0xC058, 0xC058, hangulLV},
+	{0xC059, 0xC073, hangulLVT},
+	{0xC074, 0xC074, hangulLV},
+	{0xC075, 0xC08F, hangulLVT},
+	{0xC090, 0xC090, hangulLV},
+	{0xC091, 0xC0AB, hangulLVT},
+	{0xC0AC, 0xC0AC, hangulLV},
+	{0xC0AD, 0xC0C7, hangulLVT},
+	{0xC0C8, 0xC0C8, hangulLV},
+	{0xC0C9, 0xC0E3, hangulLVT},
+	{0xC0E4, 0xC0E4, hangulLV},
+	{0xC0E5, 0xC0FF, hangulLVT},
+	{0xC100, 0xC100, hangulLV},
+	{0xC101, 0xC11B, hangulLVT},
+	{0xC11C, 0xC11C, hangulLV},
+	{0xC11D, 0xC137, hangulLVT},
+	{0xC138, 0xC138, hangulLV},
+	{0xC139, 0xC153, hangulLVT},
+	{0xC154, 0xC154, hangulLV},
+	{0xC155, 0xC16F, hangulLVT},
+	{0xC170, 0xC170, hangulLV},
+	{0xC171, 0xC18B, hangulLVT},
+	{0xC18C, 0xC18C, hangulLV},
+	{0xC18D, 0xC1A7, hangulLVT},
+	{0xC1A8, 0xC1A8, hangulLV},
+	{0xC1A9, 0xC1C3, hangulLVT},
+	{0xC1C4, 0xC1C4, hangulLV},
+	{0xC1C5, 0xC1DF, hangulLVT},
+	{0xC1E0, 0xC1E0, hangulLV},
+	{0xC1E1, 0xC1FB, hangulLVT},
+	{0xC1FC, 0xC1FC, hangulLV},
+	{0xC1FD, 0xC217, hangulLVT},
+	{0xC218, 0xC218, hangulLV},
+	{0xC219, 0xC233, hangulLVT},
+	{0xC234, 0xC234, hangulLV},
+	{0xC235, 0xC24F, hangulLVT},
+	{0xC250, 0xC250, hangulLV},
+	{0xC251, 0xC26B, hangulLVT},
+	{0xC26C, 0xC26C, hangulLV},
+	{0xC26D, 0xC287, hangulLVT},
+	{0xC288, 0xC288, hangulLV},
+	{0xC289, 0xC2A3, hangulLVT},
+	{0xC2A4, 0xC2A4, hangulLV},
+	{0xC2A5, 0xC2BF, hangulLVT},
+	{0xC2C0, 0xC2C0, hangulLV},
+	{0xC2C1, 0xC2DB, hangulLVT},
+	{0xC2DC, 0xC2DC, hangulLV},
+	{0xC2DD, 0xC2F7, hangulLVT},
+	{0xC2F8, 0xC2F8, hangulLV},
+	{0xC2F9, 0xC313, hangulLVT},
+	{0xC314, 0xC314, hangulLV},
+	{0xC315, 0xC32F, hangulLVT},
+	{0xC330, 0xC330, hangulLV},
+	{0xC331, 0xC34B, hangulLVT},
+	{0xC34C, 0xC34C, hangulLV},
+	{0xC34D, 0xC367, hangulLVT},
+	{0xC368, 0xC368, hangulLV},
+	{0xC369, 0xC383, hangulLVT},
+	{0xC384, 0xC384, hangulLV},
+	{0xC385, 0xC39F, hangulLVT},
+	{0xC3A0, 0xC3A0, hangulLV},
+	{0xC3A1, 0xC3BB, hangulLVT},
+	{0xC3BC, 0xC3BC, hangulLV},
+	{0xC3BD, 0xC3D7, hangulLVT},
+	{0xC3D8, 0xC3D8, hangulLV},
+	{0xC3D9, 0xC3F3, hangulLVT},
+	{0xC3F4, 0xC3F4, hangulLV},
+	{0xC3F5, 0xC40F, hangulLVT},
+	{0xC410, 0xC410, hangulLV},
+	{0xC411, 0xC42B, hangulLVT},
+	{0xC42C, 0xC42C, hangulLV},
+	{0xC42D, 0xC447, hangulLVT},
+	{0xC448, 0xC448, hangulLV},
+	{0xC449, 0xC463, hangulLVT},
+	{0xC464, 0xC464, hangulLV},
+	{0xC465, 0xC47F, hangulLVT},
+	{0xC480, 0xC480, hangulLV},
+	{0xC481, 0xC49B, hangulLVT},
+	{0xC49C, 0xC49C, hangulLV},
+	{0xC49D, 0xC4B7, hangulLVT},
+	{0xC4B8, 0xC4B8, hangulLV},
+	{0xC4B9, 0xC4D3, hangulLVT},
+	{0xC4D4, 0xC4D4, hangulLV},
+	{0xC4D5, 0xC4EF, hangulLVT},
+	{0xC4F0, 0xC4F0, hangulLV},
+	{0xC4F1, 0xC50B, hangulLVT},
+	{0xC50C, 0xC50C, hangulLV},
+	{0xC50D, 0xC527, hangulLVT},
+	{0xC528, 0xC528, hangulLV},
+	{0xC529, 0xC543, hangulLVT},
+	{0xC544, 0xC544, hangulLV},
+	{0xC545, 0xC55F, hangulLVT},
+	{0xC560, 0xC560, hangulLV},
+	{0xC561, 0xC57B, hangulLVT},
+	{0xC57C, 0xC57C, hangulLV},
+	{0xC57D, 0xC597, hangulLVT},
+	{0xC598, 0xC598, hangulLV},
+	{0xC599, 0xC5B3, hangulLVT},
+	{0xC5B4, 0xC5B4, hangulLV},
+	{0xC5B5, 0xC5CF, hangulLVT},
+	{0xC5D0, 0xC5D0, hangulLV},
+	{0xC5D1, 0xC5EB, hangulLVT},
+	{0xC5EC, 0xC5EC, hangulLV},
+	{0xC5ED, 0xC607, hangulLVT},
+	{0xC608, 0xC608, hangulLV},
+	{0xC609, 0xC623, hangulLVT},
+	{0xC624, 0xC624, hangulLV},
+	{0xC625, 0xC63F, hangulLVT},
+	{0xC640, 0xC640, hangulLV},
+	{0xC641, 0xC65B, hangulLVT},
+	{0xC65C, 0xC65C, hangulLV},
+	{0xC65D, 0xC677, hangulLVT},
+	{0xC678, 0xC678, hangulLV},
+	{0xC679, 0xC693, hangulLVT},
+	{0xC694, 0xC694, hangulLV},
+	{0xC695, 0xC6AF, hangulLVT},
+	{0xC6B0, 0xC6B0, hangulLV},
+	{0xC6B1, 0xC6CB, hangulLVT},
+	{0xC6CC, 0xC6CC, hangulLV},
+	{0xC6CD, 0xC6E7, hangulLVT},
+	{0xC6E8, 0xC6E8, hangulLV},
+	{0xC6E9, 0xC703, hangulLVT},
+	{0xC704, 0xC704, hangulLV},
+	{0xC705, 0xC71F, hangulLVT},
+	{0xC720, 0xC720, hangulLV},
+	{0xC721, 0xC73B, hangulLVT},
+	{0xC73C, 0xC73C, hangulLV},
+	{0xC73D, 0xC757, hangulLVT},
+	{0xC758, 0xC758, hangulLV},
+	{0xC759, 0xC773, hangulLVT},
+	{0xC774, 0xC774, hangulLV},
+	{0xC775, 0xC78F, hangulLVT},
+	{0xC790, 0xC790, hangulLV},
+	{0xC791, 0xC7AB, hangulLVT},
+	{0xC7AC, 0xC7AC, hangulLV},
+	{0xC7AD, 0xC7C7, hangulLVT},
+	{0xC7C8, 0xC7C8, hangulLV},
+	{0xC7C9, 0xC7E3, hangulLVT},
+	{0xC7E4, 0xC7E4, hangulLV},
+	{0xC7E5, 0xC7FF, hangulLVT},
+	{0xC800, 0xC800, hangulLV},
+	{0xC801, 0xC81B, hangulLVT},
+	{0xC81C, 0xC81C, hangulLV},
+	{0xC81D, 0xC837, hangulLVT},
+	{0xC838, 0xC838, hangulLV},
+	{0xC839, 0xC853, hangulLVT},
+	{0xC854, 0xC854, hangulLV},
+	{0xC855, 0xC86F, hangulLVT},
+	{0xC870, 0xC870, hangulLV},
+	{0xC871, 0xC88B, hangulLVT},
+	{0xC88C, 0xC88C, hangulLV},
+	{0xC88D, 0xC8A7, hangulLVT},
+	{0xC8A8, 0xC8A8, hangulLV},
+	{0xC8A9, 0xC8C3, hangulLVT},
+	{0xC8C4, 0xC8C4, hangulLV},
+	{0xC8C5, 0xC8DF, hangulLVT},
+	{0xC8E0, 0xC8E0, hangulLV},
+	{0xC8E1, 0xC8FB, hangulLVT},
+	{0xC8FC, 0xC8FC, hangulLV},
+	{0xC8FD, 0xC917, hangulLVT},
+	{0xC918, 0xC918, hangulLV},
+	{0xC919, 0xC933, hangulLVT},
+	{0xC934, 0xC934, hangulLV},
+	{0xC935, 0xC94F, hangulLVT},
+	{0xC950, 0xC950, hangulLV},
+	{0xC951, 0xC96B, hangulLVT},
+	{0xC96C, 0xC96C, hangulLV},
+	{0xC96D, 0xC987, hangulLVT},
+	{0xC988, 0xC988, hangulLV},
+	{0xC989, 0xC9A3, hangulLVT},
+	{0xC9A4, 0xC9A4, hangulLV},
+	{0xC9A5, 0xC9BF, hangulLVT},
+	{0xC9C0, 0xC9C0, hangulLV},
+	{0xC9C1, 0xC9DB, hangulLVT},
+	{0xC9DC, 0xC9DC, hangulLV},
+	{0xC9DD, 0xC9F7, hangulLVT},
+	{0xC9F8, 0xC9F8, hangulLV},
+	{0xC9F9, 0xCA13, hangulLVT},
+	{0xCA14, 0xCA14, hangulLV},
+	{0xCA15, 0xCA2F, hangulLVT},
+	{0xCA30, 0xCA30, hangulLV},
+	{0xCA31, 0xCA4B, hangulLVT},
+	{0xCA4C, 0xCA4C, hangulLV},
+	{0xCA4D, 0xCA67, hangulLVT},
+	{0xCA68, 0xCA68, hangulLV},
+	{0xCA69, 0xCA83, hangulLVT},
+	{0xCA84, 0xCA84, hangulLV},
+	{0xCA85, 0xCA9F, hangulLVT},
+	{0xCAA0, 0xCAA0, hangulLV},
+	{0xCAA1, 0xCABB, hangulLVT},
+	{0xCABC, 0xCABC, hangulLV},
+	{0xCABD, 0xCAD7, hangulLVT},
+	{0xCAD8, 0xCAD8, hangulLV},
+	{0xCAD9, 0xCAF3, hangulLVT},
+	{0xCAF4, 0xCAF4, hangulLV},
+	{0xCAF5, 0xCB0F, hangulLVT},
+	{0xCB10, 0xCB10, hangulLV},
+	{0xCB11, 0xCB2B, hangulLVT},
+	{0xCB2C, 0xCB2C, hangulLV},
+	{0xCB2D, 0xCB47, hangulLVT},
+	{0xCB48, 0xCB48, hangulLV},
+	{0xCB49, 0xCB63, hangulLVT},
+	{0xCB64, 0xCB64, hangulLV},
+	{0xCB65, 0xCB7F, hangulLVT},
+	{0xCB80, 0xCB80, hangulLV},
+	{0xCB81, 0xCB9B, hangulLVT},
+	{0xCB9C, 0xCB9C, hangulLV},
+	{0xCB9D, 0xCBB7, hangulLVT},
+	{0xCBB8, 0xCBB8, hangulLV},
+	{0xCBB9, 0xCBD3, hangulLVT},
+	{0xCBD4, 0xCBD4, hangulLV},
+	{0xCBD5, 0xCBEF, hangulLVT},
+	{0xCBF0, 0xCBF0, hangulLV},
+	{0xCBF1, 0xCC0B, hangulLVT},
+	{0xCC0C, 0xCC0C, hangulLV},
+	{0xCC0D, 0xCC27, hangulLVT},
+	{0xCC28, 0xCC28, hangulLV},
+	{0xCC29, 0xCC43, hangulLVT},
+	{0xCC44, 0xCC44, hangulLV},
+	{0xCC45, 0xCC5F, hangulLVT},
+	{0xCC60, 0xCC60, hangulLV},
+	{0xCC61, 0xCC7B, hangulLVT},
+	{0xCC7C, 0xCC7C, hangulLV},
+	{0xCC7D, 0xCC97, hangulLVT},
+	{0xCC98, 0xCC98, hangulLV},
+	{0xCC99, 0xCCB3, hangulLVT},
+	{0xCCB4, 0xCCB4, hangulLV},
+	{0xCCB5, 0xCCCF, hangulLVT},
+	{0xCCD0, 0xCCD0, hangulLV},
+	{0xCCD1, 0xCCEB, hangulLVT},
+	{0xCCEC, 0xCCEC, hangulLV},
+	{0xCCED, 0xCD07, hangulLVT},
+	{0xCD08, 0xCD08, hangulLV},
+	{0xCD09, 0xCD23, hangulLVT},
+	{0xCD24, 0xCD24, hangulLV},
+	{0xCD25, 0xCD3F, hangulLVT},
+	{0xCD40, 0xCD40, hangulLV},
+	{0xCD41, 0xCD5B, hangulLVT},
+	{0xCD5C, 0xCD5C, hangulLV},
+	{0xCD5D, 0xCD77, hangulLVT},
+	{0xCD78, 0xCD78, hangulLV},
+	{0xCD79, 0xCD93, hangulLVT},
+	{0xCD94, 0xCD94, hangulLV},
+	{0xCD95, 0xCDAF, hangulLVT},
+	{0xCDB0, 0xCDB0, hangulLV},
+	{0xCDB1, 0xCDCB, hangulLVT},
+	{0xCDCC, 0xCDCC, hangulLV},
+	{0xCDCD, 0xCDE7, hangulLVT},
+	{0xCDE8, 0xCDE8, hangulLV},
+	{0xCDE9, 0xCE03, hangulLVT},
+	{0xCE04, 0xCE04, hangulLV},
+	{0xCE05, 0xCE1F, hangulLVT},
+	{0xCE20, 0xCE20, hangulLV},
+	{0xCE21, 0xCE3B, hangulLVT},
+	{0xCE3C, 0xCE3C, hangulLV},
+	{0xCE3D, 0xCE57, hangulLVT},
+	{0xCE58, 0xCE58, hangulLV},
+	{0xCE59, 0xCE73, hangulLVT},
+	{0xCE74, 0xCE74, hangulLV},
+	{0xCE75, 0xCE8F, hangulLVT},
+	{0xCE90, 0xCE90, hangulLV},
+	{0xCE91, 0xCEAB, hangulLVT},
+	{0xCEAC, 0xCEAC, hangulLV},
+	{0xCEAD, 0xCEC7, hangulLVT},
+	{0xCEC8, 0xCEC8, hangulLV},
+	{0xCEC9, 0xCEE3, hangulLVT},
+	{0xCEE4, 0xCEE4, hangulLV},
+	{0xCEE5, 0xCEFF, hangulLVT},
+	{0xCF00, 0xCF00, hangulLV},
+	{0xCF01, 0xCF1B, hangulLVT},
+	{0xCF1C, 0xCF1C, hangulLV},
+	{0xCF1D, 0xCF37, hangulLVT},
+	{0xCF38, 0xCF38, hangulLV},
+	{0xCF39, 0xCF53, hangulLVT},
+	{0xCF54, 0xCF54, hangulLV},
+	{0xCF55, 0xCF6F, hangulLVT},
+	{0xCF70, 0xCF70, hangulLV},
+	{0xCF71, 0xCF8B, hangulLVT},
+	{0xCF8C, 0xCF8C, hangulLV},
+	{0xCF8D, 0xCFA7, hangulLVT},
+	{0xCFA8, 0xCFA8, hangulLV},
+	{0xCFA9, 0xCFC3, hangulLVT},
+	{0xCFC4, 0xCFC4, hangulLV},
+	{0xCFC5, 0xCFDF, hangulLVT},
+	{0xCFE0, 0xCFE0, hangulLV},
+	{0xCFE1, 0xCFFB, hangulLVT},
+	{0xCFFC, 0xCFFC, hangulLV},
+	{0xCFFD, 0xD017, hangulLVT},
+	{0xD018, 0xD018, hangulLV},
+	{0xD019, 0xD033, hangulLVT},
+	{0xD034, 0xD034, hangulLV},
+	{0xD035, 0xD04F, hangulLVT},
+	{0xD050, 0xD050, hangulLV},
+	{0xD051, 0xD06B, hangulLVT},
+	{0xD06C, 0xD06C, hangulLV},
+	{0xD06D, 0xD087, hangulLVT},
+	{0xD088, 0xD088, hangulLV},
+	{0xD089, 0xD0A3, hangulLVT},
+	{0xD0A4, 0xD0A4, hangulLV},
+	{0xD0A5, 0xD0BF, hangulLVT},
+	{0xD0C0, 0xD0C0, hangulLV},
+	{0xD0C1, 0xD0DB, hangulLVT},
+	{0xD0DC, 0xD0DC, hangulLV},
+	{0xD0DD, 0xD0F7, hangulLVT},
+	{0xD0F8, 0xD0F8, hangulLV},
+	{0xD0F9, 0xD113, hangulLVT},
+	{0xD114, 0xD114, hangulLV},
+	{0xD115, 0xD12F, hangulLVT},
+	{0xD130, 0xD130, hangulLV},
+	{0xD131, 0xD14B, hangulLVT},
+	{0xD14C, 0xD14C, hangulLV},
+	{0xD14D, 0xD167, hangulLVT},
+	{0xD168, 0xD168, hangulLV},
+	{0xD169, 0xD183, hangulLVT},
+	{0xD184, 0xD184, hangulLV},
+	{0xD185, 0xD19F, hangulLVT},
+	{0xD1A0, 0xD1A0, hangulLV},
+	{0xD1A1, 0xD1BB, hangulLVT},
+	{0xD1BC, 0xD1BC, hangulLV},
+	{0xD1BD, 0xD1D7, hangulLVT},
+	{0xD1D8, 0xD1D8, hangulLV},
+	{0xD1D9, 0xD1F3, hangulLVT},
+	{0xD1F4, 0xD1F4, hangulLV},
+	{0xD1F5, 0xD20F, hangulLVT},
+	{0xD210, 0xD210, hangulLV},
+	{0xD211, 0xD22B, hangulLVT},
+	{0xD22C, 0xD22C, hangulLV},
+	{0xD22D, 0xD247, hangulLVT},
+	{0xD248, 0xD248, hangulLV},
+	{0xD249, 0xD263, hangulLVT},
+	{0xD264, 0xD264, hangulLV},
+	{0xD265, 0xD27F, hangulLVT},
+	{0xD280, 0xD280, hangulLV},
+	{0xD281, 0xD29B, hangulLVT},
+	{0xD29C, 0xD29C, hangulLV},
+	{0xD29D, 0xD2B7, hangulLVT},
+	{0xD2B8, 0xD2B8, hangulLV},
+	{0xD2B9, 0xD2D3, hangulLVT},
+	{0xD2D4, 0xD2D4, hangulLV},
+	{0xD2D5, 0xD2EF, hangulLVT},
+	{0xD2F0, 0xD2F0, hangulLV},
+	{0xD2F1, 0xD30B, hangulLVT},
+	{0xD30C, 0xD30C, hangulLV},
+	{0xD30D, 0xD327, hangulLVT},
+	{0xD328, 0xD328, hangulLV},
+	{0xD329, 0xD343, hangulLVT},
+	{0xD344, 0xD344, hangulLV},
+	{0xD345, 0xD35F, hangulLVT},
+	{0xD360, 0xD360, hangulLV},
+	{0xD361, 0xD37B, hangulLVT},
+	{0xD37C, 0xD37C, hangulLV},
+	{0xD37D, 0xD397, hangulLVT},
+	{0xD398, 0xD398, hangulLV},
+	{0xD399, 0xD3B3, hangulLVT},
+	{0xD3B4, 0xD3B4, hangulLV},
+	{0xD3B5, 0xD3CF, hangulLVT},
+	{0xD3D0, 0xD3D0, hangulLV},
+	{0xD3D1, 0xD3EB, hangulLVT},
+	{0xD3EC, 0xD3EC, hangulLV},
+	{0xD3ED, 0xD407, hangulLVT},
+	{0xD408, 0xD408, hangulLV},
+	{0xD409, 0xD423, hangulLVT},
+	{0xD424, 0xD424, hangulLV},
+	{0xD425, 0xD43F, hangulLVT},
+	{0xD440, 0xD440, hangulLV},
+	{0xD441, 0xD45B, hangulLVT},
+	{0xD45C, 0xD45C, hangulLV},
+	{0xD45D, 0xD477, hangulLVT},
+	{0xD478, 0xD478, hangulLV},
+	{0xD479, 0xD493, hangulLVT},
+	{0xD494, 0xD494, hangulLV},
+	{0xD495, 0xD4AF, hangulLVT},
+	{0xD4B0, 0xD4B0, hangulLV},
+	{0xD4B1, 0xD4CB, hangulLVT},
+	{0xD4CC, 0xD4CC, hangulLV},
+	{0xD4CD, 0xD4E7, hangulLVT},
+	{0xD4E8, 0xD4E8, hangulLV},
+	{0xD4E9, 0xD503, hangulLVT},
+	{0xD504, 0xD504, hangulLV},
+	{0xD505, 0xD51F, hangulLVT},
+	{0xD520, 0xD520, hangulLV},
+	{0xD521, 0xD53B, hangulLVT},
+	{0xD53C, 0xD53C, hangulLV},
+	{0xD53D, 0xD557, hangulLVT},
+	{0xD558, 0xD558, hangulLV},
+	{0xD559, 0xD573, hangulLVT},
+	{0xD574, 0xD574, hangulLV},
+	{0xD575, 0xD58F, hangulLVT},
+	{0xD590, 0xD590, hangulLV},
+	{0xD591, 0xD5AB, hangulLVT},
+	{0xD5AC, 0xD5AC, hangulLV},
+	{0xD5AD, 0xD5C7, hangulLVT},
+	{0xD5C8, 0xD5C8, hangulLV},
+	{0xD5C9, 0xD5E3, hangulLVT},
+	{0xD5E4, 0xD5E4, hangulLV},
+	{0xD5E5, 0xD5FF, hangulLVT},
+	{0xD600, 0xD600, hangulLV},
+	{0xD601, 0xD61B, hangulLVT},
+	{0xD61C, 0xD61C, hangulLV},
+	{0xD61D, 0xD637, hangulLVT},
+	{0xD638, 0xD638, hangulLV},
+	{0xD639, 0xD653, hangulLVT},
+	{0xD654, 0xD654, hangulLV},
+	{0xD655, 0xD66F, hangulLVT},
+	{0xD670, 0xD670, hangulLV},
+	{0xD671, 0xD68B, hangulLVT},
+	{0xD68C, 0xD68C, hangulLV},
+	{0xD68D, 0xD6A7, hangulLVT},
+	{0xD6A8, 0xD6A8, hangulLV},
+	{0xD6A9, 0xD6C3, hangulLVT},
+	{0xD6C4, 0xD6C4, hangulLV},
+	{0xD6C5, 0xD6DF, hangulLVT},
+	{0xD6E0, 0xD6E0, hangulLV},
+	{0xD6E1, 0xD6FB, hangulLVT},
+	{0xD6FC, 0xD6FC, hangulLV},
+	{0xD6FD, 0xD717, hangulLVT},
+	{0xD718, 0xD718, hangulLV},
+	{0xD719, 0xD733, hangulLVT},
+	{0xD734, 0xD734, hangulLV},
+	{0xD735, 0xD74F, hangulLVT},
+	{0xD750, 0xD750, hangulLV},
+	{0xD751, 0xD76B, hangulLVT},
+	{0xD76C, 0xD76C, hangulLV},
+	{0xD76D, 0xD787, hangulLVT},
+	{0xD788, 0xD788, hangulLV},
+	{0xD789, 0xD7A3, hangulLVT},
+	{0xD7B0, 0xD7C6, hangulV},
+	{0xD7CB, 0xD7FB, hangulT},
+	{0xFB1E, 0xFB1E, extend},
+	{0xFE00, 0xFE0F, extend},
+	{0xFE20, 0xFE2F, extend},
+	{0xFEFF, 0xFEFF, control},
+	{0xFF9E, 0xFF9F, extend},
+	{0xFFF0, 0xFFFB, control},
+	{0x101FD, 0x101FD, extend},
+	{0x102E0, 0x102E0, extend},
+	{0x10376, 0x1037A, extend},
+	{0x10A01, 0x10A03, extend},
+	{0x10A05, 0x10A06, extend},
+	{0x10A0C, 0x10A0F, extend},
+	{0x10A38, 0x10A3A, extend},
+	{0x10A3F, 0x10A3F, extend},
+	{0x10AE5, 0x10AE6, extend},
+	{0x10D24, 0x10D27, extend},
+	{0x10EAB, 0x10EAC, extend},
+	{0x10F46, 0x10F50, extend},
+	{0x10F82, 0x10F85, extend},
+	{0x11000, 0x11000, spacingMark},
+	{0x11001, 0x11001, extend},
+	{0x11002, 0x11002, spacingMark},
+	{0x11038, 0x11046, extend},
+	{0x11070, 0x11070, extend},
+	{0x11073, 0x11074, extend},
+	{0x1107F, 0x11081, extend},
+	{0x11082, 0x11082, spacingMark},
+	{0x110B0, 0x110B2, spacingMark},
+	{0x110B3, 0x110B6, extend},
+	{0x110B7, 0x110B8, spacingMark},
+	{0x110B9, 0x110BA, extend},
+	{0x110BD, 0x110BD, prepend},
+	{0x110C2, 0x110C2, extend},
+	{0x110CD, 0x110CD, prepend},
+	{0x11100, 0x11102, extend},
+	{0x11127, 0x1112B, extend},
+	{0x1112C, 0x1112C, spacingMark},
+	{0x1112D, 0x11134, extend},
+	{0x11145, 0x11146, spacingMark},
+	{0x11173, 0x11173, extend},
+	{0x11180, 0x11181, extend},
+	{0x11182, 0x11182, spacingMark},
+	{0x111B3, 0x111B5, spacingMark},
+	{0x111B6, 0x111BE, extend},
+	{0x111BF, 0x111C0, spacingMark},
+	{0x111C2, 0x111C3, prepend},
+	{0x111C9, 0x111CC, extend},
+	{0x111CE, 0x111CE, spacingMark},
+	{0x111CF, 0x111CF, extend},
+	{0x1122C, 0x1122E, spacingMark},
+	{0x1122F, 0x11231, extend},
+	{0x11232, 0x11233, spacingMark},
+	{0x11234, 0x11234, extend},
+	{0x11235, 0x11235, spacingMark},
+	{0x11236, 0x11237, extend},
+	{0x1123E, 0x1123E, extend},
+	{0x112DF, 0x112DF, extend},
+	{0x112E0, 0x112E2, spacingMark},
+	{0x112E3, 0x112EA, extend},
+	{0x11300, 0x11301, extend},
+	{0x11302, 0x11303, spacingMark},
+	{0x1133B, 0x1133C, extend},
+	{0x1133E, 0x1133E, extend},
+	{0x1133F, 0x1133F, spacingMark},
+	{0x11340, 0x11340, extend},
+	{0x11341, 0x11344, spacingMark},
+	{0x11347, 0x11348, spacingMark},
+	{0x1134B, 0x1134D, spacingMark},
+	{0x11357, 0x11357, extend},
+	{0x11362, 0x11363, spacingMark},
+	{0x11366, 0x1136C, extend},
+	{0x11370, 0x11374, extend},
+	{0x11435, 0x11437, spacingMark},
+	{0x11438, 0x1143F, extend},
+	{0x11440, 0x11441, spacingMark},
+	{0x11442, 0x11444, extend},
+	{0x11445, 0x11445, spacingMark},
+	{0x11446, 0x11446, extend},
+	{0x1145E, 0x1145E, extend},
+	{0x114B0, 0x114B0, extend},
+	{0x114B1, 0x114B2, spacingMark},
+	{0x114B3, 0x114B8, extend},
+	{0x114B9, 0x114B9, spacingMark},
+	{0x114BA, 0x114BA, extend},
+	{0x114BB, 0x114BC, spacingMark},
+	{0x114BD, 0x114BD, extend},
+	{0x114BE, 0x114BE, spacingMark},
+	{0x114BF, 0x114C0, extend},
+	{0x114C1, 0x114C1, spacingMark},
+	{0x114C2, 0x114C3, extend},
+	{0x115AF, 0x115AF, extend},
+	{0x115B0, 0x115B1, spacingMark},
+	{0x115B2, 0x115B5, extend},
+	{0x115B8, 0x115BB, spacingMark},
+	{0x115BC, 0x115BD, extend},
+	{0x115BE, 0x115BE, spacingMark},
+	{0x115BF, 0x115C0, extend},
+	{0x115DC, 0x115DD, extend},
+	{0x11630, 0x11632, spacingMark},
+	{0x11633, 0x1163A, extend},
+	{0x1163B, 0x1163C, spacingMark},
+	{0x1163D, 0x1163D, extend},
+	{0x1163E, 0x1163E, spacingMark},
+	{0x1163F, 0x11640, extend},
+	{0x116AB, 0x116AB, extend},
+	{0x116AC, 0x116AC, spacingMark},
+	{0x116AD, 0x116AD, extend},
+	{0x116AE, 0x116AF, spacingMark},
+	{0x116B0, 0x116B5, extend},
+	{0x116B6, 0x116B6, spacingMark},
+	{0x116B7, 0x116B7, extend},
+	{0x1171D, 0x1171F, extend},
+	{0x11722, 0x11725, extend},
+	{0x11726, 0x11726, spacingMark},
+	{0x11727, 0x1172B, extend},
+	{0x1182C, 0x1182E, spacingMark},
+	{0x1182F, 0x11837, extend},
+	{0x11838, 0x11838, spacingMark},
+	{0x11839, 0x1183A, extend},
+	{0x11930, 0x11930, extend},
+	{0x11931, 0x11935, spacingMark},
+	{0x11937, 0x11938, spacingMark},
+	{0x1193B, 0x1193C, extend},
+	{0x1193D, 0x1193D, spacingMark},
+	{0x1193E, 0x1193E, extend},
+	{0x1193F, 0x1193F, prepend},
+	{0x11940, 0x11940, spacingMark},
+	{0x11941, 0x11941, prepend},
+	{0x11942, 0x11942, spacingMark},
+	{0x11943, 0x11943, extend},
+	{0x119D1, 0x119D3, spacingMark},
+	{0x119D4, 0x119D7, extend},
+	{0x119DA, 0x119DB, extend},
+	{0x119DC, 0x119DF, spacingMark},
+	{0x119E0, 0x119E0, extend},
+	{0x119E4, 0x119E4, spacingMark},
+	{0x11A01, 0x11A0A, extend},
+	{0x11A33, 0x11A38, extend},
+	{0x11A39, 0x11A39, spacingMark},
+	{0x11A3A, 0x11A3A, prepend},
+	{0x11A3B, 0x11A3E, extend},
+	{0x11A47, 0x11A47, extend},
+	{0x11A51, 0x11A56, extend},
+	{0x11A57, 0x11A58, spacingMark},
+	{0x11A59, 0x11A5B, extend},
+	{0x11A84, 0x11A89, prepend},
+	{0x11A8A, 0x11A96, extend},
+	{0x11A97, 0x11A97, spacingMark},
+	{0x11A98, 0x11A99, extend},
+	{0x11C2F, 0x11C2F, spacingMark},
+	{0x11C30, 0x11C36, extend},
+	{0x11C38, 0x11C3D, extend},
+	{0x11C3E, 0x11C3E, spacingMark},
+	{0x11C3F, 0x11C3F, extend},
+	{0x11C92, 0x11CA7, extend},
+	{0x11CA9, 0x11CA9, spacingMark},
+	{0x11CAA, 0x11CB0, extend},
+	{0x11CB1, 0x11CB1, spacingMark},
+	{0x11CB2, 0x11CB3, extend},
+	{0x11CB4, 0x11CB4, spacingMark},
+	{0x11CB5, 0x11CB6, extend},
+	{0x11D31, 0x11D36, extend},
+	{0x11D3A, 0x11D3A, extend},
+	{0x11D3C, 0x11D3D, extend},
+	{0x11D3F, 0x11D45, extend},
+	{0x11D46, 0x11D46, prepend},
+	{0x11D47, 0x11D47, extend},
+	{0x11D8A, 0x11D8E, spacingMark},
+	{0x11D90, 0x11D91, extend},
+	{0x11D93, 0x11D94, spacingMark},
+	{0x11D95, 0x11D95, extend},
+	{0x11D96, 0x11D96, spacingMark},
+	{0x11D97, 0x11D97, extend},
+	{0x11EF3, 0x11EF4, extend},
+	{0x11EF5, 0x11EF6, spacingMark},
+	{0x13430, 0x13438, control},
+	{0x16AF0, 0x16AF4, extend},
+	{0x16B30, 0x16B36, extend},
+	{0x16F4F, 0x16F4F, extend},
+	{0x16F51, 0x16F87, spacingMark},
+	{0x16F8F, 0x16F92, extend},
+	{0x16FE4, 0x16FE4, extend},
+	{0x16FF0, 0x16FF1, spacingMark},
+	{0x1BC9D, 0x1BC9E, extend},
+	{0x1BCA0, 0x1BCA3, control},
+	{0x1CF00, 0x1CF2D, extend},
+	{0x1CF30, 0x1CF46, extend},
+	{0x1D165, 0x1D165, extend},
+	{0x1D166, 0x1D166, spacingMark},
+	{0x1D167, 0x1D169, extend},
+	{0x1D16D, 0x1D16D, spacingMark},
+	{0x1D16E, 0x1D172, extend},
+	{0x1D173, 0x1D17A, control},
+	{0x1D17B, 0x1D182, extend},
+	{0x1D185, 0x1D18B, extend},
+	{0x1D1AA, 0x1D1AD, extend},
+	{0x1D242, 0x1D244, extend},
+	{0x1DA00, 0x1DA36, extend},
+	{0x1DA3B, 0x1DA6C, extend},
+	{0x1DA75, 0x1DA75, extend},
+	{0x1DA84, 0x1DA84, extend},
+	{0x1DA9B, 0x1DA9F, extend},
+	{0x1DAA1, 0x1DAAF, extend},
+	{0x1E000, 0x1E006, extend},
+	{0x1E008, 0x1E018, extend},
+	{0x1E01B, 0x1E021, extend},
+	{0x1E023, 0x1E024, extend},
+	{0x1E026, 0x1E02A, extend},
+	{0x1E130, 0x1E136, extend},
+	{0x1E2AE, 0x1E2AE, extend},
+	{0x1E2EC, 0x1E2EF, extend},
+	{0x1E8D0, 0x1E8D6, extend},
+	{0x1E944, 0x1E94A, extend},
+	{0x1F1E6, 0x1F1FF, regionalIndicator},
+	{0x1F3FB, 0x1F3FF, extend},
+	{0xE0000, 0xE001F, control},
+	{0xE0020, 0xE007F, extend},
+	{0xE0080, 0xE00FF, control},
+	{0xE0100, 0xE01EF, extend},
+	{0xE01F0, 0xE0FFF, control},
+}
+
+// extendedPictographic 为 Extended_Pictographic 属性
+var extendedPictographic = []runeRange{
+	{0x00A9, 0x00A9},
+	{0x00AE, 0x00AE},
+	{0x203C, 0x203C},
+	{0x2049, 0x2049},
+	{0x2122, 0x2122},
+	{0x2139, 0x2139},
+	{0x2194, 0x2199},
+	{0x21A9, 0x21AA},
+	{0x231A, 0x231B},
+	{0x2328, 0x2328},
+	{0x2388, 0x2388},
+	{0x23CF, 0x23CF},
+	{0x23E9, 0x23F3},
+	{0x23F8, 0x23FA},
+	{0x24C2, 0x24C2},
+	{0x25AA, 0x25AB},
+	{0x25B6, 0x25B6},
+	{0x25C0, 0x25C0},
+	{0x25FB, 0x25FE},
+	{0x2600, 0x2605},
+	{0x2607, 0x2612},
+	{0x2614, 0x2685},
+	{0x2690, 0x2705},
+	{0x2708, 0x2712},
+	{0x2714, 0x2714},
+	{0x2716, 0x2716},
+	{0x271D, 0x271D},
+	{0x2721, 0x2721},
+	{0x2728, 0x2728},
+	{0x2733, 0x2734},
+	{0x2744, 0x2744},
+	{0x2747, 0x2747},
+	{0x274C, 0x274C},
+	{0x274E, 0x274E},
+	{0x2753, 0x2755},
+	{0x2757, 0x2757},
+	{0x2763, 0x2767},
+	{0x2795, 0x2797},
+	{0x27A1, 0x27A1},
+	{0x27B0, 0x27B0},
+	{0x27BF, 0x27BF},
+	{0x2934, 0x2935},
+	{0x2B05, 0x2B07},
+	{0x2B1B, 0x2B1C},
+	{0x2B50, 0x2B50},
+	{0x2B55, 0x2B55},
+	{0x3030, 0x3030},
+	{0x303D, 0x303D},
+	{0x3297, 0x3297},
+	{0x3299, 0x3299},
+	{0x1F000, 0x1F0FF},
+	{0x1F10D, 0x1F10F},
+	{0x1F12F, 0x1F12F},
+	{0x1F16C, 0x1F171},
+	{0x1F17E, 0x1F17F},
+	{0x1F18E, 0x1F18E},
+	{0x1F191, 0x1F19A},
+	{0x1F1AD, 0x1F1E5},
+	{0x1F201, 0x1F20F},
+	{0x1F21A, 0x1F21A},
+	{0x1F22F, 0x1F22F},
+	{0x1F232, 0x1F23A},
+	{0x1F23C, 0x1F23F},
+	{0x1F249, 0x1F3FA},
+	{0x1F400, 0x1F53D},
+	{0x1F546, 0x1F64F},
+	{0x1F680, 0x1F6FF},
+	{0x1F774, 0x1F77F},
+	{0x1F7D5, 0x1F7FF},
+	{0x1F80C, 0x1F80F},
+	{0x1F848, 0x1F84F},
+	{0x1F85A, 0x1F85F},
+	{0x1F888, 0x1F88F},
+	{0x1F8AE, 0x1F8FF},
+	{0x1F90C, 0x1F93A},
+	{0x1F93C, 0x1F945},
+	{0x1F947, 0x1FAFF},
+	{0x1FC00, 0x1FFFD},
+}
+
+// emojiPresentation 为 Emoji_Presentation 属性，默认以 emoji 形式显示的字符
+var emojiPresentation = []runeRange{
+	{0x231A, 0x231B},
+	{0x23E9, 0x23EC},
+	{0x23F0, 0x23F0},
+	{0x23F3, 0x23F3},
+	{0x25FD, 0x25FE},
+	{0x2614, 0x2615},
+	{0x2648, 0x2653},
+	{0x267F, 0x267F},
+	{0x2693, 0x2693},
+	{0x26A1, 0x26A1},
+	{0x26AA, 0x26AB},
+	{0x26BD, 0x26BE},
+	{0x26C4, 0x26C5},
+	{0x26CE, 0x26CE},
+	{0x26D4, 0x26D4},
+	{0x26EA, 0x26EA},
+	{0x26F2, 0x26F3},
+	{0x26F5, 0x26F5},
+	{0x26FA, 0x26FA},
+	{0x26FD, 0x26FD},
+	{0x2705, 0x2705},
+	{0x270A, 0x270B},
+	{0x2728, 0x2728},
+	{0x274C, 0x274C},
+	{0x274E, 0x274E},
+	{0x2753, 0x2755},
+	{0x2757, 0x2757},
+	{0x2795, 0x2797},
+	{0x27B0, 0x27B0},
+	{0x27BF, 0x27BF},
+	{0x2B1B, 0x2B1C},
+	{0x2B50, 0x2B50},
+	{0x2B55, 0x2B55},
+	{0x1F004, 0x1F004},
+	{0x1F0CF, 0x1F0CF},
+	{0x1F18E, 0x1F18E},
+	{0x1F191, 0x1F19A},
+	{0x1F1E6, 0x1F1FF},
+	{0x1F201, 0x1F201},
+	{0x1F21A, 0x1F21A},
+	{0x1F22F, 0x1F22F},
+	{0x1F232, 0x1F236},
+	{0x1F238, 0x1F23A},
+	{0x1F250, 0x1F251},
+	{0x1F300, 0x1F320},
+	{0x1F32D, 0x1F335},
+	{0x1F337, 0x1F37C},
+	{0x1F37E, 0x1F393},
+	{0x1F3A0, 0x1F3CA},
+	{0x1F3CF, 0x1F3D3},
+	{0x1F3E0, 0x1F3F0},
+	{0x1F3F4, 0x1F3F4},
+	{0x1F3F8, 0x1F43E},
+	{0x1F440, 0x1F440},
+	{0x1F442, 0x1F4FC},
+	{0x1F4FF, 0x1F53D},
+	{0x1F54B, 0x1F54E},
+	{0x1F550, 0x1F567},
+	{0x1F57A, 0x1F57A},
+	{0x1F595, 0x1F596},
+	{0x1F5A4, 0x1F5A4},
+	{0x1F5FB, 0x1F64F},
+	{0x1F680, 0x1F6C5},
+	{0x1F6CC, 0x1F6CC},
+	{0x1F6D0, 0x1F6D2},
+	{0x1F6D5, 0x1F6D7},
+	{0x1F6DD, 0x1F6DF},
+	{0x1F6EB, 0x1F6EC},
+	{0x1F6F4, 0x1F6FC},
+	{0x1F7E0, 0x1F7EB},
+	{0x1F7F0, 0x1F7F0},
+	{0x1F90C, 0x1F93A},
+	{0x1F93C, 0x1F945},
+	{0x1F947, 0x1F9FF},
+	{0x1FA70, 0x1FA74},
+	{0x1FA78, 0x1FA7C},
+	{0x1FA80, 0x1FA86},
+	{0x1FA90, 0x1FAAC},
+	{0x1FAB0, 0x1FABA},
+	{0x1FAC0, 0x1FAC5},
+	{0x1FAD0, 0x1FAD9},
+	{0x1FAE0, 0x1FAE7},
+	{0x1FAF0, 0x1FAF6},
+}
+
+// wide 为 East_Asian_Width 属性为 W、F 的字符，在终端中占两列
+var wide = []runeRange{
+	{0x1100, 0x115F},
+	{0x231A, 0x231B},
+	{0x2329, 0x232A},
+	{0x23E9, 0x23EC},
+	{0x23F0, 0x23F0},
+	{0x23F3, 0x23F3},
+	{0x25FD, 0x25FE},
+	{0x2614, 0x2615},
+	{0x2648, 0x2653},
+	{0x267F, 0x267F},
+	{0x2693, 0x2693},
+	{0x26A1, 0x26A1},
+	{0x26AA, 0x26AB},
+	{0x26BD, 0x26BE},
+	{0x26C4, 0x26C5},
+	{0x26CE, 0x26CE},
+	{0x26D4, 0x26D4},
+	{0x26EA, 0x26EA},
+	{0x26F2, 0x26F3},
+	{0x26F5, 0x26F5},
+	{0x26FA, 0x26FA},
+	{0x26FD, 0x26FD},
+	{0x2705, 0x2705},
+	{0x270A, 0x270B},
+	{0x2728, 0x2728},
+	{0x274C, 0x274C},
+	{0x274E, 0x274E},
+	{0x2753, 0x2755},
+	{0x2757, 0x2757},
+	{0x2795, 0x2797},
+	{0x27B0, 0x27B0},
+	{0x27BF, 0x27BF},
+	{0x2B1B, 0x2B1C},
+	{0x2B50, 0x2B50},
+	{0x2B55, 0x2B55},
+	{0x2E80, 0x2E99},
+	{0x2E9B, 0x2EF3},
+	{0x2F00, 0x2FD5},
+	{0x2FF0, 0x2FFB},
+	{0x3000, 0x303E},
+	{0x3041, 0x3096},
+	{0x3099, 0x30FF},
+	{0x3105, 0x312F},
+	{0x3131, 0x318E},
+	{0x3190, 0x31E3},
+	{0x31F0, 0x321E},
+	{0x3220, 0x3247},
+	{0x3250, 0x4DBF},
+	{0x4E00, 0xA48C},
+	{0xA490, 0xA4C6},
+	{0xA960, 0xA97C},
+	{0xAC00, 0xD7A3},
+	{0xF900, 0xFAFF},
+	{0xFE10, 0xFE19},
+	{0xFE30, 0xFE52},
+	{0xFE54, 0xFE66},
+	{0xFE68, 0xFE6B},
+	{0xFF01, 0xFF60},
+	{0xFFE0, 0xFFE6},
+	{0x16FE0, 0x16FE4},
+	{0x16FF0, 0x16FF1},
+	{0x17000, 0x187F7},
+	{0x18800, 0x18CD5},
+	{0x18D00, 0x18D08},
+	{0x1AFF0, 0x1AFF3},
+	{0x1AFF5, 0x1AFFB},
+	{0x1AFFD, 0x1AFFE},
+	{0x1B000, 0x1B122},
+	{0x1B150, 0x1B152},
+	{0x1B164, 0x1B167},
+	{0x1B170, 0x1B2FB},
+	{0x1F004, 0x1F004},
+	{0x1F0CF, 0x1F0CF},
+	{0x1F18E, 0x1F18E},
+	{0x1F191, 0x1F19A},
+	{0x1F200, 0x1F202},
+	{0x1F210, 0x1F23B},
+	{0x1F240, 0x1F248},
+	{0x1F250, 0x1F251},
+	{0x1F260, 0x1F265},
+	{0x1F300, 0x1F320},
+	{0x1F32D, 0x1F335},
+	{0x1F337, 0x1F37C},
+	{0x1F37E, 0x1F393},
+	{0x1F3A0, 0x1F3CA},
+	{0x1F3CF, 0x1F3D3},
+	{0x1F3E0, 0x1F3F0},
+	{0x1F3F4, 0x1F3F4},
+	{0x1F3F8, 0x1F43E},
+	{0x1F440, 0x1F440},
+	{0x1F442, 0x1F4FC},
+	{0x1F4FF, 0x1F53D},
+	{0x1F54B, 0x1F54E},
+	{0x1F550, 0x1F567},
+	{0x1F57A, 0x1F57A},
+	{0x1F595, 0x1F596},
+	{0x1F5A4, 0x1F5A4},
+	{0x1F5FB, 0x1F64F},
+	{0x1F680, 0x1F6C5},
+	{0x1F6CC, 0x1F6CC},
+	{0x1F6D0, 0x1F6D2},
+	{0x1F6D5, 0x1F6D7},
+	{0x1F6DD, 0x1F6DF},
+	{0x1F6EB, 0x1F6EC},
+	{0x1F6F4, 0x1F6FC},
+	{0x1F7E0, 0x1F7EB},
+	{0x1F7F0, 0x1F7F0},
+	{0x1F90C, 0x1F93A},
+	{0x1F93C, 0x1F945},
+	{0x1F947, 0x1F9FF},
+	{0x1FA70, 0x1FA74},
+	{0x1FA78, 0x1FA7C},
+	{0x1FA80, 0x1FA86},
+	{0x1FA90, 0x1FAAC},
+	{0x1FAB0, 0x1FABA},
+	{0x1FAC0, 0x1FAC5},
+	{0x1FAD0, 0x1FAD9},
+	{0x1FAE0, 0x1FAE7},
+	{0x1FAF0, 0x1FAF6},
+	{0x20000, 0x2FFFD},
+	{0x30000, 0x3FFFD},
+}
@@ -0,0 +1,124 @@
+/*
+Package runestring 提供按字符（rune）索引的只读字符串，参考 03-strings 中的 "正确获取字符串中指定索引处的字符"
+
+	`[]rune(str)[i]` 每次访问都会把整个字符串解码、复制为 []rune，RuneString 则：
+		* 保留原始的 utf-8 字节，不展开为 []rune
+		* 每隔 Stride 个 rune 记录一次字节偏移，At(i) 从最近的记录开始最多解码 Stride-1 个 rune，即 O(1)
+		* 纯 ASCII 字符串的字节偏移即 rune 索引，不需要记录
+		* 无效的 utf-8 字节与 range 的处理方式一致，视为一个 U+FFFD，Len() 与 utf8.RuneCountInString 一致
+*/
+package runestring
+
+import (
+	"iter"
+	"unicode/utf8"
+)
+
+// DefaultStride 为 New 使用的记录间隔，索引占用的内存约为字符串 rune 数量的 4/DefaultStride 字节
+const DefaultStride = 32
+
+// RuneString 为按 rune 索引的只读字符串，零值为空字符串
+type RuneString struct {
+	s       string
+	n       int      // rune 数量
+	stride  int      // 每隔 stride 个 rune 记录一次字节偏移
+	offsets []uint32 // offsets[k] 为第 k*stride 个 rune 的字节偏移，纯 ASCII 时为 nil
+}
+
+// New 使用 DefaultStride 创建 RuneString
+func New(s string) RuneString {
+	return NewStride(s, DefaultStride)
+}
+
+// NewStride 创建 RuneString，stride 越小 At 越快，索引越大
+func NewStride(s string, stride int) RuneString {
+	if stride < 1 {
+		panic("runestring: stride must be positive")
+	}
+	if uint64(len(s)) > 1<<32-1 {
+		panic("runestring: string too long")
+	}
+
+	rs := RuneString{s: s, stride: stride}
+	ascii := true
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		rs.n = len(s)
+		return rs
+	}
+
+	for offset := 0; offset < len(s); rs.n++ {
+		if rs.n%stride == 0 {
+			rs.offsets = append(rs.offsets, uint32(offset))
+		}
+		_, size := utf8.DecodeRuneInString(s[offset:])
+		offset += size
+	}
+	return rs
+}
+
+// String 返回原始字符串
+func (rs RuneString) String() string {
+	return rs.s
+}
+
+// Len 返回 rune 数量
+func (rs RuneString) Len() int {
+	return rs.n
+}
+
+// At 返回第 i 个 rune，i 越界时 panic
+func (rs RuneString) At(i int) rune {
+	r, _ := utf8.DecodeRuneInString(rs.s[rs.offset(i):])
+	return r
+}
+
+// Slice 返回第 i 个到第 j 个 rune（不包含 j）组成的子字符串，与 string([]rune(s)[i:j]) 的区别在于，
+// 子字符串与原字符串共享内存，且保留原有的无效字节
+func (rs RuneString) Slice(i, j int) string {
+	if i < 0 || j < i || j > rs.n {
+		panic("runestring: slice bounds out of range")
+	}
+	end := len(rs.s)
+	if j < rs.n {
+		end = rs.offset(j)
+	}
+	if i == j {
+		return ""
+	}
+	return rs.s[rs.offset(i):end]
+}
+
+// All 按顺序遍历所有 rune，返回 rune 索引以及 rune，类似于 range，但索引为 rune 索引，而不是字节索引
+func (rs RuneString) All() iter.Seq2[int, rune] {
+	return func(yield func(int, rune) bool) {
+		i := 0
+		for _, r := range rs.s {
+			if !yield(i, r) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// offset 返回第 i 个 rune 的字节偏移
+func (rs RuneString) offset(i int) int {
+	if i < 0 || i >= rs.n {
+		panic("runestring: index out of range")
+	}
+	if rs.offsets == nil {
+		return i
+	}
+	offset := int(rs.offsets[i/rs.stride])
+	for k := i % rs.stride; k > 0; k-- {
+		_, size := utf8.DecodeRuneInString(rs.s[offset:])
+		offset += size
+	}
+	return offset
+}
@@ -0,0 +1,137 @@
+package runestring
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+var inputs = []string{
+	"",
+	"hello, world",
+	"中国香港",
+	"Hello, 世界 😀",
+	"a\xffb\xe4\xb8c", // 无效的 utf-8 字节
+	strings.Repeat("中a😀", 100),
+}
+
+func TestRuneString(t *testing.T) {
+	for _, s := range inputs {
+		for _, stride := range []int{1, 3, DefaultStride} {
+			rs := NewStride(s, stride)
+			runes := []rune(s)
+
+			if rs.Len() != len(runes) || rs.Len() != utf8.RuneCountInString(s) {
+				t.Fatalf("%q: Len() = %d, want %d", s, rs.Len(), len(runes))
+			}
+			for i, r := range runes {
+				if got := rs.At(i); got != r {
+					t.Errorf("%q/%d: At(%d) = %q, want %q", s, stride, i, got, r)
+				}
+			}
+			for i := 0; i <= len(runes); i++ {
+				for j := i; j <= len(runes) && j < i+5; j++ {
+					// 无效字节在 []rune 中变为 U+FFFD，所以按 rune 比较
+					if got, want := []rune(rs.Slice(i, j)), runes[i:j]; string(got) != string(want) {
+						t.Errorf("%q/%d: Slice(%d, %d) = %q, want %q", s, stride, i, j, string(got), string(want))
+					}
+				}
+			}
+
+			n := 0
+			for i, r := range rs.All() {
+				if i != n || r != runes[i] {
+					t.Errorf("%q: All() yields (%d, %q), want (%d, %q)", s, i, r, n, runes[n])
+				}
+				n++
+			}
+			if n != len(runes) {
+				t.Errorf("%q: All() yields %d runes, want %d", s, n, len(runes))
+			}
+		}
+	}
+}
+
+func TestSliceKeepsBytes(t *testing.T) {
+	rs := New("a\xffb")
+	if got := rs.Slice(1, 3); got != "\xffb" {
+		t.Errorf("Slice(1, 3) = %q, want %q", got, "\xffb")
+	}
+}
+
+func TestOutOfRange(t *testing.T) {
+	rs := New("中国")
+	for _, f := range []func(){
+		func() { rs.At(2) },
+		func() { rs.At(-1) },
+		func() { rs.Slice(1, 3) },
+		func() { rs.Slice(2, 1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// 基准测试使用约 10000 个字符的中英文混合文本，随机访问中间位置的字符，运行：
+//
+//	go test -bench . ./03-strings/runestring
+var benchText = strings.Repeat("Go 语言中的字符串是只读的字节 slice，", 500)
+
+func BenchmarkAt(b *testing.B) {
+	rs := New(benchText)
+	n := rs.Len()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = rs.At(i % n)
+	}
+}
+
+func BenchmarkRuneConversionAt(b *testing.B) {
+	n := utf8.RuneCountInString(benchText)
+	for i := 0; i < b.N; i++ {
+		_ = []rune(benchText)[i%n]
+	}
+}
+
+func BenchmarkLen(b *testing.B) {
+	rs := New(benchText)
+	for i := 0; i < b.N; i++ {
+		_ = rs.Len()
+	}
+}
+
+func BenchmarkRuneCountInString(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = utf8.RuneCountInString(benchText)
+	}
+}
+
+func BenchmarkSlice(b *testing.B) {
+	rs := New(benchText)
+	n := rs.Len()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		j := i % (n - 10)
+		_ = rs.Slice(j, j+10)
+	}
+}
+
+func BenchmarkRuneConversionSlice(b *testing.B) {
+	n := utf8.RuneCountInString(benchText)
+	for i := 0; i < b.N; i++ {
+		j := i % (n - 10)
+		_ = string([]rune(benchText)[j : j+10])
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = New(benchText)
+	}
+}
@@ -16,7 +16,7 @@ String:
 			"中国香港"                                 			// UTF-8 input text
 			`中国香港`                                 			// UTF-8 input text as a raw literal
 			"\u4e2d\u56fd\u9999\u6e2f"                    		// the explicit Unicode code points
-			"\U00004e2d\U000056fd\U00009999\U6e2f"        		// the explicit Unicode code points
+			"\U00004e2d\U000056fd\U00009999\U00006e2f"    		// the explicit Unicode code points
 			"\xe4\xb8\xad\xe5\x9b\xbd\xe9\xa6\x99\xe6\xb8\xaf"  // the explicit UTF-8 bytes
 			```
 
@@ -88,6 +88,11 @@ Rune:
 		}
 		```
 
+	* 正确获取字符串中用户感知的字符数量：
+		rune 也不一定是一个"字符"，组合字符（e + U+0301）、emoji 序列（👨‍👩‍👧）、国旗（🇨🇳）都由多个 rune 组成，
+		用户感知的字符为 extended grapheme cluster（UAX #29），参考 graphemeCount，
+		以及 `github.com/SamHwang1990/go-tour/03-strings/grapheme` 中的 Count、Index、Reverse、Truncate
+
 	* 更多对 string 的操作参照内置的 string package：
 		[Package strings](https://golang.org/pkg/strings/)
 
@@ -102,7 +107,9 @@ package main
 
 import (
 	"fmt"
-	_ "unicode/utf8"
+	"unicode/utf8"
+
+	"github.com/SamHwang1990/go-tour/03-strings/grapheme"
 )
 
 func main() {
@@ -115,3 +122,42 @@ func main() {
 		fmt.Printf("charactor at index %d is %c\n", index, char)
 	}
 }
+
+// graphemeCount 对比字节数、rune 数量以及 grapheme cluster 数量
+func graphemeCount() {
+	fmt.Println("------- graphemeCount -------")
+
+	samples := []string{
+		"go",
+		"中国香港",
+		"\u00e9",               // é，预组合字符
+		"e\u0301",              // é，e + 组合重音符号
+		"\U0001F1E8\U0001F1F3", // 国旗，两个 Regional Indicator
+		"\U0001F44D\U0001F3FD", // emoji + 肤色修饰符
+		"\U0001F468\u200D\U0001F469\u200D\U0001F467", // 家庭，三个 emoji 以 ZWJ 连接
+	}
+
+	// 按显示宽度（grapheme.Width）而不是字节数对齐第一列
+	fmt.Printf("%-10s %5s %5s %9s %5s  %s\n", "sample", "len", "runes", "graphemes", "width", "escaped")
+	for _, str := range samples {
+		runes := utf8.RuneCountInString(str)
+		graphemes := grapheme.Count(str)
+		width := grapheme.Width(str)
+		mark := ""
+		if runes != graphemes {
+			mark = "  <- rune 数量 != 字符数量"
+		}
+		fmt.Printf("%s%*s %5d %5d %9d %5d  %+q%s\n", str, 10-width, "", len(str), runes, graphemes, width, str, mark)
+	}
+
+	// 按 rune 反转会拆散组合字符以及 emoji 序列
+	const str = "cafe\u0301 \U0001F1E8\U0001F1F3"
+	runes := []rune(str)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	fmt.Printf("reverse by rune:     %+q\n", string(runes))
+	fmt.Printf("reverse by grapheme: %+q\n", grapheme.Reverse(str))
+
+	fmt.Println("------- graphemeCount -------")
+}
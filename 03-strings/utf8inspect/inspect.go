@@ -0,0 +1,141 @@
+/*
+Package utf8inspect 逐个字符地展示字符串的 utf-8 编码，配合 03-strings 中 len、index、range 的说明使用
+
+	对于字符串中的每个 rune，给出：
+		* byte offset：rune 第一个字节在字符串中的索引，即 range 得到的 index
+		* rune index：rune 在 []rune(s) 中的索引
+		* code point，比如 U+4E2D
+		* utf-8 编码后的字节，比如 e4 b8 ad
+		* 字面量写法：\u4e2d、\U00004e2d、\xe4\xb8\xad
+
+	无效的 utf-8 字节：
+		range 遇到无效的字节时，会得到 utf8.RuneError（U+FFFD），且只前进一个字节，
+		这些字节的 Invalid 为 true，只能使用 \x 的写法表示
+*/
+package utf8inspect
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Rune 为字符串中的一个 rune，或者一个无效的字节
+type Rune struct {
+	Offset  int    // 字节索引，即 range 得到的 index
+	Index   int    // 在 []rune(s) 中的索引
+	Rune    rune   // 无效字节为 utf8.RuneError
+	Bytes   []byte // 在字符串中的 utf-8 编码
+	Invalid bool   // 无效的 utf-8 字节，range 会将其转换为 U+FFFD
+}
+
+// Inspect 按 range 的方式遍历字符串，返回每个 rune 的编码信息
+func Inspect(s string) []Rune {
+	var runes []Rune
+	index := 0
+	for offset, r := range s {
+		size := utf8.RuneLen(r)
+		invalid := false
+		if r == utf8.RuneError {
+			_, size = utf8.DecodeRuneInString(s[offset:])
+			invalid = size == 1
+		}
+		runes = append(runes, Rune{
+			Offset:  offset,
+			Index:   index,
+			Rune:    r,
+			Bytes:   []byte(s[offset : offset+size]),
+			Invalid: invalid,
+		})
+		index++
+	}
+	return runes
+}
+
+// CodePoint 返回 U+XXXX 形式的 code point
+func (r Rune) CodePoint() string {
+	return fmt.Sprintf("%U", r.Rune)
+}
+
+// Hex 返回以空格分隔的十六进制字节，比如 "e4 b8 ad"
+func (r Rune) Hex() string {
+	return fmt.Sprintf("% x", r.Bytes)
+}
+
+// Char 返回可以直接显示的字符，无效字节以及不可打印的字符返回空字符串
+func (r Rune) Char() string {
+	if r.Invalid || !strconv.IsPrint(r.Rune) {
+		return ""
+	}
+	return string(r.Rune)
+}
+
+// Unicode 返回 \u 形式的字面量，code point 超出 U+FFFF 或者无效字节时返回空字符串
+func (r Rune) Unicode() string {
+	if r.Invalid || r.Rune > 0xFFFF {
+		return ""
+	}
+	return fmt.Sprintf(`\u%04x`, r.Rune)
+}
+
+// UnicodeLong 返回 \U 形式的字面量，无效字节返回空字符串
+func (r Rune) UnicodeLong() string {
+	if r.Invalid {
+		return ""
+	}
+	return fmt.Sprintf(`\U%08x`, r.Rune)
+}
+
+// Escape 返回 \x 形式的字面量，即 utf-8 编码后的每个字节
+func (r Rune) Escape() string {
+	var b strings.Builder
+	for _, c := range r.Bytes {
+		fmt.Fprintf(&b, `\x%02x`, c)
+	}
+	return b.String()
+}
+
+// Literal 为字符串的一种字面量写法
+type Literal struct {
+	Form    string // 写法说明，与 03-strings 中的注释一致
+	Literal string // 无法使用该写法时为空，比如包含反引号的字符串不能写为 raw literal
+}
+
+// Literals 返回与 03-strings 中列出的五种写法等价的字符串字面量：
+// 原文、raw literal、\u code point、\U code point 以及 \x utf-8 字节
+//
+// 无效的 utf-8 字节在 \u、\U 写法中使用 \x 表示；\u 写法中超出 U+FFFF 的字符使用 \U 表示
+func Literals(s string) []Literal {
+	runes := Inspect(s)
+	join := func(form func(Rune) string) string {
+		var b strings.Builder
+		b.WriteByte('"')
+		for _, r := range runes {
+			lit := form(r)
+			if lit == "" {
+				lit = r.Escape()
+			}
+			b.WriteString(lit)
+		}
+		b.WriteByte('"')
+		return b.String()
+	}
+
+	raw := ""
+	if strconv.CanBackquote(s) {
+		raw = "`" + s + "`"
+	}
+	return []Literal{
+		{"UTF-8 input text", strconv.Quote(s)},
+		{"UTF-8 input text as a raw literal", raw},
+		{"the explicit Unicode code points", join(func(r Rune) string {
+			if r.Unicode() == "" {
+				return r.UnicodeLong()
+			}
+			return r.Unicode()
+		})},
+		{"the explicit Unicode code points", join(Rune.UnicodeLong)},
+		{"the explicit UTF-8 bytes", join(Rune.Escape)},
+	}
+}
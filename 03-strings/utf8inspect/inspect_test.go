@@ -0,0 +1,81 @@
+package utf8inspect
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/SamHwang1990/go-tour/chapter"
+	"github.com/SamHwang1990/go-tour/lesson"
+)
+
+// TestChapterLiterals 检查 Literals 给出的五种写法与 03-strings 中列出的写法一致
+func TestChapterLiterals(t *testing.T) {
+	c, err := chapter.Load("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	literal := regexp.MustCompile("^([\"`][^\"`]*[\"`])\\s*//\\s*(.+)$")
+	var want []Literal
+	for _, e := range lesson.Load(c).Examples() {
+		for _, line := range strings.Split(e.Code, "\n") {
+			if m := literal.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+				want = append(want, Literal{Form: m[2], Literal: m[1]})
+			}
+		}
+	}
+	if len(want) != 5 {
+		t.Fatalf("found %d literals in %s, want 5", len(want), c.DocFile)
+	}
+
+	got := Literals("中国香港")
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("literal %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLiterals(t *testing.T) {
+	for _, s := range []string{"", "hello", "中国香港", "a\xffb", "\xe4\xb8", "😀`", "\uFFFD", "\"\\\n"} {
+		for _, l := range Literals(s) {
+			if l.Literal == "" {
+				continue
+			}
+			got, err := strconv.Unquote(l.Literal)
+			if err != nil || got != s {
+				t.Errorf("%q: %s literal %s = %q, %v", s, l.Form, l.Literal, got, err)
+			}
+		}
+	}
+}
+
+func TestInspect(t *testing.T) {
+	runes := Inspect("a\xff中\xe4\xb8\uFFFD")
+	type row struct {
+		offset, index int
+		hex           string
+		invalid       bool
+	}
+	want := []row{
+		{0, 0, "61", false},
+		{1, 1, "ff", true},
+		{2, 2, "e4 b8 ad", false},
+		{5, 3, "e4", true},
+		{6, 4, "b8", true},
+		{7, 5, "ef bf bd", false}, // 字符串中本来就是 U+FFFD，不是无效字节
+	}
+	if len(runes) != len(want) {
+		t.Fatalf("got %d runes, want %d", len(runes), len(want))
+	}
+	for i, r := range runes {
+		if got := (row{r.Offset, r.Index, r.Hex(), r.Invalid}); got != want[i] {
+			t.Errorf("rune %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+	if r := runes[2]; r.CodePoint() != "U+4E2D" || r.Unicode() != `\u4e2d` || r.UnicodeLong() != `\U00004e2d` || r.Escape() != `\xe4\xb8\xad` {
+		t.Errorf("中 = %s %s %s %s", r.CodePoint(), r.Unicode(), r.UnicodeLong(), r.Escape())
+	}
+}
@@ -0,0 +1,50 @@
+package utf8inspect
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"unicode/utf8"
+)
+
+// Write 输出字符串的编码报告：len、rune 数量、每个 rune 的编码表格，以及五种等价的字面量写法，
+// 无效的 utf-8 字节在表格中以 `!` 标记
+func Write(w io.Writer, s string) error {
+	runes := Inspect(s)
+	invalid := 0
+	for _, r := range runes {
+		if r.Invalid {
+			invalid++
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "len(s) = %d bytes, utf8.RuneCountInString(s) = %d runes", len(s), utf8.RuneCountInString(s))
+	if invalid > 0 {
+		fmt.Fprintf(tw, ", %d invalid bytes", invalid)
+	}
+	fmt.Fprintf(tw, "\n\n")
+
+	fmt.Fprintf(tw, "  \toffset\tindex\tchar\tcode point\tutf-8\t\\u\t\\U\t\\x\n")
+	for _, r := range runes {
+		mark, point := "", r.CodePoint()
+		if r.Invalid {
+			mark, point = "!", point+" (invalid)"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			mark, r.Offset, r.Index, r.Char(), point, r.Hex(), r.Unicode(), r.UnicodeLong(), r.Escape())
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(tw, "\nliterals:")
+	for _, l := range Literals(s) {
+		lit := l.Literal
+		if lit == "" {
+			lit = "(not possible)"
+		}
+		fmt.Fprintf(tw, "  %s\t// %s\n", lit, l.Form)
+	}
+	return tw.Flush()
+}
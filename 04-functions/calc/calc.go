@@ -0,0 +1,209 @@
+/*
+Package calc 是基于函数类型的整数表达式计算器，参考 04-functions 中的函数类型（Function Type）以及 calcFunc
+
+	运算符是一等公民的函数值：
+		* Func 与章节中的 `type calcFunc func(int, int) int` 具有相同的函数签名，两者可以直接转换
+		* Registry 将运算符符号映射到 Func，可以注册自定义运算符，比如 `max`、`<<`
+		* 解析中缀表达式时按照运算符的优先级以及结合性构建语法树，支持括号以及一元负号
+
+	Func 只能返回 int，运算错误（溢出、除数为 0）通过 panic 一个 error 报告，
+	Eval 使用 defer、recover 以及命名返回值将其转换为返回的 error，参考 04-functions 的 safedivide 练习
+*/
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Func 为二元运算符的实现，与 04-functions 中的 calcFunc 相同
+type Func func(int, int) int
+
+// 运算错误，Func 通过 panic 这些 error（或者包装了它们的 error）报告错误
+var (
+	ErrOverflow         = errors.New("integer overflow")
+	ErrDivisionByZero   = errors.New("division by zero")
+	ErrNegativeExponent = errors.New("negative exponent")
+)
+
+// 默认运算符的优先级，数值越大越先计算
+const (
+	PrecAdditive       = 1 // + -
+	PrecMultiplicative = 2 // * / %
+	PrecUnary          = 3 // 一元负号，-2^2 == -4
+	PrecPower          = 4 // ^
+)
+
+// Operator 为一个二元运算符
+type Operator struct {
+	Symbol     string // 由字母组成（比如 max），或者由标点、符号组成（比如 <<），不能包含括号
+	Precedence int    // 必须大于 0
+	RightAssoc bool   // 右结合，比如 2^3^2 == 2^(3^2)
+	Fn         Func
+}
+
+// Registry 为运算符注册表，零值不可用，使用 NewRegistry 或 Default 创建
+type Registry struct {
+	ops map[string]Operator
+}
+
+// NewRegistry 创建一个空的注册表
+func NewRegistry() *Registry {
+	return &Registry{ops: map[string]Operator{}}
+}
+
+// Default 创建包含 + - * / % ^ 的注册表，这些运算符在溢出时报告 ErrOverflow
+func Default() *Registry {
+	r := NewRegistry()
+	for _, op := range []Operator{
+		{"+", PrecAdditive, false, Add},
+		{"-", PrecAdditive, false, Sub},
+		{"*", PrecMultiplicative, false, Mul},
+		{"/", PrecMultiplicative, false, Div},
+		{"%", PrecMultiplicative, false, Mod},
+		{"^", PrecPower, true, Pow},
+	} {
+		if err := r.Register(op); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// Register 注册运算符，替换已注册的同名运算符
+func (r *Registry) Register(op Operator) error {
+	if err := validSymbol(op.Symbol); err != nil {
+		return err
+	}
+	if op.Precedence <= 0 {
+		return fmt.Errorf("operator %s: precedence must be positive", op.Symbol)
+	}
+	if op.Fn == nil {
+		return fmt.Errorf("operator %s: nil Fn", op.Symbol)
+	}
+	r.ops[op.Symbol] = op
+	return nil
+}
+
+// Lookup 按符号查找运算符
+func (r *Registry) Lookup(symbol string) (Operator, bool) {
+	op, ok := r.ops[symbol]
+	return op, ok
+}
+
+// Operators 返回所有运算符，按优先级从低到高排列，优先级相同时按符号排列
+func (r *Registry) Operators() []Operator {
+	ops := make([]Operator, 0, len(r.ops))
+	for _, op := range r.ops {
+		ops = append(ops, op)
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].Precedence != ops[j].Precedence {
+			return ops[i].Precedence < ops[j].Precedence
+		}
+		return ops[i].Symbol < ops[j].Symbol
+	})
+	return ops
+}
+
+// Eval 解析并计算表达式
+func (r *Registry) Eval(expr string) (int, error) {
+	e, err := r.Parse(expr)
+	if err != nil {
+		return 0, err
+	}
+	return Eval(e)
+}
+
+func validSymbol(symbol string) error {
+	if symbol == "" {
+		return errors.New("empty operator symbol")
+	}
+	first, _ := utf8.DecodeRuneInString(symbol)
+	word := unicode.IsLetter(first)
+	for _, c := range symbol {
+		switch {
+		case c == '(' || c == ')':
+			return fmt.Errorf("operator %s: parentheses are reserved", symbol)
+		case word && unicode.IsLetter(c):
+		case !word && isSymbol(c):
+		default:
+			return fmt.Errorf("operator %s: symbol must be all letters or all punctuation", symbol)
+		}
+	}
+	return nil
+}
+
+func isSymbol(c rune) bool {
+	return c != '(' && c != ')' && (unicode.IsPunct(c) || unicode.IsSymbol(c))
+}
+
+// Add 返回 x + y，溢出时 panic ErrOverflow
+func Add(x, y int) int {
+	z := x + y
+	if (z > x) != (y > 0) {
+		panic(ErrOverflow)
+	}
+	return z
+}
+
+// Sub 返回 x - y，溢出时 panic ErrOverflow
+func Sub(x, y int) int {
+	z := x - y
+	if (z < x) != (y > 0) {
+		panic(ErrOverflow)
+	}
+	return z
+}
+
+// Mul 返回 x * y，溢出时 panic ErrOverflow
+func Mul(x, y int) int {
+	if x == 0 || y == 0 {
+		return 0
+	}
+	z := x * y
+	if z/y != x || (x == -1 && y == math.MinInt) || (y == -1 && x == math.MinInt) {
+		panic(ErrOverflow)
+	}
+	return z
+}
+
+// Div 返回 x / y（向 0 取整），除数为 0 时 panic ErrDivisionByZero
+func Div(x, y int) int {
+	if y == 0 {
+		panic(ErrDivisionByZero)
+	}
+	if x == math.MinInt && y == -1 {
+		panic(ErrOverflow)
+	}
+	return x / y
+}
+
+// Mod 返回 x % y，符号与 x 相同，除数为 0 时 panic ErrDivisionByZero
+func Mod(x, y int) int {
+	if y == 0 {
+		panic(ErrDivisionByZero)
+	}
+	return x % y
+}
+
+// Pow 返回 x 的 y 次方，y 为负数时 panic ErrNegativeExponent
+func Pow(x, y int) int {
+	if y < 0 {
+		panic(ErrNegativeExponent)
+	}
+	z := 1
+	for ; y > 0; y >>= 1 {
+		if y&1 == 1 {
+			z = Mul(z, x)
+		}
+		if y > 1 {
+			x = Mul(x, x)
+		}
+	}
+	return z
+}
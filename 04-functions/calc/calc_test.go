@@ -0,0 +1,238 @@
+package calc
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		expr string
+		want int
+	}{
+		{"42", 42},
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3}, // 左结合
+		{"100 / 10 / 5", 2},
+		{"2 ^ 3 ^ 2", 512}, // 右结合
+		{"-2^2", -4},       // 一元负号的优先级低于 ^
+		{"-2 * 3", -6},
+		{"2 - -3", 5},
+		{"7 % 3 + 7 / 2", 4},
+		{"-7 / 2", -3},
+		{"((((1))))", 1},
+		{"9223372036854775807", math.MaxInt},
+		{"-9223372036854775807 - 1", math.MinInt},
+	}
+	r := Default()
+	for _, tt := range tests {
+		got, err := r.Eval(tt.expr)
+		if err != nil || got != tt.want {
+			t.Errorf("Eval(%q) = %d, %v, want %d", tt.expr, got, err, tt.want)
+		}
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want error
+		msg  string
+	}{
+		{"1 / 0", ErrDivisionByZero, "1 / 0: division by zero"},
+		{"5 % (2 - 2)", ErrDivisionByZero, "5 % 0: division by zero"},
+		{"9223372036854775807 + 1", ErrOverflow, "9223372036854775807 + 1: integer overflow"},
+		{"-9223372036854775807 - 2", ErrOverflow, ""},
+		{"3037000500 * 3037000500", ErrOverflow, ""},
+		{"(-9223372036854775807 - 1) / -1", ErrOverflow, ""},
+		{"-(-9223372036854775807 - 1)", ErrOverflow, ""},
+		{"2 ^ 63", ErrOverflow, ""},
+		{"2 ^ -1", ErrNegativeExponent, ""},
+	}
+	r := Default()
+	for _, tt := range tests {
+		_, err := r.Eval(tt.expr)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Eval(%q) error = %v, want %v", tt.expr, err, tt.want)
+			continue
+		}
+		if tt.msg != "" && err.Error() != tt.msg {
+			t.Errorf("Eval(%q) error = %q, want %q", tt.expr, err, tt.msg)
+		}
+	}
+}
+
+func TestSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		pos  int
+	}{
+		{"", 0},
+		{"1 +", 3},
+		{"(1 + 2", 6},
+		{"1 + 2)", 5},
+		{"1 2", 2},
+		{"* 2", 0},
+		{"1 max 2", 2},
+		{"1 $ 2", 2},
+		{"1 + #", 4},
+		{"99999999999999999999", 0},
+	}
+	r := Default()
+	for _, tt := range tests {
+		_, err := r.Eval(tt.expr)
+		var serr *SyntaxError
+		if !errors.As(err, &serr) {
+			t.Errorf("Eval(%q) error = %v, want *SyntaxError", tt.expr, err)
+			continue
+		}
+		if serr.Pos != tt.pos {
+			t.Errorf("Eval(%q) error = %v, want column %d", tt.expr, err, tt.pos+1)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		expr, want string
+	}{
+		{"1 + 2 * 3", "(1 + (2 * 3))"},
+		{"1 - 2 + 3", "((1 - 2) + 3)"},
+		{"2 ^ 3 ^ 2", "(2 ^ (3 ^ 2))"},
+		{"-2 ^ 2 * 3", "((-(2 ^ 2)) * 3)"},
+	}
+	r := Default()
+	for _, tt := range tests {
+		e, err := r.Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.expr, err)
+		}
+		if got := e.String(); got != tt.want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.expr, got, tt.want)
+		}
+	}
+}
+
+// calcFunc 与 04-functions 中的声明相同
+type calcFunc func(int, int) int
+
+func TestRegister(t *testing.T) {
+	r := Default()
+
+	var max calcFunc = func(x, y int) int {
+		if x > y {
+			return x
+		}
+		return y
+	}
+	ops := []Operator{
+		{Symbol: "max", Precedence: PrecMultiplicative, Fn: Func(max)},
+		{Symbol: "<<", Precedence: PrecMultiplicative, Fn: func(x, y int) int { return x << y }},
+		{Symbol: "**", Precedence: PrecPower, RightAssoc: true, Fn: Pow},
+	}
+	for _, op := range ops {
+		if err := r.Register(op); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		expr string
+		want int
+	}{
+		{"1 + 2 max 10", 11},
+		{"1 << 4 + 1", 17},
+		{"2**3*2", 16}, // 最长匹配：** 而不是 * *
+	}
+	for _, tt := range tests {
+		got, err := r.Eval(tt.expr)
+		if err != nil || got != tt.want {
+			t.Errorf("Eval(%q) = %d, %v, want %d", tt.expr, got, err, tt.want)
+		}
+	}
+
+	for _, op := range []Operator{
+		{Symbol: "", Precedence: 1, Fn: Add},
+		{Symbol: "a+", Precedence: 1, Fn: Add},
+		{Symbol: "(+", Precedence: 1, Fn: Add},
+		{Symbol: "+", Precedence: 0, Fn: Add},
+		{Symbol: "+", Precedence: 1},
+	} {
+		if err := r.Register(op); err == nil {
+			t.Errorf("Register(%q, %d) succeeded", op.Symbol, op.Precedence)
+		}
+	}
+}
+
+// TestOverflow 与 math/big 的结果对比：结果能用 int 表示时不应报告溢出，否则必须报告 ErrOverflow
+func TestOverflow(t *testing.T) {
+	values := []int{0, 1, -1, 2, -2, 3037000499, 3037000500, -3037000500, math.MaxInt, math.MinInt, math.MaxInt / 2, math.MinInt / 2}
+	ops := []struct {
+		symbol string
+		fn     Func
+		exact  func(z, x, y *big.Int) *big.Int
+	}{
+		{"+", Add, (*big.Int).Add},
+		{"-", Sub, (*big.Int).Sub},
+		{"*", Mul, (*big.Int).Mul},
+	}
+	for _, op := range ops {
+		for _, x := range values {
+			for _, y := range values {
+				z, err := apply(op.symbol, op.fn, x, y)
+				exact := op.exact(new(big.Int), big.NewInt(int64(x)), big.NewInt(int64(y)))
+				if !exact.IsInt64() {
+					if !errors.Is(err, ErrOverflow) {
+						t.Errorf("%d %s %d = %d, %v, want ErrOverflow", x, op.symbol, y, z, err)
+					}
+				} else if err != nil || int64(z) != exact.Int64() {
+					t.Errorf("%d %s %d = %d, %v, want %s", x, op.symbol, y, z, err, exact)
+				}
+			}
+		}
+	}
+}
+
+func TestREPL(t *testing.T) {
+	in := strings.NewReader(strings.Join([]string{
+		"1 + 2 * 3",
+		"",
+		":parse 1 + 2 * 3",
+		"1 / 0",
+		":nope",
+		":quit",
+		"2 + 2",
+	}, "\n"))
+	var out strings.Builder
+	if err := Default().REPL(in, &out, ""); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"7",
+		"(1 + (2 * 3))",
+		"error: 1 / 0: division by zero",
+		"error: unknown command :nope, try :help",
+	}, "\n") + "\n"
+	if out.String() != want {
+		t.Errorf("REPL output:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestPow(t *testing.T) {
+	for x := -5; x <= 5; x++ {
+		want := 1
+		for y := 0; y <= 20; y++ {
+			if got := Pow(x, y); got != want {
+				t.Errorf("Pow(%d, %d) = %d, want %d", x, y, got, want)
+			}
+			want *= x
+		}
+	}
+	if got := Pow(-2, 63); got != math.MinInt {
+		t.Errorf("Pow(-2, 63) = %d, want %d", got, math.MinInt)
+	}
+}
@@ -0,0 +1,268 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Expr 为表达式的语法树
+type Expr interface {
+	// String 返回完整加上括号的表达式，用于展示优先级以及结合性，比如 (1 + (2 * 3))
+	String() string
+	eval() (int, error)
+}
+
+// Num 为整数字面量
+type Num int
+
+// Neg 为一元负号
+type Neg struct {
+	X Expr
+}
+
+// Binary 为二元运算
+type Binary struct {
+	Op   Operator
+	X, Y Expr
+}
+
+func (n Num) String() string { return strconv.Itoa(int(n)) }
+func (n Neg) String() string { return "(-" + n.X.String() + ")" }
+func (b Binary) String() string {
+	return "(" + b.X.String() + " " + b.Op.Symbol + " " + b.Y.String() + ")"
+}
+
+func (n Num) eval() (int, error) { return int(n), nil }
+
+func (n Neg) eval() (int, error) {
+	x, err := n.X.eval()
+	if err != nil {
+		return 0, err
+	}
+	if x == math.MinInt {
+		return 0, fmt.Errorf("-(%d): %w", x, ErrOverflow)
+	}
+	return -x, nil
+}
+
+func (b Binary) eval() (int, error) {
+	x, err := b.X.eval()
+	if err != nil {
+		return 0, err
+	}
+	y, err := b.Y.eval()
+	if err != nil {
+		return 0, err
+	}
+	return apply(b.Op.Symbol, b.Op.Fn, x, y)
+}
+
+// Eval 计算表达式，运算符 panic 的 error 作为返回值
+func Eval(e Expr) (int, error) {
+	return e.eval()
+}
+
+// apply 调用运算符，将 Func panic 的 error 转换为返回值，其他 panic 继续向上传递
+func apply(symbol string, fn Func, x, y int) (z int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(error)
+			if !ok {
+				panic(r)
+			}
+			z, err = 0, fmt.Errorf("%d %s %d: %w", x, symbol, y, e)
+		}
+	}()
+	return fn(x, y), nil
+}
+
+// SyntaxError 为表达式的语法错误，Pos 为出错位置的字节偏移
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Pos+1, e.Msg)
+}
+
+// Parse 解析中缀表达式：
+//   - 运算符按照优先级以及结合性组合，括号优先
+//   - 出现在操作数位置的 - 为一元负号，优先级为 PrecUnary
+//   - 由字母组成的运算符需要与操作数用空格分开，比如 `1 max 2`
+func (r *Registry) Parse(expr string) (Expr, error) {
+	p := &parser{reg: r, src: expr}
+	p.next()
+	e, err := p.binary(1)
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf("unexpected %s", p.tok)
+	}
+	return e, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNum
+	tokOp
+	tokLParen
+	tokRParen
+	tokInvalid
+)
+
+type token struct {
+	kind tokenKind
+	pos  int
+	text string
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+type parser struct {
+	reg *Registry
+	src string
+	off int
+	tok token
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return &SyntaxError{Pos: p.tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// next 读取下一个 token，运算符按最长匹配，比如注册了 * 与 ** 时，2**3 中的 ** 为一个运算符
+func (p *parser) next() {
+	for p.off < len(p.src) && (p.src[p.off] == ' ' || p.src[p.off] == '\t') {
+		p.off++
+	}
+	start := p.off
+	if start == len(p.src) {
+		p.tok = token{tokEOF, start, ""}
+		return
+	}
+
+	c, size := utf8.DecodeRuneInString(p.src[start:])
+	span := func(f func(rune) bool) string {
+		end := start
+		for end < len(p.src) {
+			c, size := utf8.DecodeRuneInString(p.src[end:])
+			if !f(c) {
+				break
+			}
+			end += size
+		}
+		return p.src[start:end]
+	}
+
+	switch {
+	case c == '(':
+		p.tok = token{tokLParen, start, "("}
+	case c == ')':
+		p.tok = token{tokRParen, start, ")"}
+	case c >= '0' && c <= '9':
+		p.tok = token{tokNum, start, span(func(c rune) bool { return c >= '0' && c <= '9' })}
+	case unicode.IsLetter(c):
+		p.tok = token{tokOp, start, span(unicode.IsLetter)}
+	case isSymbol(c):
+		text := span(isSymbol)
+		for len(text) > 0 && !p.isOperator(text) {
+			_, size := utf8.DecodeLastRuneInString(text)
+			text = text[:len(text)-size]
+		}
+		if text == "" {
+			text = p.src[start : start+size]
+		}
+		p.tok = token{tokOp, start, text}
+	default:
+		p.tok = token{tokInvalid, start, p.src[start : start+size]}
+	}
+	p.off = start + len(p.tok.text)
+}
+
+// isOperator 表示 text 为已注册的运算符，或者一元负号
+func (p *parser) isOperator(text string) bool {
+	_, ok := p.reg.Lookup(text)
+	return ok || text == "-"
+}
+
+// binary 解析优先级不低于 minPrec 的二元运算（precedence climbing）
+func (p *parser) binary(minPrec int) (Expr, error) {
+	x, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokOp {
+		op, ok := p.reg.Lookup(p.tok.text)
+		if !ok {
+			return nil, p.errorf("unknown operator %s", p.tok)
+		}
+		if op.Precedence < minPrec {
+			break
+		}
+		p.next()
+
+		// 左结合时，右侧只能包含优先级更高的运算，右结合时可以包含同级的运算
+		next := op.Precedence + 1
+		if op.RightAssoc {
+			next = op.Precedence
+		}
+		y, err := p.binary(next)
+		if err != nil {
+			return nil, err
+		}
+		x = Binary{Op: op, X: x, Y: y}
+	}
+	return x, nil
+}
+
+// unary 解析操作数：整数、括号表达式，或者一元负号
+func (p *parser) unary() (Expr, error) {
+	switch tok := p.tok; tok.kind {
+	case tokNum:
+		n, err := strconv.Atoi(tok.text)
+		if err != nil {
+			if errors.Is(err, strconv.ErrRange) {
+				return nil, p.errorf("%s: %v", tok.text, ErrOverflow)
+			}
+			return nil, p.errorf("invalid number %s", tok)
+		}
+		p.next()
+		return Num(n), nil
+
+	case tokLParen:
+		p.next()
+		x, err := p.binary(1)
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokRParen {
+			return nil, p.errorf("expected \")\", found %s", p.tok)
+		}
+		p.next()
+		return x, nil
+
+	case tokOp:
+		if tok.text != "-" {
+			return nil, p.errorf("expected operand, found operator %s", tok)
+		}
+		p.next()
+		x, err := p.binary(PrecUnary)
+		if err != nil {
+			return nil, err
+		}
+		return Neg{X: x}, nil
+	}
+	return nil, p.errorf("expected operand, found %s", p.tok)
+}
@@ -0,0 +1,69 @@
+package calc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const replHelp = `expressions:  1 + 2 * (3 - 4), -2^2, 7 % 3
+commands:
+  :ops           list operators
+  :parse <expr>  show how the expression is grouped
+  :help          show this message
+  :quit          exit
+`
+
+// REPL 从 in 逐行读取表达式或命令，将结果写入 out，直到读取结束或者 :quit；
+// prompt 不为空时，在读取每一行之前输出
+func (r *Registry) REPL(in io.Reader, out io.Writer, prompt string) error {
+	s := bufio.NewScanner(in)
+	for {
+		if prompt != "" {
+			fmt.Fprint(out, prompt)
+		}
+		if !s.Scan() {
+			if prompt != "" {
+				fmt.Fprintln(out)
+			}
+			return s.Err()
+		}
+
+		line := strings.TrimSpace(s.Text())
+		cmd, arg, _ := strings.Cut(line, " ")
+		switch cmd {
+		case "":
+		case ":quit", ":q":
+			return nil
+		case ":help":
+			fmt.Fprint(out, replHelp)
+		case ":ops":
+			for _, op := range r.Operators() {
+				assoc := "left"
+				if op.RightAssoc {
+					assoc = "right"
+				}
+				fmt.Fprintf(out, "%-6s precedence %d, %s-associative\n", op.Symbol, op.Precedence, assoc)
+			}
+		case ":parse":
+			e, err := r.Parse(arg)
+			if err != nil {
+				fmt.Fprintln(out, "error:", err)
+				continue
+			}
+			fmt.Fprintln(out, e)
+		default:
+			if strings.HasPrefix(cmd, ":") {
+				fmt.Fprintf(out, "error: unknown command %s, try :help\n", cmd)
+				continue
+			}
+			n, err := r.Eval(line)
+			if err != nil {
+				fmt.Fprintln(out, "error:", err)
+				continue
+			}
+			fmt.Fprintln(out, n)
+		}
+	}
+}
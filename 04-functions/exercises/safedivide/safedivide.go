@@ -0,0 +1,18 @@
+/*
+使用 defer 与命名返回值处理 panic：
+
+	除数为 0 时，` a / b ` 会 panic，
+	请使用 defer、recover 以及命名返回值，使 Divide 在除数为 0 时不会 panic，而是返回 error：
+		* ` Divide(6, 3) ` 返回 ` 2, nil `
+		* ` Divide(1, 0) ` 返回 ` 0, err `，err 不为 nil
+*/
+
+//gotour:hint named-return-value-命名返回值
+//gotour:hint defer-语句
+package safedivide
+
+// Divide 返回 a / b，除数为 0 时返回 error
+func Divide(a, b int) (int, error) {
+	// TODO: 在 defer 函数中 recover，并通过命名返回值返回 error
+	return a / b, nil
+}
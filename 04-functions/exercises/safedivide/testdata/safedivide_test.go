@@ -0,0 +1,26 @@
+package safedivide
+
+import "testing"
+
+func TestDivide(t *testing.T) {
+	q, err := Divide(6, 3)
+	if q != 2 || err != nil {
+		t.Errorf("Divide(6, 3) = %d, %v, want 2, nil", q, err)
+	}
+}
+
+func TestDivideByZero(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("Divide(1, 0) panicked: %v", r)
+		}
+	}()
+
+	q, err := Divide(1, 0)
+	if err == nil {
+		t.Errorf("Divide(1, 0) returned nil error")
+	}
+	if q != 0 {
+		t.Errorf("Divide(1, 0) = %d, want 0", q)
+	}
+}
@@ -0,0 +1,13 @@
+package safedivide
+
+import "fmt"
+
+// Divide 返回 a / b，除数为 0 时返回 error
+func Divide(a, b int) (q int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			q, err = 0, fmt.Errorf("divide %d by %d: %v", a, b, r)
+		}
+	}()
+	return a / b, nil
+}
@@ -93,11 +93,11 @@ Functions
 					// print: 1 PM
 					func f() () {
 						time := "1 PM"
-						defer func(in: string) {
-							fmt.Println(time)
+						defer func(in string) {
+							fmt.Println(in)
 						}(time)					// 函数实参会在此时取值：1 PM
 
-						time := "2 PM"
+						time = "2 PM"
 					}
 					```
 
@@ -146,15 +146,19 @@ Functions
 			例如：
 				` type CalcFunc func(int, int) int`
 
+		函数类型的值与其他类型的值一样，可以作为参数、返回值，也可以保存在 map、struct 中：
+			` 04-functions/calc ` 将运算符符号映射到 ` func(int, int) int `，
+			calcFunc 类型的函数字面量可以直接注册为计算器的运算符，参考 calculator
+
 	匿名函数（anonymouse function）：
 		创建函数是不提供函数名，常用于创建函数字面量
-			```go
+			```go sketch
 				var convertFn = func (x int, y int) (int, int) { ... }
 			```
 
 	快速调用函数（Immediately-invoked function）：
 		创建函数的同时完成函数调用：
-			```go
+			```go sketch
 				var x, y = 1, 2
 
 				x, y = func (x int, y int) (int, int) { ... }(x, y)
@@ -172,6 +176,7 @@ package main
 
 import "fmt"
 import "errors"
+import "github.com/SamHwang1990/go-tour/04-functions/calc"
 
 type calcFunc func(int, int) int
 
@@ -220,3 +225,42 @@ func main() {
 		return
 	}))
 }
+
+// calculator 将 calcFunc 类型的函数注册为计算器的运算符
+func calculator() {
+	fmt.Println("------- calculator -------")
+
+	var max calcFunc = func(x int, y int) int {
+		if x > y {
+			return x
+		}
+		return y
+	}
+
+	// calcFunc 与 calc.Func 的函数签名相同，可以直接转换
+	r := calc.Default()
+	if err := r.Register(calc.Operator{Symbol: "max", Precedence: calc.PrecMultiplicative, Fn: calc.Func(max)}); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, expr := range []string{
+		"1 + 2 * 3",
+		"(1 + 2) * 3",
+		"2 ^ 3 ^ 2",
+		"1 + 2 max 10",
+		"1 / 0",
+		"9223372036854775807 + 1",
+	} {
+		if n, err := r.Eval(expr); err != nil {
+			fmt.Printf("%s => error: %v\n", expr, err)
+		} else {
+			fmt.Printf("%s => %d\n", expr, n)
+		}
+	}
+
+	// 反过来，calc.Mul 也可以作为 calcFunc 传给 calcNumber
+	fmt.Println(calcNumber(6, 7, calc.Mul))
+
+	fmt.Println("------- calculator -------")
+}
@@ -0,0 +1,21 @@
+package typeswitch
+
+import "fmt"
+
+// Describe 返回 v 的类型描述
+func Describe(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return "nil"
+	case int:
+		return fmt.Sprintf("int %d", x)
+	case string:
+		return fmt.Sprintf("string %q", x)
+	case []int:
+		return fmt.Sprintf("%d ints", len(x))
+	case bool, float64:
+		return "bool or float64"
+	default:
+		return "unknown"
+	}
+}
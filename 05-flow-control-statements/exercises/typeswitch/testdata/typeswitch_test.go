@@ -0,0 +1,25 @@
+package typeswitch
+
+import "testing"
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		in   interface{}
+		want string
+	}{
+		{nil, "nil"},
+		{42, "int 42"},
+		{"go", `string "go"`},
+		{[]int{1, 2, 3}, "3 ints"},
+		{[]int(nil), "0 ints"},
+		{true, "bool or float64"},
+		{1.5, "bool or float64"},
+		{struct{}{}, "unknown"},
+		{int64(42), "unknown"},
+	}
+	for _, tt := range tests {
+		if got := Describe(tt.in); got != tt.want {
+			t.Errorf("Describe(%#v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+/*
+Type switches：
+
+	使用 type switch 实现 Describe，根据 v 的类型返回描述：
+		* nil：` "nil" `
+		* int：` "int 42" `
+		* string：` "string \"go\"" `，即使用 %q 输出
+		* []int：` "3 ints" `，即 slice 的长度
+		* bool 或 float64：` "bool or float64" `
+		* 其他类型：` "unknown" `
+*/
+
+//gotour:hint switch-case
+package typeswitch
+
+// Describe 返回 v 的类型描述
+func Describe(v interface{}) string {
+	// TODO
+	return ""
+}
@@ -15,7 +15,7 @@ Flow Control Statements:
 			* For statements with single condition
 				( 仅包含条件判断语句 )
 				举例：
-				```go
+				```go sketch
 					for a < b {
 						...
 					}
@@ -38,7 +38,7 @@ Flow Control Statements:
 						*** post statement 可以忽略，但 Condition 之后的分号不能忽略
 
 				举例：
-					```go
+					```go sketch
 						for i := 0; i < 10; i++ {
 							...
 						}
@@ -95,7 +95,7 @@ Flow Control Statements:
 						- channel
 
 					不同类型的值，在每次循环体中，iteration variables 的值以表格展示如下：
-						```go
+						```text
 							Range expression                          1st value          2nd value
 
 							array or slice  a  [n]E, *[n]E, or []E    index    i  int    a[i]       E
@@ -190,7 +190,7 @@ Flow Control Statements:
 						** fallthrough 语句必须位于所属 clause 的最后一个语句
 
 				举例：
-					```go
+					```go sketch
 						switch tag {
 						default: s3()					// default clause 位置没有强制要求
 						case 0, 1, 2, 3: s1()			// case clause 支持 expression 列表
@@ -242,7 +242,7 @@ Flow Control Statements:
 					** Switch Expression 之前可以添加表达式，该表达式只会在 Type Switches 中取值一次
 
 				举例：
-					```go
+					```go sketch
 						switch i := x.(type) {
 						case nil:
 							printString("x is nil")                // type of i is type of x (interface{})
@@ -260,7 +260,7 @@ Flow Control Statements:
 					```
 
 					上面的 Type Switches 使用 if-else 语句来改写如下：
-					```go
+					```go sketch
 						v := x  								   // x is evaluated exactly once
 						if v == nil {
 							i := v                                 // type of i is type of x (interface{})
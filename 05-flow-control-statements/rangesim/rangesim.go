@@ -0,0 +1,116 @@
+/*
+Package rangesim 通过大量试验观察 range 语句的行为，参考 05-flow-control-statements 中的 For statements with range clause
+
+	章节中列出的 range 规则有些是确定的，有些只给出了可能的结果：
+		* 在循环体中删除一个尚未遍历的 key，该 key 不会出现在后续的循环中
+		* 在循环体中新创建的 key，可能出现，也可能不出现在后续的循环中
+		* range 一个 nil 的 channel 会永远阻塞
+		* range expression 仅会在遍历开始之前取值一次；len 为常量且只有一个 iteration variable 时不会取值
+
+	每个 Scenario 对应一条规则，Run 按照给定的 seed 生成每次试验的参数（map 的大小、删除或者插入的 key 等），
+	运行 Config.Trials 次试验，统计每种结果出现的次数；
+	map 的遍历顺序由 runtime 随机决定，不受 seed 控制，所以同一个 seed 下插入 key 的统计结果也会有所不同
+
+	nil channel 的试验不会结束，通过 Config.Timeout 判定为阻塞：
+		阻塞的 goroutine 无法被唤醒，每次试验都会泄漏一个 goroutine，所以这个场景的试验次数不超过 MaxBlockingTrials
+*/
+package rangesim
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// MaxBlockingTrials 为 nil channel 场景的最大试验次数
+const MaxBlockingTrials = 100
+
+// Config 为试验的配置
+type Config struct {
+	Trials  int           // 每个场景的试验次数
+	Seed    int64         // 生成试验参数的随机数种子
+	Timeout time.Duration // 超过该时间没有结束的 range nil channel 判定为阻塞，需要大于 0
+}
+
+// DefaultConfig 返回默认配置
+func DefaultConfig() Config {
+	return Config{Trials: 1000, Seed: 1, Timeout: 20 * time.Millisecond}
+}
+
+// Scenario 为一条 range 规则的试验
+type Scenario struct {
+	Name string
+	Rule string // 章节中对应的规则
+	run  func(c Config, rng *rand.Rand, record func(outcome string))
+}
+
+// each 返回逐次运行 trial 的 run 函数
+func each(trial func(rng *rand.Rand) string) func(Config, *rand.Rand, func(string)) {
+	return func(c Config, rng *rand.Rand, record func(string)) {
+		for i := 0; i < c.Trials; i++ {
+			record(trial(rng))
+		}
+	}
+}
+
+// Outcome 为一种结果及其出现的次数
+type Outcome struct {
+	Name  string
+	Count int
+}
+
+// Result 为一个场景的试验结果
+type Result struct {
+	Scenario *Scenario
+	Trials   int
+	Outcomes []Outcome // 按出现次数从多到少排列，次数相同时按名字排列
+}
+
+// Count 返回结果 name 出现的次数
+func (r *Result) Count(name string) int {
+	for _, o := range r.Outcomes {
+		if o.Name == name {
+			return o.Count
+		}
+	}
+	return 0
+}
+
+// Run 运行场景 s 的试验
+func Run(s *Scenario, c Config) *Result {
+	counts := map[string]int{}
+	trials := 0
+	s.run(c, rand.New(rand.NewSource(c.Seed)), func(outcome string) {
+		counts[outcome]++
+		trials++
+	})
+
+	r := &Result{Scenario: s, Trials: trials}
+	for name, n := range counts {
+		r.Outcomes = append(r.Outcomes, Outcome{name, n})
+	}
+	sort.Slice(r.Outcomes, func(i, j int) bool {
+		a, b := r.Outcomes[i], r.Outcomes[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Name < b.Name
+	})
+	return r
+}
+
+// Scenarios 返回所有场景，顺序与章节中规则出现的顺序相同
+func Scenarios() []*Scenario {
+	return scenarios
+}
+
+// Lookup 按名字查找场景
+func Lookup(name string) (*Scenario, error) {
+	for _, s := range scenarios {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown scenario %q", name)
+}
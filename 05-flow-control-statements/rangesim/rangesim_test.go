@@ -0,0 +1,125 @@
+package rangesim
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func run(t *testing.T, name string, c Config) *Result {
+	t.Helper()
+	s, err := Lookup(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := Run(s, c)
+	total := 0
+	for _, o := range r.Outcomes {
+		total += o.Count
+	}
+	if total != r.Trials {
+		t.Errorf("%s: outcomes add up to %d, want %d", name, total, r.Trials)
+	}
+	return r
+}
+
+// TestDeterministic 检查确定的规则在所有试验中都成立
+func TestDeterministic(t *testing.T) {
+	c := Config{Trials: 500, Seed: 7}
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"eval-once", []string{"evaluated 1 time(s), appended elements not visited"}},
+		{"const-len", []string{
+			"for i := range p: not dereferenced, 4 iterations",
+			"for i, v := range p: dereferenced, nil pointer panic",
+			"for i := range get(): get called 1 time(s), 4 iterations",
+		}},
+		{"map-delete", []string{"deleted key not produced"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := run(t, tt.name, c)
+			if r.Trials != c.Trials {
+				t.Errorf("%d trials, want %d", r.Trials, c.Trials)
+			}
+			if len(r.Outcomes) != len(tt.want) {
+				t.Errorf("outcomes = %v, want %q", r.Outcomes, tt.want)
+			}
+			for _, want := range tt.want {
+				if r.Count(want) == 0 {
+					t.Errorf("outcome %q never observed: %v", want, r.Outcomes)
+				}
+			}
+		})
+	}
+}
+
+// TestMapInsert 检查插入的 key 两种结果都会出现
+func TestMapInsert(t *testing.T) {
+	r := run(t, "map-insert", Config{Trials: 2000, Seed: 1})
+	for _, want := range []string{"inserted key produced", "inserted key not produced"} {
+		if r.Count(want) == 0 {
+			t.Errorf("outcome %q never observed: %v", want, r.Outcomes)
+		}
+	}
+}
+
+func TestNilChannel(t *testing.T) {
+	start := time.Now()
+	r := run(t, "nil-channel", Config{Trials: 1000, Seed: 3, Timeout: 50 * time.Millisecond})
+	if r.Trials != MaxBlockingTrials {
+		t.Errorf("%d trials, want %d", r.Trials, MaxBlockingTrials)
+	}
+	if d := time.Since(start); d > 10*time.Second {
+		t.Errorf("trials took %v, want about one timeout", d)
+	}
+	for _, o := range r.Outcomes {
+		nilChan := strings.HasPrefix(o.Name, "nil channel")
+		if blocked := strings.Contains(o.Name, "blocked"); blocked != nilChan {
+			t.Errorf("unexpected outcome %q", o.Name)
+		}
+	}
+	if r.Count("nil channel: blocked after 50ms") == 0 || r.Count("closed channel: finished after 3 values") == 0 {
+		t.Errorf("outcomes = %v", r.Outcomes)
+	}
+}
+
+// TestNilChannelShortTimeout 检查 Timeout 很短时，closed channel 的试验仍然等待完成，不会被判定为阻塞
+func TestNilChannelShortTimeout(t *testing.T) {
+	r := run(t, "nil-channel", Config{Trials: MaxBlockingTrials, Seed: 5, Timeout: time.Nanosecond})
+	closed := 0
+	for _, o := range r.Outcomes {
+		switch {
+		case strings.HasPrefix(o.Name, "closed channel"):
+			if o.Name != "closed channel: finished after 3 values" {
+				t.Errorf("unexpected outcome %q", o.Name)
+			}
+			closed += o.Count
+		case o.Name != "nil channel: blocked after 1ns":
+			t.Errorf("unexpected outcome %q", o.Name)
+		}
+	}
+	if closed == 0 || closed == r.Trials {
+		t.Errorf("outcomes = %v, want both closed and nil channels", r.Outcomes)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	var b strings.Builder
+	if err := run(t, "map-delete", Config{Trials: 10, Seed: 1}).Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"map-delete: ", "10 trials", "100.0%  deleted key not produced"} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("report does not contain %q\n%s", want, b.String())
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if _, err := Lookup("missing"); err == nil {
+		t.Error("Lookup(missing) succeeded")
+	}
+}
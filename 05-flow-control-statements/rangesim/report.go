@@ -0,0 +1,35 @@
+package rangesim
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Write 输出试验结果：规则、试验次数以及每种结果出现的次数和比例
+func (r *Result) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	pw := &printer{w: tw}
+
+	pw.printf("%s: %s\n", r.Scenario.Name, r.Scenario.Rule)
+	pw.printf("  %d trials\n", r.Trials)
+	for _, o := range r.Outcomes {
+		pw.printf("\t%d\t%.1f%%\t  %s\n", o.Count, 100*float64(o.Count)/float64(r.Trials), o.Name)
+	}
+
+	if pw.err != nil {
+		return pw.err
+	}
+	return tw.Flush()
+}
+
+type printer struct {
+	w   io.Writer
+	err error
+}
+
+func (pw *printer) printf(format string, args ...interface{}) {
+	if pw.err == nil {
+		_, pw.err = fmt.Fprintf(pw.w, format, args...)
+	}
+}
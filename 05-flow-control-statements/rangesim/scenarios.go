@@ -0,0 +1,223 @@
+package rangesim
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+var scenarios = []*Scenario{
+	{
+		Name: "eval-once",
+		Rule: "range expression 仅会在遍历过程开始之前取值一次",
+		run:  each(evalOnce),
+	},
+	{
+		Name: "const-len",
+		Rule: "len(range expression) 为常量，且只声明了一个 iteration variable 时，range expression 本身不会被取值",
+		run:  each(constLen),
+	},
+	{
+		Name: "map-delete",
+		Rule: "若在循环体中删除了一个尚未遍历的 key，则该 key 不会出现在后续的循环",
+		run:  each(mapDelete),
+	},
+	{
+		Name: "map-insert",
+		Rule: "若在循环体中新创建了一个 key，则该 key 可能出现，也可能不出现在后续的循环中",
+		run:  each(mapInsert),
+	},
+	{
+		Name: "nil-channel",
+		Rule: "若 channel 为 nil，则遍历会永远阻塞",
+		run:  nilChannel,
+	},
+}
+
+// evalOnce 的 range expression 为函数调用，循环体中向 slice 追加元素
+func evalOnce(rng *rand.Rand) string {
+	n := 1 + rng.Intn(8)
+	s := make([]int, n)
+	calls := 0
+	next := func() []int {
+		calls++
+		return s
+	}
+
+	iterations := 0
+	for range next() {
+		iterations++
+		s = append(s, iterations)
+	}
+
+	visited := "appended elements not visited"
+	if iterations != n {
+		visited = "appended elements visited"
+	}
+	return fmt.Sprintf("evaluated %d time(s), %s", calls, visited)
+}
+
+// constLen 随机选择一种 range nil *[4]int 的写法，观察 range expression 是否被取值
+func constLen(rng *rand.Rand) (outcome string) {
+	var p *[4]int
+	calls := 0
+	get := func() *[4]int {
+		calls++
+		return p
+	}
+
+	iterations := 0
+	switch rng.Intn(3) {
+	case 0:
+		for i := range p {
+			iterations = i + 1
+		}
+		return fmt.Sprintf("for i := range p: not dereferenced, %d iterations", iterations)
+	case 1:
+		defer func() {
+			if recover() != nil {
+				outcome = "for i, v := range p: dereferenced, nil pointer panic"
+			}
+		}()
+		for _, v := range p {
+			iterations += v
+		}
+		return "for i, v := range p: no panic"
+	default:
+		// 包含函数调用时 len 不是常量，range expression 会被取值，但仍然不会解引用
+		for i := range get() {
+			iterations = i + 1
+		}
+		return fmt.Sprintf("for i := range get(): get called %d time(s), %d iterations", calls, iterations)
+	}
+}
+
+// mapDelete 在随机的一次循环中删除一个随机的、尚未遍历的 key
+func mapDelete(rng *rand.Rand) string {
+	n := 2 + rng.Intn(31)
+	m := make(map[int]bool, n)
+	for k := 0; k < n; k++ {
+		m[k] = true
+	}
+
+	step := rng.Intn(n - 1)
+	visited := map[int]bool{}
+	deleted, produced := -1, false
+	i := 0
+	for k := range m {
+		visited[k] = true
+		if k == deleted {
+			produced = true
+		}
+		if i == step {
+			var unvisited []int
+			for u := 0; u < n; u++ {
+				if !visited[u] {
+					unvisited = append(unvisited, u)
+				}
+			}
+			deleted = unvisited[rng.Intn(len(unvisited))]
+			delete(m, deleted)
+		}
+		i++
+	}
+
+	if produced {
+		return "deleted key produced"
+	}
+	return "deleted key not produced"
+}
+
+// mapInsert 在随机的一次循环中插入一个新的 key
+func mapInsert(rng *rand.Rand) string {
+	n := 1 + rng.Intn(32)
+	m := make(map[int]bool, n)
+	for k := 0; k < n; k++ {
+		m[k] = true
+	}
+
+	step := rng.Intn(n)
+	inserted, produced := n, false
+	i := 0
+	for k := range m {
+		if k == inserted {
+			produced = true
+		}
+		if i == step {
+			m[inserted] = true
+		}
+		i++
+	}
+
+	if produced {
+		return "inserted key produced"
+	}
+	return "inserted key not produced"
+}
+
+// nilChannel 随机 range 一个 nil channel 或者一个已经关闭的 channel：
+// range nil channel 超过 Timeout 没有结束的判定为阻塞；range 已经关闭的 channel 一定会结束，等待它完成，不受 Timeout 影响。
+// 所有试验同时开始，总耗时约为一个 Timeout
+func nilChannel(c Config, rng *rand.Rand, record func(string)) {
+	trials := c.Trials
+	if trials > MaxBlockingTrials {
+		trials = MaxBlockingTrials
+	}
+
+	type trial struct {
+		nil  bool
+		done chan int
+	}
+	ts := make([]trial, trials)
+	for i := range ts {
+		var ch chan int
+		if ts[i].nil = rng.Intn(2) == 0; !ts[i].nil {
+			ch = make(chan int, 3)
+			for v := 0; v < cap(ch); v++ {
+				ch <- v
+			}
+			close(ch)
+		}
+		ts[i].done = make(chan int, 1)
+
+		go func(done chan<- int) {
+			received := 0
+			for range ch {
+				received++
+			}
+			done <- received
+		}(ts[i].done)
+	}
+
+	deadline := time.After(c.Timeout)
+	expired := false
+	for _, t := range ts {
+		if !t.nil {
+			record(fmt.Sprintf("closed channel: finished after %d values", <-t.done))
+			continue
+		}
+
+		received, finished := 0, false
+		if !expired {
+			select {
+			case received = <-t.done:
+				finished = true
+			case <-deadline:
+				expired = true
+			}
+		}
+		if expired && !finished {
+			select {
+			case received = <-t.done:
+				finished = true
+			default:
+			}
+		}
+
+		if finished {
+			record(fmt.Sprintf("nil channel: finished after %d values", received))
+		} else {
+			record(fmt.Sprintf("nil channel: blocked after %v", c.Timeout))
+		}
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Package arraycopy 测量数组复制的开销，参考 06-arrays 中的 arrAsValue 以及数组的值语义
+
+	数组是值类型，以下操作在语义上都会复制整个数组：
+		* 赋值：`dst := src`
+		* 作为参数传递：`f(src)`，传递指针 `f(&src)` 只复制 8 个字节
+		* range 数组且声明了 value variable：`for _, v := range src`，range expression 被取值一次，即复制一次；
+			只声明 index variable 时 len 为常量，range expression 不会被取值，不会复制
+
+	每个操作对 Sizes 中的每个大小（8 B 到 1 MiB）都有一个基准测试，cases.go 由 gen.go 生成；
+	编译器可能消除复制（比如内联之后），Compile 解析 `go build -gcflags=-m` 的输出，
+	报告每个基准测试中的函数是否被内联、变量是否逃逸到堆上
+
+	运行：
+		```
+			go test -bench . ./06-arrays/arraycopy
+			gotour bench arrays
+		```
+*/
+package arraycopy
+
+//go:generate go run gen.go
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// Sizes 为数组的大小（字节数），与 gen.go 中的 sizes 相同
+var Sizes = []int{8, 64, 512, 4 << 10, 32 << 10, 256 << 10, 1 << 20}
+
+// 各个操作
+const (
+	OpAssign        = "assign"         // dst := src
+	OpValue         = "value"          // 传值调用可以内联的函数
+	OpValueNoInline = "value-noinline" // 传值调用不能内联的函数
+	OpPointer       = "pointer"        // 传指针调用不能内联的函数
+	OpRangeValue    = "range-value"    // for _, v := range src
+	OpRangeIndex    = "range-index"    // for i := range src
+)
+
+// Ops 为所有操作，按报告中的顺序排列
+var Ops = []string{OpAssign, OpValue, OpValueNoInline, OpPointer, OpRangeValue, OpRangeIndex}
+
+// baselines 为每个操作的对照：对照与操作的区别只在于是否复制数组
+var baselines = map[string]string{
+	OpValue:         OpPointer,
+	OpValueNoInline: OpPointer,
+	OpRangeValue:    OpRangeIndex,
+}
+
+// Case 为一个操作在一个数组大小上的基准测试
+type Case struct {
+	Op   string
+	Size int
+	Run  func(n int) byte // 执行 n 次操作
+}
+
+func (c Case) Name() string {
+	return c.Op + "/" + SizeName(c.Size)
+}
+
+// Cases 返回所有基准测试，按大小、操作排列
+func Cases() []Case {
+	return cases
+}
+
+// SizeName 返回易读的大小，比如 4KiB
+func SizeName(size int) string {
+	switch {
+	case size >= 1<<20 && size%(1<<20) == 0:
+		return fmt.Sprintf("%dMiB", size>>20)
+	case size >= 1<<10 && size%(1<<10) == 0:
+		return fmt.Sprintf("%dKiB", size>>10)
+	}
+	return fmt.Sprintf("%dB", size)
+}
+
+// Result 为基准测试的结果
+type Result struct {
+	Case
+	N           int
+	NsPerOp     float64
+	BytesPerOp  int64
+	AllocsPerOp int64
+}
+
+// sink 保存 Run 的结果，避免循环被编译器优化掉
+var sink byte
+
+// Measure 运行基准测试，与 testing.B 一样逐渐增加次数，直到运行时间达到 d
+func Measure(c Case, d time.Duration) Result {
+	var before, after runtime.MemStats
+	n := 1
+	for {
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		sink += c.Run(n)
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+
+		if elapsed >= d || n >= 1e9 {
+			return Result{
+				Case:        c,
+				N:           n,
+				NsPerOp:     float64(elapsed.Nanoseconds()) / float64(n),
+				BytesPerOp:  int64(after.TotalAlloc-before.TotalAlloc) / int64(n),
+				AllocsPerOp: int64(after.Mallocs-before.Mallocs) / int64(n),
+			}
+		}
+
+		// 按已经运行的时间预估达到 d 所需的次数，最多增加为原来的 100 倍
+		next := n * 100
+		if elapsed > 0 {
+			if predicted := int(float64(n) * 1.2 * float64(d) / float64(elapsed)); predicted < next {
+				next = predicted
+			}
+		}
+		if next <= n {
+			next = n + 1
+		}
+		n = next
+	}
+}
@@ -0,0 +1,138 @@
+package arraycopy
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCases(t *testing.T) {
+	seen := map[string]bool{}
+	for _, c := range Cases() {
+		seen[c.Name()] = true
+		if got := c.Run(1); got != 0 && c.Op != OpAssign {
+			t.Errorf("%s: Run(1) = %d, want 0", c.Name(), got)
+		}
+	}
+	for _, size := range Sizes {
+		for _, op := range Ops {
+			name := Case{Op: op, Size: size}.Name()
+			if !seen[name] {
+				t.Errorf("missing case %s (run `go generate ./06-arrays/arraycopy`)", name)
+			}
+		}
+	}
+	if len(seen) != len(Sizes)*len(Ops) {
+		t.Errorf("%d cases, want %d", len(seen), len(Sizes)*len(Ops))
+	}
+}
+
+func TestSizeName(t *testing.T) {
+	tests := map[int]string{8: "8B", 512: "512B", 4096: "4KiB", 1 << 20: "1MiB", 1536: "1536B"}
+	for size, want := range tests {
+		if got := SizeName(size); got != want {
+			t.Errorf("SizeName(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	c := Case{Op: OpAssign, Size: 1 << 20, Run: assign1048576}
+	r := Measure(c, 10*time.Millisecond)
+	if r.N < 1 || r.NsPerOp <= 0 {
+		t.Errorf("N = %d, ns/op = %v", r.N, r.NsPerOp)
+	}
+	// 1 MiB 的局部变量超过了栈上变量的大小限制，被移动到堆上
+	if r.AllocsPerOp != 1 || r.BytesPerOp < 1<<20 {
+		t.Errorf("allocs/op = %d, B/op = %d, want 1 allocation of 1MiB", r.AllocsPerOp, r.BytesPerOp)
+	}
+}
+
+const gcflagsOutput = `# github.com/SamHwang1990/go-tour/06-arrays/arraycopy
+./cases.go:65:22: inlining call to lastByValue8
+./cases.go:452:3: moved to heap: dst
+./cases.go:461:28: inlining call to lastByValue1048576
+./cases.go:461:28: moved to heap: a
+./arraycopy.go:61:20: c.Op + "/" + SizeName(c.Size) escapes to heap
+<autogenerated>:1: inlining call to Case.Name
+`
+
+func TestParseGCFlags(t *testing.T) {
+	diags, err := ParseGCFlags([]byte(gcflagsOutput), ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diags) != 5 {
+		t.Fatalf("%d diagnostics, want 5: %v", len(diags), diags)
+	}
+	if d := diags[0]; d.Func != "value8" || d.Pos.Filename != "cases.go" || d.Pos.Line != 65 || d.Message != "inlining call to lastByValue8" {
+		t.Errorf("diags[0] = %+v", d)
+	}
+	if d := diags[4]; d.Func != "Name" {
+		t.Errorf("diags[4].Func = %q, want Name", d.Func)
+	}
+
+	tests := []struct {
+		c    Case
+		want string
+	}{
+		{Case{Op: OpValue, Size: 8}, "inlined lastByValue8"},
+		{Case{Op: OpAssign, Size: 1 << 20}, "dst moved to heap"},
+		{Case{Op: OpValue, Size: 1 << 20}, "inlined lastByValue1048576, a moved to heap"},
+		{Case{Op: OpPointer, Size: 8}, ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(Notes(diags, tt.c), ", "); got != tt.want {
+			t.Errorf("Notes(%s) = %q, want %q", tt.c.Name(), got, tt.want)
+		}
+	}
+}
+
+// TestCompile 检查实际的编译器输出：传值调用被内联，超过栈大小限制的数组被移动到堆上
+func TestCompile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode: runs go build")
+	}
+	diags, err := Compile(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		c    Case
+		want string
+	}{
+		{Case{Op: OpValue, Size: 64}, "inlined lastByValue64"},
+		{Case{Op: OpAssign, Size: 1 << 20}, "dst moved to heap"},
+	} {
+		if got := strings.Join(Notes(diags, tt.c), ", "); !strings.Contains(got, tt.want) {
+			t.Errorf("Notes(%s) = %q, want %q", tt.c.Name(), got, tt.want)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	results := []Result{
+		{Case: Case{Op: OpValueNoInline, Size: 4096}, NsPerOp: 60},
+		{Case: Case{Op: OpPointer, Size: 4096}, NsPerOp: 2},
+	}
+	var b strings.Builder
+	if err := Write(&b, results, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "30.0x pointer") {
+		t.Errorf("report does not contain the baseline ratio\n%s", b.String())
+	}
+}
+
+// 运行：
+//
+//	go test -bench . ./06-arrays/arraycopy
+//	go test -bench 'value-noinline|pointer' ./06-arrays/arraycopy
+func BenchmarkArrays(b *testing.B) {
+	for _, c := range Cases() {
+		b.Run(c.Name(), func(b *testing.B) {
+			b.ReportAllocs()
+			sink += c.Run(b.N)
+		})
+	}
+}
@@ -0,0 +1,510 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package arraycopy
+
+var cases = []Case{
+	{OpAssign, 8, assign8},
+	{OpValue, 8, value8},
+	{OpValueNoInline, 8, valueNoInline8},
+	{OpPointer, 8, pointer8},
+	{OpRangeValue, 8, rangeValue8},
+	{OpRangeIndex, 8, rangeIndex8},
+	{OpAssign, 64, assign64},
+	{OpValue, 64, value64},
+	{OpValueNoInline, 64, valueNoInline64},
+	{OpPointer, 64, pointer64},
+	{OpRangeValue, 64, rangeValue64},
+	{OpRangeIndex, 64, rangeIndex64},
+	{OpAssign, 512, assign512},
+	{OpValue, 512, value512},
+	{OpValueNoInline, 512, valueNoInline512},
+	{OpPointer, 512, pointer512},
+	{OpRangeValue, 512, rangeValue512},
+	{OpRangeIndex, 512, rangeIndex512},
+	{OpAssign, 4096, assign4096},
+	{OpValue, 4096, value4096},
+	{OpValueNoInline, 4096, valueNoInline4096},
+	{OpPointer, 4096, pointer4096},
+	{OpRangeValue, 4096, rangeValue4096},
+	{OpRangeIndex, 4096, rangeIndex4096},
+	{OpAssign, 32768, assign32768},
+	{OpValue, 32768, value32768},
+	{OpValueNoInline, 32768, valueNoInline32768},
+	{OpPointer, 32768, pointer32768},
+	{OpRangeValue, 32768, rangeValue32768},
+	{OpRangeIndex, 32768, rangeIndex32768},
+	{OpAssign, 262144, assign262144},
+	{OpValue, 262144, value262144},
+	{OpValueNoInline, 262144, valueNoInline262144},
+	{OpPointer, 262144, pointer262144},
+	{OpRangeValue, 262144, rangeValue262144},
+	{OpRangeIndex, 262144, rangeIndex262144},
+	{OpAssign, 1048576, assign1048576},
+	{OpValue, 1048576, value1048576},
+	{OpValueNoInline, 1048576, valueNoInline1048576},
+	{OpPointer, 1048576, pointer1048576},
+	{OpRangeValue, 1048576, rangeValue1048576},
+	{OpRangeIndex, 1048576, rangeIndex1048576},
+}
+
+type array8 [8]byte
+
+var src8 array8
+
+func assign8(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		dst := src8
+		dst[i%len(dst)]++
+		sum += dst[len(dst)-1]
+	}
+	return sum
+}
+
+func value8(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByValue8(src8)
+	}
+	return sum
+}
+
+func lastByValue8(a array8) byte {
+	return a[len(a)-1]
+}
+
+func valueNoInline8(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByValueNoInline8(src8)
+	}
+	return sum
+}
+
+//go:noinline
+func lastByValueNoInline8(a array8) byte {
+	return a[len(a)-1]
+}
+
+func pointer8(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByPointer8(&src8)
+	}
+	return sum
+}
+
+//go:noinline
+func lastByPointer8(a *array8) byte {
+	return a[len(a)-1]
+}
+
+func rangeValue8(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		for _, v := range src8 {
+			sum += v
+		}
+	}
+	return sum
+}
+
+func rangeIndex8(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		for j := range src8 {
+			sum += src8[j]
+		}
+	}
+	return sum
+}
+
+type array64 [64]byte
+
+var src64 array64
+
+func assign64(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		dst := src64
+		dst[i%len(dst)]++
+		sum += dst[len(dst)-1]
+	}
+	return sum
+}
+
+func value64(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByValue64(src64)
+	}
+	return sum
+}
+
+func lastByValue64(a array64) byte {
+	return a[len(a)-1]
+}
+
+func valueNoInline64(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByValueNoInline64(src64)
+	}
+	return sum
+}
+
+//go:noinline
+func lastByValueNoInline64(a array64) byte {
+	return a[len(a)-1]
+}
+
+func pointer64(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByPointer64(&src64)
+	}
+	return sum
+}
+
+//go:noinline
+func lastByPointer64(a *array64) byte {
+	return a[len(a)-1]
+}
+
+func rangeValue64(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		for _, v := range src64 {
+			sum += v
+		}
+	}
+	return sum
+}
+
+func rangeIndex64(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		for j := range src64 {
+			sum += src64[j]
+		}
+	}
+	return sum
+}
+
+type array512 [512]byte
+
+var src512 array512
+
+func assign512(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		dst := src512
+		dst[i%len(dst)]++
+		sum += dst[len(dst)-1]
+	}
+	return sum
+}
+
+func value512(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByValue512(src512)
+	}
+	return sum
+}
+
+func lastByValue512(a array512) byte {
+	return a[len(a)-1]
+}
+
+func valueNoInline512(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByValueNoInline512(src512)
+	}
+	return sum
+}
+
+//go:noinline
+func lastByValueNoInline512(a array512) byte {
+	return a[len(a)-1]
+}
+
+func pointer512(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByPointer512(&src512)
+	}
+	return sum
+}
+
+//go:noinline
+func lastByPointer512(a *array512) byte {
+	return a[len(a)-1]
+}
+
+func rangeValue512(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		for _, v := range src512 {
+			sum += v
+		}
+	}
+	return sum
+}
+
+func rangeIndex512(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		for j := range src512 {
+			sum += src512[j]
+		}
+	}
+	return sum
+}
+
+type array4096 [4096]byte
+
+var src4096 array4096
+
+func assign4096(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		dst := src4096
+		dst[i%len(dst)]++
+		sum += dst[len(dst)-1]
+	}
+	return sum
+}
+
+func value4096(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByValue4096(src4096)
+	}
+	return sum
+}
+
+func lastByValue4096(a array4096) byte {
+	return a[len(a)-1]
+}
+
+func valueNoInline4096(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByValueNoInline4096(src4096)
+	}
+	return sum
+}
+
+//go:noinline
+func lastByValueNoInline4096(a array4096) byte {
+	return a[len(a)-1]
+}
+
+func pointer4096(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByPointer4096(&src4096)
+	}
+	return sum
+}
+
+//go:noinline
+func lastByPointer4096(a *array4096) byte {
+	return a[len(a)-1]
+}
+
+func rangeValue4096(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		for _, v := range src4096 {
+			sum += v
+		}
+	}
+	return sum
+}
+
+func rangeIndex4096(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		for j := range src4096 {
+			sum += src4096[j]
+		}
+	}
+	return sum
+}
+
+type array32768 [32768]byte
+
+var src32768 array32768
+
+func assign32768(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		dst := src32768
+		dst[i%len(dst)]++
+		sum += dst[len(dst)-1]
+	}
+	return sum
+}
+
+func value32768(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByValue32768(src32768)
+	}
+	return sum
+}
+
+func lastByValue32768(a array32768) byte {
+	return a[len(a)-1]
+}
+
+func valueNoInline32768(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByValueNoInline32768(src32768)
+	}
+	return sum
+}
+
+//go:noinline
+func lastByValueNoInline32768(a array32768) byte {
+	return a[len(a)-1]
+}
+
+func pointer32768(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByPointer32768(&src32768)
+	}
+	return sum
+}
+
+//go:noinline
+func lastByPointer32768(a *array32768) byte {
+	return a[len(a)-1]
+}
+
+func rangeValue32768(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		for _, v := range src32768 {
+			sum += v
+		}
+	}
+	return sum
+}
+
+func rangeIndex32768(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		for j := range src32768 {
+			sum += src32768[j]
+		}
+	}
+	return sum
+}
+
+type array262144 [262144]byte
+
+var src262144 array262144
+
+func assign262144(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		dst := src262144
+		dst[i%len(dst)]++
+		sum += dst[len(dst)-1]
+	}
+	return sum
+}
+
+func value262144(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByValue262144(src262144)
+	}
+	return sum
+}
+
+func lastByValue262144(a array262144) byte {
+	return a[len(a)-1]
+}
+
+func valueNoInline262144(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByValueNoInline262144(src262144)
+	}
+	return sum
+}
+
+//go:noinline
+func lastByValueNoInline262144(a array262144) byte {
+	return a[len(a)-1]
+}
+
+func pointer262144(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByPointer262144(&src262144)
+	}
+	return sum
+}
+
+//go:noinline
+func lastByPointer262144(a *array262144) byte {
+	return a[len(a)-1]
+}
+
+func rangeValue262144(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		for _, v := range src262144 {
+			sum += v
+		}
+	}
+	return sum
+}
+
+func rangeIndex262144(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		for j := range src262144 {
+			sum += src262144[j]
+		}
+	}
+	return sum
+}
+
+type array1048576 [1048576]byte
+
+var src1048576 array1048576
+
+func assign1048576(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		dst := src1048576
+		dst[i%len(dst)]++
+		sum += dst[len(dst)-1]
+	}
+	return sum
+}
+
+func value1048576(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByValue1048576(src1048576)
+	}
+	return sum
+}
+
+func lastByValue1048576(a array1048576) byte {
+	return a[len(a)-1]
+}
+
+func valueNoInline1048576(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByValueNoInline1048576(src1048576)
+	}
+	return sum
+}
+
+//go:noinline
+func lastByValueNoInline1048576(a array1048576) byte {
+	return a[len(a)-1]
+}
+
+func pointer1048576(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByPointer1048576(&src1048576)
+	}
+	return sum
+}
+
+//go:noinline
+func lastByPointer1048576(a *array1048576) byte {
+	return a[len(a)-1]
+}
+
+func rangeValue1048576(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		for _, v := range src1048576 {
+			sum += v
+		}
+	}
+	return sum
+}
+
+func rangeIndex1048576(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		for j := range src1048576 {
+			sum += src1048576[j]
+		}
+	}
+	return sum
+}
@@ -0,0 +1,138 @@
+package arraycopy
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Diagnostic 为 `go build -gcflags=-m` 输出的一条编译器决定
+type Diagnostic struct {
+	Pos     token.Position
+	Func    string // 所在的函数，不在函数中时为空
+	Message string // 比如 "inlining call to lastByValue8"、"moved to heap: dst"
+}
+
+// Compile 在 dir 中运行 `go build -gcflags=-m`，返回解析后的输出
+func Compile(dir string) ([]Diagnostic, error) {
+	cmd := exec.Command("go", "build", "-gcflags=-m", ".")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("go build -gcflags=-m: %v\n%s", err, out)
+	}
+	return ParseGCFlags(out, dir)
+}
+
+// ParseGCFlags 解析 `go build -gcflags=-m` 的输出，dir 为 package 所在目录，用于确定每条输出所在的函数；
+// 忽略 package 名字所在的行以及 <autogenerated> 中的输出
+func ParseGCFlags(out []byte, dir string) ([]Diagnostic, error) {
+	funcs, err := funcRanges(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var diags []Diagnostic
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "<autogenerated>") {
+			continue
+		}
+		// file:line:col: message
+		parts := strings.SplitN(line, ":", 4)
+		if len(parts) != 4 {
+			continue
+		}
+		ln, err1 := strconv.Atoi(parts[1])
+		col, err2 := strconv.Atoi(parts[2])
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		d := Diagnostic{
+			Pos:     token.Position{Filename: filepath.Base(parts[0]), Line: ln, Column: col},
+			Message: strings.TrimSpace(parts[3]),
+		}
+		for _, f := range funcs[d.Pos.Filename] {
+			if f.start <= ln && ln <= f.end {
+				d.Func = f.name
+				break
+			}
+		}
+		diags = append(diags, d)
+	}
+	return diags, sc.Err()
+}
+
+type funcRange struct {
+	name       string
+	start, end int
+}
+
+// funcRanges 返回 dir 中每个文件的函数所在的行
+func funcRanges(dir string) (map[string][]funcRange, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	ranges := map[string][]funcRange{}
+	for _, name := range bp.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(bp.Dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok {
+				ranges[name] = append(ranges[name], funcRange{
+					name:  fn.Name.Name,
+					start: fset.Position(fn.Pos()).Line,
+					end:   fset.Position(fn.End()).Line,
+				})
+			}
+		}
+	}
+	return ranges, nil
+}
+
+// caseFuncs 为每个操作在 cases.go 中的函数名，%d 为数组大小
+var caseFuncs = map[string][]string{
+	OpAssign:        {"assign%d"},
+	OpValue:         {"value%d", "lastByValue%d"},
+	OpValueNoInline: {"valueNoInline%d", "lastByValueNoInline%d"},
+	OpPointer:       {"pointer%d", "lastByPointer%d"},
+	OpRangeValue:    {"rangeValue%d"},
+	OpRangeIndex:    {"rangeIndex%d"},
+}
+
+// Notes 返回与基准测试 c 相关的编译器决定：调用是否被内联、变量是否被移动到堆上
+func Notes(diags []Diagnostic, c Case) []string {
+	funcs := map[string]bool{}
+	for _, format := range caseFuncs[c.Op] {
+		funcs[fmt.Sprintf(format, c.Size)] = true
+	}
+
+	var notes []string
+	for _, d := range diags {
+		if !funcs[d.Func] {
+			continue
+		}
+		switch msg := d.Message; {
+		case strings.HasPrefix(msg, "inlining call to "):
+			notes = append(notes, "inlined "+strings.TrimPrefix(msg, "inlining call to "))
+		case strings.HasPrefix(msg, "moved to heap: "):
+			notes = append(notes, strings.TrimPrefix(msg, "moved to heap: ")+" moved to heap")
+		case strings.HasSuffix(msg, " escapes to heap"):
+			notes = append(notes, msg)
+		}
+	}
+	return notes
+}
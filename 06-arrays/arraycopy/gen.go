@@ -0,0 +1,113 @@
+//go:build ignore
+
+// gen 为 Sizes 中的每个大小生成数组类型以及各个操作的基准测试函数，写入 cases.go，运行：
+//
+//	go generate ./06-arrays/arraycopy
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"log"
+	"os"
+	"text/template"
+)
+
+// 与 arraycopy.go 中的 Sizes 相同
+var sizes = []int{8, 64, 512, 4 << 10, 32 << 10, 256 << 10, 1 << 20}
+
+var tmpl = template.Must(template.New("cases").Parse(`// Code generated by gen.go; DO NOT EDIT.
+
+package arraycopy
+
+var cases = []Case{
+{{- range .}}
+	{OpAssign, {{.}}, assign{{.}}},
+	{OpValue, {{.}}, value{{.}}},
+	{OpValueNoInline, {{.}}, valueNoInline{{.}}},
+	{OpPointer, {{.}}, pointer{{.}}},
+	{OpRangeValue, {{.}}, rangeValue{{.}}},
+	{OpRangeIndex, {{.}}, rangeIndex{{.}}},
+{{- end}}
+}
+{{range .}}
+type array{{.}} [{{.}}]byte
+
+var src{{.}} array{{.}}
+
+func assign{{.}}(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		dst := src{{.}}
+		dst[i%len(dst)]++
+		sum += dst[len(dst)-1]
+	}
+	return sum
+}
+
+func value{{.}}(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByValue{{.}}(src{{.}})
+	}
+	return sum
+}
+
+func lastByValue{{.}}(a array{{.}}) byte {
+	return a[len(a)-1]
+}
+
+func valueNoInline{{.}}(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByValueNoInline{{.}}(src{{.}})
+	}
+	return sum
+}
+
+//go:noinline
+func lastByValueNoInline{{.}}(a array{{.}}) byte {
+	return a[len(a)-1]
+}
+
+func pointer{{.}}(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		sum += lastByPointer{{.}}(&src{{.}})
+	}
+	return sum
+}
+
+//go:noinline
+func lastByPointer{{.}}(a *array{{.}}) byte {
+	return a[len(a)-1]
+}
+
+func rangeValue{{.}}(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		for _, v := range src{{.}} {
+			sum += v
+		}
+	}
+	return sum
+}
+
+func rangeIndex{{.}}(n int) (sum byte) {
+	for i := 0; i < n; i++ {
+		for j := range src{{.}} {
+			sum += src{{.}}[j]
+		}
+	}
+	return sum
+}
+{{end}}`))
+
+func main() {
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, sizes); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("cases.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
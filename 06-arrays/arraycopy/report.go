@@ -0,0 +1,61 @@
+package arraycopy
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Write 按数组大小输出基准测试结果；vs baseline 为与对照操作的耗时之比，
+// 接近 1.0x 表示复制被消除或者可以忽略，diags 为 Compile 的结果，可以为 nil
+func Write(w io.Writer, results []Result, diags []Diagnostic) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	pw := &printer{w: tw}
+
+	byName := map[string]Result{}
+	for _, r := range results {
+		byName[r.Name()] = r
+	}
+
+	pw.printf("size\top\tns/op\tvs baseline\tB/op\tallocs/op\tcompiler (-gcflags=-m)\n")
+	for _, r := range results {
+		ratio := "-"
+		if op, ok := baselines[r.Op]; ok {
+			if b, ok := byName[Case{Op: op, Size: r.Size}.Name()]; ok && b.NsPerOp > 0 {
+				ratio = fmt.Sprintf("%.1fx %s", r.NsPerOp/b.NsPerOp, op)
+			}
+		}
+		notes := "-"
+		if n := Notes(diags, r.Case); len(n) > 0 {
+			notes = strings.Join(n, ", ")
+		}
+		pw.printf("%s\t%s\t%s\t%s\t%d\t%d\t%s\n", SizeName(r.Size), r.Op, ns(r.NsPerOp), ratio, r.BytesPerOp, r.AllocsPerOp, notes)
+	}
+
+	if pw.err != nil {
+		return pw.err
+	}
+	return tw.Flush()
+}
+
+func ns(v float64) string {
+	switch {
+	case v >= 100:
+		return fmt.Sprintf("%.0f", v)
+	case v >= 10:
+		return fmt.Sprintf("%.1f", v)
+	}
+	return fmt.Sprintf("%.2f", v)
+}
+
+type printer struct {
+	w   io.Writer
+	err error
+}
+
+func (pw *printer) printf(format string, args ...interface{}) {
+	if pw.err == nil {
+		_, pw.err = fmt.Fprintf(pw.w, format, args...)
+	}
+}
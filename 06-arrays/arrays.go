@@ -66,7 +66,7 @@ Array: 数组
 			所用 index 更新元素：` arr[index] = newValue `
 
 		* 遍历数组（ for loop ）
-			```go
+			```go sketch
 				arr := [...]int{1, 2, 3, 4, 5}
 
 				for index, value := range arr {
@@ -0,0 +1,25 @@
+/*
+数组是值类型：
+
+	* Reversed 返回反转后的数组，不能修改传入的数组
+	* ReverseInPlace 直接反转传入的数组，
+		目前的实现修改的是数组的副本，调用者看不到任何变化，请修正
+*/
+
+//gotour:hint 值类型-非引用类型
+//gotour:demo arrAsValue
+package arrayvalue
+
+// Reversed 返回反转后的数组
+func Reversed(a [5]int) [5]int {
+	// TODO
+	return [5]int{}
+}
+
+// ReverseInPlace 反转 a 指向的数组
+func ReverseInPlace(a *[5]int) {
+	b := *a
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
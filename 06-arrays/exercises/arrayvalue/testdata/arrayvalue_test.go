@@ -0,0 +1,22 @@
+package arrayvalue
+
+import "testing"
+
+func TestReversed(t *testing.T) {
+	a := [5]int{1, 2, 3, 4, 5}
+	got := Reversed(a)
+	if want := [5]int{5, 4, 3, 2, 1}; got != want {
+		t.Errorf("Reversed(%v) = %v, want %v", a, got, want)
+	}
+	if want := [5]int{1, 2, 3, 4, 5}; a != want {
+		t.Errorf("Reversed modified its argument: %v", a)
+	}
+}
+
+func TestReverseInPlace(t *testing.T) {
+	a := [5]int{1, 2, 3, 4, 5}
+	ReverseInPlace(&a)
+	if want := [5]int{5, 4, 3, 2, 1}; a != want {
+		t.Errorf("after ReverseInPlace, a = %v, want %v", a, want)
+	}
+}
@@ -0,0 +1,14 @@
+package arrayvalue
+
+// Reversed 返回反转后的数组
+func Reversed(a [5]int) [5]int {
+	ReverseInPlace(&a)
+	return a
+}
+
+// ReverseInPlace 反转 a 指向的数组
+func ReverseInPlace(a *[5]int) {
+	for i, j := 0, len(a)-1; i < j; i, j = i+1, j-1 {
+		a[i], a[j] = a[j], a[i]
+	}
+}
@@ -0,0 +1,141 @@
+package vec
+
+import (
+	"fmt"
+	"hash/maphash"
+)
+
+// Rows 为矩阵的底层数组类型，每个元素为一行
+type Rows[T Number, R Array[T]] interface {
+	~[2]R | ~[3]R | ~[4]R
+}
+
+// Mat 为底层数组类型为 M 的矩阵，R 为一行的数组类型，零值为零矩阵；
+// Mul、MulVec、Transpose 只支持方阵，行数与列数不同时 panic
+type Mat[T Number, R Array[T], M Rows[T, R]] struct {
+	m M
+}
+
+type (
+	Mat2 = Mat[float64, [2]float64, [2][2]float64]
+	Mat3 = Mat[float64, [3]float64, [3][3]float64]
+	Mat4 = Mat[float64, [4]float64, [4][4]float64]
+)
+
+// MatOf 返回元素为 m 的矩阵，m 被复制
+func MatOf[T Number, R Array[T], M Rows[T, R]](m M) Mat[T, R, M] {
+	return Mat[T, R, M]{m}
+}
+
+func M2(m [2][2]float64) Mat2 { return Mat2{m} }
+func M3(m [3][3]float64) Mat3 { return Mat3{m} }
+func M4(m [4][4]float64) Mat4 { return Mat4{m} }
+
+// Identity 返回与 m 类型相同的单位矩阵
+func (m Mat[T, R, M]) Identity() Mat[T, R, M] {
+	m.square()
+	var id Mat[T, R, M]
+	for i := 0; i < len(id.m); i++ {
+		id.m[i][i] = 1
+	}
+	return id
+}
+
+// Array 返回底层数组的副本
+func (m Mat[T, R, M]) Array() M { return m.m }
+
+func (m Mat[T, R, M]) Rows() int { return len(m.m) }
+
+func (m Mat[T, R, M]) Cols() int {
+	var r R
+	return len(r)
+}
+
+func (m Mat[T, R, M]) At(i, j int) T { return m.m[i][j] }
+
+// With 返回第 i 行第 j 列的元素替换为 x 的矩阵，m 保持不变
+func (m Mat[T, R, M]) With(i, j int, x T) Mat[T, R, M] {
+	m.m[i][j] = x
+	return m
+}
+
+// Row 返回第 i 行
+func (m Mat[T, R, M]) Row(i int) Vec[T, R] { return Vec[T, R]{m.m[i]} }
+
+func (m Mat[T, R, M]) Add(n Mat[T, R, M]) Mat[T, R, M] {
+	for i := 0; i < len(m.m); i++ {
+		for j := 0; j < len(m.m[i]); j++ {
+			m.m[i][j] += n.m[i][j]
+		}
+	}
+	return m
+}
+
+func (m Mat[T, R, M]) Sub(n Mat[T, R, M]) Mat[T, R, M] {
+	for i := 0; i < len(m.m); i++ {
+		for j := 0; j < len(m.m[i]); j++ {
+			m.m[i][j] -= n.m[i][j]
+		}
+	}
+	return m
+}
+
+func (m Mat[T, R, M]) Scale(k T) Mat[T, R, M] {
+	for i := 0; i < len(m.m); i++ {
+		for j := 0; j < len(m.m[i]); j++ {
+			m.m[i][j] *= k
+		}
+	}
+	return m
+}
+
+// Mul 返回矩阵乘积 m×n
+func (m Mat[T, R, M]) Mul(n Mat[T, R, M]) Mat[T, R, M] {
+	m.square()
+	var p Mat[T, R, M]
+	for i := 0; i < len(m.m); i++ {
+		for j := 0; j < len(m.m); j++ {
+			var sum T
+			for k := 0; k < len(m.m); k++ {
+				sum += m.m[i][k] * n.m[k][j]
+			}
+			p.m[i][j] = sum
+		}
+	}
+	return p
+}
+
+// MulVec 返回 m 与列向量 v 的乘积
+func (m Mat[T, R, M]) MulVec(v Vec[T, R]) Vec[T, R] {
+	m.square()
+	var p Vec[T, R]
+	for i := 0; i < len(m.m); i++ {
+		p.a[i] = Vec[T, R]{m.m[i]}.Dot(v)
+	}
+	return p
+}
+
+func (m Mat[T, R, M]) Transpose() Mat[T, R, M] {
+	m.square()
+	for i := 0; i < len(m.m); i++ {
+		for j := i + 1; j < len(m.m); j++ {
+			m.m[i][j], m.m[j][i] = m.m[j][i], m.m[i][j]
+		}
+	}
+	return m
+}
+
+// Hash 返回矩阵的 hash 值，m == n 时 m.Hash(seed) == n.Hash(seed)
+func (m Mat[T, R, M]) Hash(seed maphash.Seed) uint64 {
+	return maphash.Comparable(seed, m.m)
+}
+
+func (m Mat[T, R, M]) String() string {
+	return fmt.Sprint(m.m)
+}
+
+func (m Mat[T, R, M]) square() {
+	if m.Rows() != m.Cols() {
+		panic(fmt.Sprintf("vec: %d×%d matrix is not square", m.Rows(), m.Cols()))
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Package vec 是基于数组的定长向量、矩阵，参考 06-arrays 中数组的值语义（arrAsValue、arrComparison）
+
+	数组是值类型：
+		* 赋值、传参、作为返回值时复制整个数组，所以 Vec、Mat 的运算都返回新的值，不会修改参数
+		* 元素可比较的数组可以直接使用 == 比较，也可以直接作为 map 的 key
+		* 数组的长度是类型的一部分，[3]float64 与 [4]float64 是不同的类型，不同长度的向量相加在编译时报错
+
+	Go 的泛型不能以数组长度作为类型参数，Vec 以底层数组类型 A 作为类型参数，A 的长度为 2、3 或者 4：
+		```go sketch
+			type Vec3 = Vec[float64, [3]float64]
+			type Mat4 = Mat[float64, [4]float64, [4][4]float64]
+		```
+	类型参数 T 无法从数组类型推断，创建向量时需要显式指定元素类型，比如 `vec.Of[int]([2]int{1, 2})`，
+	或者使用 float64 的 V2、V3、V4、M2、M3、M4
+
+	元素为浮点数时，== 与 map 的 key 都遵循浮点数的比较规则：0 与 -0 相等，包含 NaN 的向量与任何向量都不相等
+*/
+package vec
+
+import (
+	"fmt"
+	"hash/maphash"
+	"math"
+)
+
+// Number 为向量元素的类型
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Array 为向量的底层数组类型
+type Array[T Number] interface {
+	~[2]T | ~[3]T | ~[4]T
+}
+
+// Vec 为底层数组类型为 A 的向量，零值为零向量
+type Vec[T Number, A Array[T]] struct {
+	a A
+}
+
+type (
+	Vec2 = Vec[float64, [2]float64]
+	Vec3 = Vec[float64, [3]float64]
+	Vec4 = Vec[float64, [4]float64]
+)
+
+// Of 返回元素为 a 的向量，a 被复制
+func Of[T Number, A Array[T]](a A) Vec[T, A] {
+	return Vec[T, A]{a}
+}
+
+func V2(x, y float64) Vec2       { return Vec2{[2]float64{x, y}} }
+func V3(x, y, z float64) Vec3    { return Vec3{[3]float64{x, y, z}} }
+func V4(x, y, z, w float64) Vec4 { return Vec4{[4]float64{x, y, z, w}} }
+
+// Array 返回底层数组的副本
+func (v Vec[T, A]) Array() A { return v.a }
+
+func (v Vec[T, A]) Len() int { return len(v.a) }
+
+func (v Vec[T, A]) At(i int) T { return v.a[i] }
+
+// With 返回第 i 个元素替换为 x 的向量，v 保持不变
+func (v Vec[T, A]) With(i int, x T) Vec[T, A] {
+	v.a[i] = x
+	return v
+}
+
+func (v Vec[T, A]) Add(w Vec[T, A]) Vec[T, A] {
+	for i := 0; i < len(v.a); i++ {
+		v.a[i] += w.a[i]
+	}
+	return v
+}
+
+func (v Vec[T, A]) Sub(w Vec[T, A]) Vec[T, A] {
+	for i := 0; i < len(v.a); i++ {
+		v.a[i] -= w.a[i]
+	}
+	return v
+}
+
+func (v Vec[T, A]) Scale(k T) Vec[T, A] {
+	for i := 0; i < len(v.a); i++ {
+		v.a[i] *= k
+	}
+	return v
+}
+
+func (v Vec[T, A]) Dot(w Vec[T, A]) T {
+	var sum T
+	for i := 0; i < len(v.a); i++ {
+		sum += v.a[i] * w.a[i]
+	}
+	return sum
+}
+
+// Norm 返回向量的欧几里得长度
+func (v Vec[T, A]) Norm() float64 {
+	return math.Sqrt(float64(v.Dot(v)))
+}
+
+// Hash 返回向量的 hash 值，v == w 时 v.Hash(seed) == w.Hash(seed)；
+// 向量可以直接作为 map 的 key，Hash 用于自定义的 hash 表或者去重
+func (v Vec[T, A]) Hash(seed maphash.Seed) uint64 {
+	return maphash.Comparable(seed, v.a)
+}
+
+func (v Vec[T, A]) String() string {
+	return fmt.Sprint(v.a)
+}
+
+// Cross 返回三维向量的叉积
+func Cross[T Number](v, w Vec[T, [3]T]) Vec[T, [3]T] {
+	a, b := v.a, w.a
+	return Vec[T, [3]T]{[3]T{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+	}}
+}
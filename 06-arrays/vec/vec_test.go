@@ -0,0 +1,291 @@
+package vec
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"testing"
+)
+
+func TestVec(t *testing.T) {
+	a, b := V3(1, 2, 3), V3(4, 5, 6)
+	if got, want := a.Add(b), V3(5, 7, 9); got != want {
+		t.Errorf("Add = %v, want %v", got, want)
+	}
+	if got, want := b.Sub(a), V3(3, 3, 3); got != want {
+		t.Errorf("Sub = %v, want %v", got, want)
+	}
+	if got, want := a.Scale(2), V3(2, 4, 6); got != want {
+		t.Errorf("Scale = %v, want %v", got, want)
+	}
+	if got := a.Dot(b); got != 32 {
+		t.Errorf("Dot = %v, want 32", got)
+	}
+	if got := V2(3, 4).Norm(); got != 5 {
+		t.Errorf("Norm = %v, want 5", got)
+	}
+	if got, want := Cross(V3(1, 0, 0), V3(0, 1, 0)), V3(0, 0, 1); got != want {
+		t.Errorf("Cross = %v, want %v", got, want)
+	}
+
+	// 运算返回新的值，a、b 保持不变
+	if a != V3(1, 2, 3) || b != V3(4, 5, 6) {
+		t.Errorf("operands modified: %v %v", a, b)
+	}
+
+	ints := Of[int]([4]int{1, 2, 3, 4})
+	if got := ints.Dot(ints); got != 30 {
+		t.Errorf("int Dot = %d, want 30", got)
+	}
+	if got := ints.String(); got != "[1 2 3 4]" {
+		t.Errorf("String = %q", got)
+	}
+}
+
+// TestValueSemantics 与 06-arrays 中的 arrAsValue 相同：赋值复制整个数组
+func TestValueSemantics(t *testing.T) {
+	a := V4(1, 2, 3, 4)
+	b := a
+	b = b.With(0, 10)
+	if a.At(0) != 1 || b.At(0) != 10 {
+		t.Errorf("With changed the original: a = %v, b = %v", a, b)
+	}
+
+	arr := a.Array()
+	arr[1] = 20
+	if a.At(1) != 2 {
+		t.Errorf("modifying Array() changed the vector: %v", a)
+	}
+
+	m := M2([2][2]float64{{1, 2}, {3, 4}})
+	rows := m.Array()
+	rows[0][0] = 100
+	if m.At(0, 0) != 1 {
+		t.Errorf("modifying Array() changed the matrix: %v", m)
+	}
+}
+
+func TestMapKey(t *testing.T) {
+	seen := map[Vec3]int{}
+	for _, v := range []Vec3{V3(1, 2, 3), V3(1, 2, 3), V3(0, 0, 0), V3(math.Copysign(0, -1), 0, 0)} {
+		seen[v]++
+	}
+	// 0 与 -0 相等，是同一个 key
+	if len(seen) != 2 || seen[V3(1, 2, 3)] != 2 || seen[Vec3{}] != 2 {
+		t.Errorf("seen = %v", seen)
+	}
+
+	nan := V3(math.NaN(), 0, 0)
+	if nan == nan {
+		t.Error("vector containing NaN equals itself")
+	}
+
+	seed := maphash.MakeSeed()
+	if V3(0, 1, 2).Hash(seed) != V3(math.Copysign(0, -1), 1, 2).Hash(seed) {
+		t.Error("equal vectors have different hashes")
+	}
+	if V3(1, 2, 3).Hash(seed) == V3(3, 2, 1).Hash(seed) {
+		t.Error("different vectors have the same hash")
+	}
+	m := M3([3][3]float64{{1}, {0, 1}, {0, 0, 1}})
+	if m != m.Identity() || m.Hash(seed) != m.Identity().Hash(seed) {
+		t.Error("identity matrices differ")
+	}
+}
+
+func TestMat(t *testing.T) {
+	m := M2([2][2]float64{{1, 2}, {3, 4}})
+	n := M2([2][2]float64{{5, 6}, {7, 8}})
+	if got, want := m.Mul(n), M2([2][2]float64{{19, 22}, {43, 50}}); got != want {
+		t.Errorf("Mul = %v, want %v", got, want)
+	}
+	if got := m.Mul(m.Identity()); got != m {
+		t.Errorf("m × I = %v, want %v", got, m)
+	}
+	if got, want := m.Transpose(), M2([2][2]float64{{1, 3}, {2, 4}}); got != want {
+		t.Errorf("Transpose = %v, want %v", got, want)
+	}
+	if got, want := m.MulVec(V2(1, 1)), V2(3, 7); got != want {
+		t.Errorf("MulVec = %v, want %v", got, want)
+	}
+	if got, want := m.Add(n).Sub(n), m; got != want {
+		t.Errorf("Add then Sub = %v, want %v", got, want)
+	}
+	if got, want := m.Scale(2).Row(1), V2(6, 8); got != want {
+		t.Errorf("Scale(2).Row(1) = %v, want %v", got, want)
+	}
+	if m != M2([2][2]float64{{1, 2}, {3, 4}}) {
+		t.Errorf("operand modified: %v", m)
+	}
+}
+
+func TestNonSquare(t *testing.T) {
+	m := MatOf[int, [3]int]([2][3]int{{1, 2, 3}, {4, 5, 6}})
+	if m.Rows() != 2 || m.Cols() != 3 {
+		t.Errorf("%d×%d, want 2×3", m.Rows(), m.Cols())
+	}
+	if got := m.Add(m).At(1, 2); got != 12 {
+		t.Errorf("Add: At(1, 2) = %d, want 12", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Mul of a non-square matrix did not panic")
+		}
+	}()
+	m.Mul(m)
+}
+
+// slice 实现的向量与矩阵，作为基准测试的对照
+type sliceVec []float64
+
+func (v sliceVec) add(w sliceVec) sliceVec {
+	s := make(sliceVec, len(v))
+	for i := range v {
+		s[i] = v[i] + w[i]
+	}
+	return s
+}
+
+func (v sliceVec) dot(w sliceVec) float64 {
+	var sum float64
+	for i := range v {
+		sum += v[i] * w[i]
+	}
+	return sum
+}
+
+// key 将 slice 编码为字符串，slice 不能直接作为 map 的 key
+func (v sliceVec) key() string {
+	b := make([]byte, 0, 8*len(v))
+	for _, x := range v {
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(x))
+	}
+	return string(b)
+}
+
+type sliceMat [][]float64
+
+func newSliceMat(n int) sliceMat {
+	m := make(sliceMat, n)
+	for i := range m {
+		m[i] = make([]float64, n)
+	}
+	return m
+}
+
+func (m sliceMat) mul(n sliceMat) sliceMat {
+	p := newSliceMat(len(m))
+	for i := range m {
+		for j := range m {
+			var sum float64
+			for k := range m {
+				sum += m[i][k] * n[k][j]
+			}
+			p[i][j] = sum
+		}
+	}
+	return p
+}
+
+// clone 返回与 m 不共享内存的副本，相当于数组的赋值
+func (m sliceMat) clone() sliceMat {
+	c := make(sliceMat, len(m))
+	for i := range m {
+		c[i] = append([]float64(nil), m[i]...)
+	}
+	return c
+}
+
+// 基准测试比较数组与 slice 实现的向量、矩阵，运行：
+//
+//	go test -bench . ./06-arrays/vec
+//
+// 数组的运算结果在栈上，没有内存分配；slice 的每个结果都需要在堆上分配
+var (
+	benchVec   = V3(1, 2, 3)
+	benchSlice = sliceVec{1, 2, 3}
+	benchMat   = M4([4][4]float64{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10, 11, 12}, {13, 14, 15, 16}})
+	benchRows  = sliceMat{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10, 11, 12}, {13, 14, 15, 16}}
+)
+
+func BenchmarkVecAdd(b *testing.B) {
+	v := benchVec
+	for i := 0; i < b.N; i++ {
+		v = v.Add(benchVec)
+	}
+	_ = v
+}
+
+func BenchmarkSliceVecAdd(b *testing.B) {
+	v := benchSlice
+	for i := 0; i < b.N; i++ {
+		v = v.add(benchSlice)
+	}
+	_ = v
+}
+
+func BenchmarkVecDot(b *testing.B) {
+	var sum float64
+	for i := 0; i < b.N; i++ {
+		sum += benchVec.Dot(benchVec)
+	}
+	_ = sum
+}
+
+func BenchmarkSliceVecDot(b *testing.B) {
+	var sum float64
+	for i := 0; i < b.N; i++ {
+		sum += benchSlice.dot(benchSlice)
+	}
+	_ = sum
+}
+
+func BenchmarkMatMul(b *testing.B) {
+	m := benchMat
+	for i := 0; i < b.N; i++ {
+		m = benchMat.Mul(m).Scale(0.001)
+	}
+	_ = m
+}
+
+func BenchmarkSliceMatMul(b *testing.B) {
+	m := benchRows
+	for i := 0; i < b.N; i++ {
+		m = benchRows.mul(m)
+	}
+	_ = m
+}
+
+// 赋值给包级变量，避免复制被编译器优化掉
+var (
+	sinkMat      Mat4
+	sinkSliceMat sliceMat
+)
+
+func BenchmarkMatCopy(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkMat = benchMat
+	}
+}
+
+func BenchmarkSliceMatCopy(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sinkSliceMat = benchRows.clone()
+	}
+}
+
+func BenchmarkVecMapKey(b *testing.B) {
+	counts := map[Vec3]int{}
+	for i := 0; i < b.N; i++ {
+		counts[benchVec.With(0, float64(i%64))]++
+	}
+}
+
+func BenchmarkSliceVecMapKey(b *testing.B) {
+	counts := map[string]int{}
+	v := append(sliceVec(nil), benchSlice...)
+	for i := 0; i < b.N; i++ {
+		v[0] = float64(i % 64)
+		counts[v.key()]++
+	}
+}
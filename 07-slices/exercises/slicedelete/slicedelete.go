@@ -0,0 +1,20 @@
+/*
+删除 slice 元素，且不修改原 slice 的底层数组：
+
+	` append(s[:i], s[i+1:]...) ` 可以删除 s[i]，但 append 会把后面的元素写回 s 的底层数组，
+	调用者手中的 s 也随之被修改（参考 operationDelete）
+
+	请实现 Delete，返回删除 s[i] 之后的新 slice，要求：
+		* 不修改 s 的底层数组
+		* 对返回的 slice 进行 append，也不会影响 s
+*/
+
+//gotour:hint slice-operation
+//gotour:hint slice-is-a-struct
+//gotour:demo operationDelete
+package slicedelete
+
+// Delete 返回删除 s[i] 之后的新 slice，不修改 s
+func Delete(s []int, i int) []int {
+	return append(s[:i], s[i+1:]...)
+}
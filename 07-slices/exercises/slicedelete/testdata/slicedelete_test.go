@@ -0,0 +1,44 @@
+package slicedelete
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		s    []int
+		i    int
+		want []int
+	}{
+		{[]int{0, 1, 2, 3, 4, 5, 6}, 3, []int{0, 1, 2, 4, 5, 6}},
+		{[]int{0, 1, 2}, 0, []int{1, 2}},
+		{[]int{0, 1, 2}, 2, []int{0, 1}},
+		{[]int{0}, 0, []int{}},
+	}
+	for _, tt := range tests {
+		orig := append([]int(nil), tt.s...)
+		got := Delete(tt.s, tt.i)
+		if len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+			t.Errorf("Delete(%v, %d) = %v, want %v", orig, tt.i, got, tt.want)
+		}
+		if !reflect.DeepEqual(tt.s, orig) {
+			t.Errorf("Delete(%v, %d) modified the source slice: %v", orig, tt.i, tt.s)
+		}
+	}
+}
+
+func TestDeleteThenAppend(t *testing.T) {
+	arr := [...]int{0, 1, 2, 3, 4, 5, 6}
+	s := arr[:4]
+
+	got := Delete(s, 1)
+	got = append(got, -1, -2, -3)
+
+	if want := [...]int{0, 1, 2, 3, 4, 5, 6}; arr != want {
+		t.Errorf("appending to the result of Delete modified the source array: %v", arr)
+	}
+	if want := []int{0, 2, 3, -1, -2, -3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
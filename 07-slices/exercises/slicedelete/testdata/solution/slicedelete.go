@@ -0,0 +1,8 @@
+package slicedelete
+
+// Delete 返回删除 s[i] 之后的新 slice，不修改 s
+func Delete(s []int, i int) []int {
+	result := make([]int, 0, len(s)-1)
+	result = append(result, s[:i]...)
+	return append(result, s[i+1:]...)
+}
@@ -0,0 +1,287 @@
+/*
+Package ring 在 slice 之上实现双端队列 Deque 与环形缓冲区 Ring，参考 07-slices 中的 append
+
+	用 append 加 re-slicing 实现队列：
+		` q = append(q, x) ` 入队，` x, q = q[0], q[1:] ` 出队
+		* 出队只是把 slice header 中的 ptr 后移，底层数组中 q[0] 之前的元素（head capacity）既不能被复用，
+			也不会被回收，直到 append 因为 cap 不足分配新的数组
+		* 在队头插入只能 ` append([]T{x}, q...) `，每次都要复制整个队列
+
+	Deque 与 Ring 使用长度为 2 的幂的 slice 作为环形数组，head 为队头元素的位置，n 为元素数量：
+		* 第 i 个元素位于 buf[(head+i) & (len(buf)-1)]，长度为 2 的幂时可以用按位与代替取模
+		* 两端的 push、pop 只移动 head 或者 n，出队空出的位置会被之后的入队复用
+		* Deque 已满时容量翻倍，元素按顺序复制到新数组的开头，head 归零，push 均摊 O(1)
+		* Deque 的元素减少时按照 Shrink 策略缩小数组，参考 ShrinkQuarter
+		* Ring 的容量固定，已满时 Push 移除最早的元素，适合保存最近的 N 条记录；
+			数组长度为不小于容量的 2 的幂，多出的位置不保存元素，NewRing(100) 最多保存 100 个元素
+
+	pop 会将空出的位置置为 zero value，避免被移出的元素引用的对象无法被回收。
+	All、Backward 返回的 iterator 在迭代期间直接读取环形数组，迭代期间修改队列的结果不确定；
+	需要与队列无关的副本时使用 Snapshot。
+*/
+package ring
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// minCap 为 Deque 分配数组时的最小容量
+const minCap = 8
+
+// buffer 为 Deque 与 Ring 共用的环形数组，len(buf) 为 0 或者 2 的幂
+type buffer[T any] struct {
+	buf  []T
+	head int
+	n    int
+}
+
+// pow2 返回不小于 n 的最小的 2 的幂，n <= 1 时返回 1
+func pow2(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return 1 << bits.Len(uint(n-1))
+}
+
+// index 返回第 i 个元素在 buf 中的位置
+func (b *buffer[T]) index(i int) int {
+	return (b.head + i) & (len(b.buf) - 1)
+}
+
+// Len 返回元素数量
+func (b *buffer[T]) Len() int { return b.n }
+
+// Cap 返回环形数组的长度，即不重新分配时可以容纳的元素数量
+func (b *buffer[T]) Cap() int { return len(b.buf) }
+
+// At 返回从队头开始的第 i 个元素，i 越界时 panic
+func (b *buffer[T]) At(i int) T {
+	if i < 0 || i >= b.n {
+		panic("ring: index out of range")
+	}
+	return b.buf[b.index(i)]
+}
+
+// Clear 移除所有元素，保留环形数组
+func (b *buffer[T]) Clear() {
+	clear(b.buf)
+	b.head, b.n = 0, 0
+}
+
+// All 返回从队头到队尾的 (下标, 元素) iterator
+func (b *buffer[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < b.n; i++ {
+			if !yield(i, b.buf[b.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward 返回从队尾到队头的 (下标, 元素) iterator
+func (b *buffer[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := b.n - 1; i >= 0; i-- {
+			if !yield(i, b.buf[b.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Snapshot 返回按顺序复制所有元素的新 slice，之后对队列的修改不会影响它
+func (b *buffer[T]) Snapshot() []T {
+	s := make([]T, b.n)
+	b.copyTo(s)
+	return s
+}
+
+// copyTo 将元素按顺序复制到 dst 的开头：环形数组中的元素最多分为 buf[head:] 与 buf[:tail] 两段
+func (b *buffer[T]) copyTo(dst []T) {
+	if b.n == 0 {
+		return
+	}
+	end := b.head + b.n
+	if end <= len(b.buf) {
+		copy(dst, b.buf[b.head:end])
+		return
+	}
+	k := copy(dst, b.buf[b.head:])
+	copy(dst[k:], b.buf[:end-len(b.buf)])
+}
+
+// resize 将元素按顺序复制到长度为 c 的新数组中，c 为 0 时释放数组
+func (b *buffer[T]) resize(c int) {
+	var buf []T
+	if c > 0 {
+		buf = make([]T, c)
+		b.copyTo(buf)
+	}
+	b.buf, b.head = buf, 0
+}
+
+// popFront 移除队头元素，调用前需要确认 n > 0
+func (b *buffer[T]) popFront() T {
+	var zero T
+	v := b.buf[b.head]
+	b.buf[b.head] = zero
+	b.head = b.index(1)
+	b.n--
+	return v
+}
+
+// Shrink 为 Deque 在元素减少时缩小数组的策略
+type Shrink int
+
+const (
+	// ShrinkQuarter 在元素数量降到容量的 1/4 时将容量减半，但不低于 minCap；
+	// 与容量翻倍的时机之间留有余地，避免在边界上交替 push、pop 时反复分配
+	ShrinkQuarter Shrink = iota
+	// ShrinkEmpty 只在 Deque 变空时释放数组
+	ShrinkEmpty
+	// ShrinkNever 从不缩小数组，适合元素数量反复涨落的场景
+	ShrinkNever
+)
+
+// Deque 是可增长的双端队列，zero value 为使用 ShrinkQuarter 策略的空队列
+type Deque[T any] struct {
+	buffer[T]
+	shrink Shrink
+}
+
+// NewDeque 返回预先分配了至少 capacity 个位置的空队列
+func NewDeque[T any](capacity int) *Deque[T] {
+	d := &Deque[T]{}
+	d.Grow(capacity)
+	return d
+}
+
+// SetShrink 设置缩小数组的策略
+func (d *Deque[T]) SetShrink(s Shrink) {
+	d.shrink = s
+}
+
+// Grow 确保之后的 n 次 push 不需要重新分配数组
+func (d *Deque[T]) Grow(n int) {
+	if n <= 0 || d.n+n <= len(d.buf) {
+		return
+	}
+	d.resize(max(pow2(d.n+n), minCap))
+}
+
+// PushBack 在队尾添加 v
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[d.index(d.n)] = v
+	d.n++
+}
+
+// PushFront 在队头添加 v
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = d.index(-1)
+	d.buf[d.head] = v
+	d.n++
+}
+
+// PopFront 移除并返回队头元素，队列为空时返回 false
+func (d *Deque[T]) PopFront() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	v := d.popFront()
+	d.shrinkIfNeeded()
+	return v, true
+}
+
+// PopBack 移除并返回队尾元素，队列为空时返回 false
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.n == 0 {
+		return zero, false
+	}
+	i := d.index(d.n - 1)
+	v := d.buf[i]
+	d.buf[i] = zero
+	d.n--
+	d.shrinkIfNeeded()
+	return v, true
+}
+
+// Front 返回队头元素，队列为空时返回 false
+func (d *Deque[T]) Front() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.head], true
+}
+
+// Back 返回队尾元素，队列为空时返回 false
+func (d *Deque[T]) Back() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.index(d.n-1)], true
+}
+
+// grow 在数组已满时将容量翻倍
+func (d *Deque[T]) grow() {
+	if d.n == len(d.buf) {
+		d.resize(max(2*len(d.buf), minCap))
+	}
+}
+
+func (d *Deque[T]) shrinkIfNeeded() {
+	switch d.shrink {
+	case ShrinkQuarter:
+		if len(d.buf) > minCap && d.n <= len(d.buf)/4 {
+			d.resize(len(d.buf) / 2)
+		}
+	case ShrinkEmpty:
+		if d.n == 0 {
+			d.resize(0)
+		}
+	}
+}
+
+// Ring 是容量固定的环形缓冲区，已满时 Push 移除最早的元素；需要通过 NewRing 创建
+type Ring[T any] struct {
+	buffer[T]
+	limit int // 最多保存的元素数量，不超过 len(buf)
+}
+
+// NewRing 返回最多保存 capacity 个元素的环形缓冲区，数组长度为不小于 capacity 的最小的 2 的幂；
+// capacity < 1 时 panic
+func NewRing[T any](capacity int) *Ring[T] {
+	if capacity < 1 {
+		panic("ring: capacity must be positive")
+	}
+	return &Ring[T]{buffer: buffer[T]{buf: make([]T, pow2(capacity))}, limit: capacity}
+}
+
+// Cap 返回 NewRing 时指定的容量，即最多保存的元素数量
+func (r *Ring[T]) Cap() int { return r.limit }
+
+// Push 在队尾添加 v；已满时移除并返回最早的元素
+func (r *Ring[T]) Push(v T) (evicted T, ok bool) {
+	if r.n == r.limit {
+		evicted, ok = r.popFront(), true
+	}
+	r.buf[r.index(r.n)] = v
+	r.n++
+	return evicted, ok
+}
+
+// Pop 移除并返回最早的元素，为空时返回 false
+func (r *Ring[T]) Pop() (T, bool) {
+	if r.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return r.popFront(), true
+}
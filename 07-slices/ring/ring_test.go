@@ -0,0 +1,395 @@
+package ring
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestPow2(t *testing.T) {
+	for _, tt := range []struct{ n, want int }{
+		{-1, 1}, {0, 1}, {1, 1}, {2, 2}, {3, 4}, {8, 8}, {9, 16}, {1000, 1024},
+	} {
+		if got := pow2(tt.n); got != tt.want {
+			t.Errorf("pow2(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+}
+
+// TestDequeModel 随机执行 push、pop，并与使用 slice 实现的参照队列比较
+func TestDequeModel(t *testing.T) {
+	for _, shrink := range []Shrink{ShrinkQuarter, ShrinkEmpty, ShrinkNever} {
+		r := rand.New(rand.NewPCG(1, uint64(shrink)))
+		var d Deque[int]
+		d.SetShrink(shrink)
+		var model []int
+		for i := 0; i < 10000; i++ {
+			// 前一半偏向 push，后一半偏向 pop，让容量先增长再缩小
+			push := r.IntN(10) < 6
+			if i >= 5000 {
+				push = r.IntN(10) < 4
+			}
+			switch front := r.IntN(2) == 0; {
+			case push && front:
+				d.PushFront(i)
+				model = slices.Insert(model, 0, i)
+			case push:
+				d.PushBack(i)
+				model = append(model, i)
+			case front:
+				v, ok := d.PopFront()
+				if ok != (len(model) > 0) || ok && v != model[0] {
+					t.Fatalf("shrink %d, step %d: PopFront() = %d, %v, want front of %v", shrink, i, v, ok, model)
+				}
+				if ok {
+					model = model[1:]
+				}
+			default:
+				v, ok := d.PopBack()
+				if ok != (len(model) > 0) || ok && v != model[len(model)-1] {
+					t.Fatalf("shrink %d, step %d: PopBack() = %d, %v, want back of %v", shrink, i, v, ok, model)
+				}
+				if ok {
+					model = model[:len(model)-1]
+				}
+			}
+
+			if d.Len() != len(model) {
+				t.Fatalf("shrink %d, step %d: Len() = %d, want %d", shrink, i, d.Len(), len(model))
+			}
+			if c := d.Cap(); c&(c-1) != 0 || c < d.Len() {
+				t.Fatalf("shrink %d, step %d: Cap() = %d is not a power of two >= %d", shrink, i, c, d.Len())
+			}
+		}
+		if got := d.Snapshot(); !slices.Equal(got, model) {
+			t.Errorf("shrink %d: Snapshot() = %v, want %v", shrink, got, model)
+		}
+	}
+}
+
+func TestDequeShrink(t *testing.T) {
+	for _, tt := range []struct {
+		shrink Shrink
+		left   int // pop 之后剩余的元素数量
+		want   int // 剩余 left 个元素时的容量
+	}{
+		{ShrinkQuarter, 257, 1024},
+		{ShrinkQuarter, 256, 512},
+		{ShrinkQuarter, 128, 256},
+		{ShrinkQuarter, 1, 8},
+		{ShrinkQuarter, 0, 8},
+		{ShrinkEmpty, 1, 1024},
+		{ShrinkEmpty, 0, 0},
+		{ShrinkNever, 0, 1024},
+	} {
+		var d Deque[int]
+		d.SetShrink(tt.shrink)
+		for i := 0; i < 1024; i++ {
+			d.PushBack(i)
+		}
+		for d.Len() > tt.left {
+			d.PopFront()
+		}
+		if got := d.Cap(); got != tt.want {
+			t.Errorf("shrink %d: Cap() with %d elements = %d, want %d", tt.shrink, tt.left, got, tt.want)
+		}
+		if tt.left > 0 {
+			if v, _ := d.Front(); v != 1024-tt.left {
+				t.Errorf("shrink %d: Front() = %d, want %d", tt.shrink, v, 1024-tt.left)
+			}
+		}
+	}
+}
+
+func TestDequeGrow(t *testing.T) {
+	d := NewDeque[int](100)
+	if d.Cap() != 128 {
+		t.Fatalf("NewDeque(100).Cap() = %d, want 128", d.Cap())
+	}
+	for i := 0; i < 128; i++ {
+		d.PushFront(i)
+	}
+	if d.Cap() != 128 {
+		t.Errorf("Cap() after 128 pushes = %d, want 128", d.Cap())
+	}
+	d.Grow(1)
+	if d.Cap() != 256 {
+		t.Errorf("Cap() after Grow(1) = %d, want 256", d.Cap())
+	}
+	if v, _ := d.Back(); v != 0 {
+		t.Errorf("Back() = %d, want 0", v)
+	}
+	if v := d.At(1); v != 126 {
+		t.Errorf("At(1) = %d, want 126", v)
+	}
+}
+
+func TestDequeEmpty(t *testing.T) {
+	var d Deque[string]
+	for name, f := range map[string]func() (string, bool){
+		"PopFront": d.PopFront, "PopBack": d.PopBack, "Front": d.Front, "Back": d.Back,
+	} {
+		if v, ok := f(); ok || v != "" {
+			t.Errorf("%s() on empty deque = %q, %v", name, v, ok)
+		}
+	}
+	if d.Snapshot() == nil || len(d.Snapshot()) != 0 {
+		t.Errorf("Snapshot() on empty deque = %#v, want empty slice", d.Snapshot())
+	}
+}
+
+// TestPopClears 检查 pop 之后环形数组中不再引用被移出的元素
+func TestPopClears(t *testing.T) {
+	d := NewDeque[*int](8)
+	d.SetShrink(ShrinkNever)
+	for i := 0; i < 4; i++ {
+		d.PushBack(new(int))
+	}
+	d.PopFront()
+	d.PopBack()
+	d.Clear()
+	for i, p := range d.buf {
+		if p != nil {
+			t.Errorf("buf[%d] still references a popped element", i)
+		}
+	}
+}
+
+func TestIterators(t *testing.T) {
+	var d Deque[int]
+	for i := 1; i <= 5; i++ {
+		d.PushFront(-i)
+		d.PushBack(i)
+	}
+	want := []int{-5, -4, -3, -2, -1, 1, 2, 3, 4, 5}
+
+	var got []int
+	for i, v := range d.All() {
+		if v != d.At(i) {
+			t.Errorf("All() yields %d at %d, At(%d) = %d", v, i, i, d.At(i))
+		}
+		got = append(got, v)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+
+	got = got[:0]
+	for i, v := range d.Backward() {
+		if i < 7 {
+			break
+		}
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{5, 4, 3}) {
+		t.Errorf("Backward() with break = %v, want [5 4 3]", got)
+	}
+
+	snap := d.Snapshot()
+	d.PopFront()
+	d.PushBack(6)
+	if !slices.Equal(snap, want) {
+		t.Errorf("Snapshot() changed with the deque: %v", snap)
+	}
+}
+
+func TestRing(t *testing.T) {
+	r := NewRing[int](3)
+	if r.Cap() != 3 || len(r.buf) != 4 {
+		t.Fatalf("NewRing(3): Cap() = %d, len(buf) = %d, want 3, 4", r.Cap(), len(r.buf))
+	}
+	var evicted []int
+	for i := 0; i < 10; i++ {
+		if v, ok := r.Push(i); ok {
+			evicted = append(evicted, v)
+		}
+	}
+	if !slices.Equal(evicted, []int{0, 1, 2, 3, 4, 5, 6}) {
+		t.Errorf("evicted = %v, want [0 1 2 3 4 5 6]", evicted)
+	}
+	if got := r.Snapshot(); !slices.Equal(got, []int{7, 8, 9}) {
+		t.Errorf("Snapshot() = %v, want [7 8 9]", got)
+	}
+	if v, ok := r.Pop(); !ok || v != 7 {
+		t.Errorf("Pop() = %d, %v, want 7, true", v, ok)
+	}
+	if _, ok := r.Push(10); ok {
+		t.Errorf("Push(10) evicted an element with %d of %d elements", r.Len()-1, r.Cap())
+	}
+	if v, ok := r.Push(11); !ok || v != 8 {
+		t.Errorf("Push(11) evicted %d, %v, want 8, true", v, ok)
+	}
+	var got []int
+	for _, v := range r.Backward() {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{11, 10, 9}) {
+		t.Errorf("Backward() = %v, want [11 10 9]", got)
+	}
+}
+
+// TestRingLimit 检查 Ring 按照指定的容量淘汰元素，而不是数组长度
+func TestRingLimit(t *testing.T) {
+	for _, capacity := range []int{1, 4, 100, 128} {
+		r := NewRing[int](capacity)
+		for i := 0; i < 1000; i++ {
+			r.Push(i)
+			if r.Len() > capacity {
+				t.Fatalf("NewRing(%d): Len() = %d after %d pushes", capacity, r.Len(), i+1)
+			}
+		}
+		if r.Cap() != capacity || r.Len() != capacity {
+			t.Errorf("NewRing(%d): Cap() = %d, Len() = %d", capacity, r.Cap(), r.Len())
+		}
+		if v, _ := r.Pop(); v != 1000-capacity {
+			t.Errorf("NewRing(%d): oldest element = %d, want %d", capacity, v, 1000-capacity)
+		}
+		// 被移出的位置置为 zero value
+		for r.Len() > 0 {
+			r.Pop()
+		}
+		for i, v := range r.buf {
+			if v != 0 {
+				t.Fatalf("NewRing(%d): buf[%d] = %d after popping everything", capacity, i, v)
+			}
+		}
+	}
+}
+
+func TestPanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"At":      func() { NewDeque[int](4).At(0) },
+		"NewRing": func() { NewRing[int](0) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			f()
+		})
+	}
+}
+
+// appendQueue 是用 append 与 re-slicing 实现的队列：pop 只把 slice 的起点后移，
+// 起点之前的 head capacity 既不能复用也不能回收，直到 append 分配新的数组
+type appendQueue[T any] struct {
+	q     []T
+	array int // 当前底层数组的长度
+}
+
+func (a *appendQueue[T]) push(v T) {
+	grows := len(a.q) == cap(a.q)
+	a.q = append(a.q, v)
+	if grows {
+		a.array = cap(a.q)
+	}
+}
+
+func (a *appendQueue[T]) pop() T {
+	v := a.q[0]
+	a.q = a.q[1:]
+	return v
+}
+
+func (a *appendQueue[T]) pushFront(v T) {
+	a.q = append([]T{v}, a.q...)
+}
+
+func TestAppendQueueLeak(t *testing.T) {
+	var a appendQueue[int]
+	for i := 0; i < 1000; i++ {
+		a.push(i)
+	}
+	for i := 0; i < 990; i++ {
+		a.pop()
+	}
+	// 只剩 10 个元素，但整个数组仍然被引用
+	if leaked := a.array - cap(a.q); leaked != 990 {
+		t.Errorf("leaked head capacity = %d, want 990", leaked)
+	}
+}
+
+// 基准测试比较 Deque 与 append 加 re-slicing 实现的队列，运行：
+//
+//	go test -bench . ./07-slices/ring
+//
+// 队列保持 benchQueueLen 个元素，每次迭代入队、出队各一次；
+// slots 为结束时底层数组的长度，appendQueue 的数组中包含已经出队、无法复用的 head capacity
+const benchQueueLen = 1024
+
+func BenchmarkQueueDeque(b *testing.B) {
+	d := NewDeque[int](benchQueueLen)
+	for i := 0; i < benchQueueLen; i++ {
+		d.PushBack(i)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.PushBack(i)
+		d.PopFront()
+	}
+	b.ReportMetric(float64(d.Cap()), "slots")
+}
+
+func BenchmarkQueueAppend(b *testing.B) {
+	var a appendQueue[int]
+	for i := 0; i < benchQueueLen; i++ {
+		a.push(i)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.push(i)
+		a.pop()
+	}
+	b.ReportMetric(float64(a.array), "slots")
+}
+
+// 每次迭代在队头插入 benchQueueLen 个元素
+func BenchmarkPushFrontDeque(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var d Deque[int]
+		for j := 0; j < benchQueueLen; j++ {
+			d.PushFront(j)
+		}
+	}
+}
+
+func BenchmarkPushFrontAppend(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var a appendQueue[int]
+		for j := 0; j < benchQueueLen; j++ {
+			a.pushFront(j)
+		}
+	}
+}
+
+// 两端交替 push、pop，元素数量在扩容边界附近涨落，比较不同 Shrink 策略
+func BenchmarkShrink(b *testing.B) {
+	for _, bb := range []struct {
+		name   string
+		shrink Shrink
+	}{
+		{"quarter", ShrinkQuarter},
+		{"empty", ShrinkEmpty},
+		{"never", ShrinkNever},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			var d Deque[int]
+			d.SetShrink(bb.shrink)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < 100; j++ {
+					d.PushBack(j)
+				}
+				for j := 0; j < 100; j++ {
+					d.PopFront()
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+/*
+Package sliceops 为常用的 slice 操作提供两种版本，参考 07-slices 中的 Slice Operation
+
+	章节中的删除、插入、复制、unpack 都是手写的，像 operationDelete 中的 ` append(arr[:3], arr[4:]...) ` 一样，
+	很容易在得到结果的同时改写了原来的底层数组。这里每个操作都有两个版本：
+		* copy-on-write 版本（Insert、Delete ...）：结果总是使用新分配的数组，
+			s 的底层数组（包括 len(s) 到 cap(s) 之间的元素）不会被修改，结果与 s 之间也没有共享
+		* in-place 版本（InsertInPlace、DeleteInPlace ...）：直接在 s 的底层数组中完成操作，不分配或者尽量少分配，
+			结果与 s 共享底层数组，s 中的元素会被改写，调用之后应该只使用返回的结果
+
+	in-place 版本在 s 中留下的无用元素：
+		* Delete、DeleteFunc、Filter、Dedup 将结果之后、len(s) 之前的元素置为 zero value，避免它们引用的对象无法被回收
+		* Insert 在 cap(s) 足够时会改写 len(s) 之后的元素，与 append 相同，参考 slicelint 中的 appendalias
+
+	Chunk、Window 返回多个 slice：
+		* in-place 版本返回 s 的 sub-slice，使用 full slice expression 限制容量，
+			对其中一个 slice 进行 append 不会改写相邻的 slice
+		* copy-on-write 版本将所有结果复制到一个新的数组中，同样限制每个 slice 的容量
+
+	与 append、slice expression 一样，下标越界时会 panic。
+*/
+package sliceops
+
+// Insert 返回在 s[i] 之前插入 v 之后的新 slice，0 <= i <= len(s)
+func Insert[S ~[]E, E any](s S, i int, v ...E) S {
+	_ = s[i:] // 检查下标
+	r := make(S, 0, len(s)+len(v))
+	r = append(r, s[:i]...)
+	r = append(r, v...)
+	return append(r, s[i:]...)
+}
+
+// InsertInPlace 在 s[i] 之前插入 v：
+// cap(s) 足够时，s[i:] 在原数组中后移，结果与 s 共享底层数组；否则与 append 一样分配新的数组，s 不变。
+// v 不能与 s 的底层数组重叠
+func InsertInPlace[S ~[]E, E any](s S, i int, v ...E) S {
+	_ = s[i:]
+	n := len(s) + len(v)
+	if n > cap(s) {
+		return Insert(s, i, v...)
+	}
+	r := s[:n]
+	copy(r[i+len(v):], s[i:])
+	copy(r[i:], v)
+	return r
+}
+
+// Delete 返回删除 s[i:j] 之后的新 slice，0 <= i <= j <= len(s)
+func Delete[S ~[]E, E any](s S, i, j int) S {
+	_ = s[i:j:len(s)]
+	r := make(S, 0, len(s)-(j-i))
+	r = append(r, s[:i]...)
+	return append(r, s[j:]...)
+}
+
+// DeleteInPlace 将 s[j:] 前移到 s[i:]，删除 s[i:j]，并将 s 中结果之后的元素置为 zero value
+func DeleteInPlace[S ~[]E, E any](s S, i, j int) S {
+	_ = s[i:j:len(s)]
+	n := i + copy(s[i:], s[j:])
+	clear(s[n:])
+	return s[:n]
+}
+
+// DeleteFunc 返回删除 del 返回 true 的元素之后的新 slice
+func DeleteFunc[S ~[]E, E any](s S, del func(E) bool) S {
+	return Filter(s, func(e E) bool { return !del(e) })
+}
+
+// DeleteFuncInPlace 删除 del 返回 true 的元素，剩余元素在 s 中前移，并将 s 中结果之后的元素置为 zero value
+func DeleteFuncInPlace[S ~[]E, E any](s S, del func(E) bool) S {
+	return FilterInPlace(s, func(e E) bool { return !del(e) })
+}
+
+// Filter 返回 keep 返回 true 的元素组成的新 slice
+func Filter[S ~[]E, E any](s S, keep func(E) bool) S {
+	r := S{}
+	for _, e := range s {
+		if keep(e) {
+			r = append(r, e)
+		}
+	}
+	return r
+}
+
+// FilterInPlace 只保留 keep 返回 true 的元素，保留的元素在 s 中前移，并将 s 中结果之后的元素置为 zero value
+func FilterInPlace[S ~[]E, E any](s S, keep func(E) bool) S {
+	n := 0
+	for _, e := range s {
+		if keep(e) {
+			s[n] = e
+			n++
+		}
+	}
+	clear(s[n:])
+	return s[:n]
+}
+
+// Map 返回对每个元素调用 f 的结果组成的新 slice，结果的元素类型可以与 s 不同
+func Map[S ~[]E, E, R any](s S, f func(E) R) []R {
+	r := make([]R, len(s))
+	for i, e := range s {
+		r[i] = f(e)
+	}
+	return r
+}
+
+// MapInPlace 将 s 的每个元素替换为 f 的结果，元素类型不变
+func MapInPlace[S ~[]E, E any](s S, f func(E) E) S {
+	for i, e := range s {
+		s[i] = f(e)
+	}
+	return s
+}
+
+// Chunk 将 s 分为长度为 n 的多个 slice，最后一个可能不足 n 个元素；n < 1 时 panic
+func Chunk[S ~[]E, E any](s S, n int) []S {
+	return ChunkInPlace(Clone(s), n)
+}
+
+// ChunkInPlace 返回 s 中每 n 个元素组成的 sub-slice，每个 sub-slice 的 cap 等于 len
+func ChunkInPlace[S ~[]E, E any](s S, n int) []S {
+	if n < 1 {
+		panic("sliceops: chunk size must be positive")
+	}
+	var r []S
+	for i := 0; i < len(s); i += n {
+		j := min(i+n, len(s))
+		r = append(r, s[i:j:j])
+	}
+	return r
+}
+
+// Window 返回 s 中所有长度为 n 的连续片段，共 len(s)-n+1 个，每个片段使用新数组中各自的元素；
+// len(s) < n 时返回 nil，n < 1 时 panic
+func Window[S ~[]E, E any](s S, n int) []S {
+	w := WindowInPlace(s, n)
+	all := make(S, 0, len(w)*n)
+	for i, sub := range w {
+		all = append(all, sub...)
+		w[i] = all[i*n : (i+1)*n : (i+1)*n]
+	}
+	return w
+}
+
+// WindowInPlace 返回 s 中所有长度为 n 的连续片段，相邻的片段共享 n-1 个元素，修改一个片段会反映到其他片段
+func WindowInPlace[S ~[]E, E any](s S, n int) []S {
+	if n < 1 {
+		panic("sliceops: window size must be positive")
+	}
+	var r []S
+	for i := 0; i+n <= len(s); i++ {
+		r = append(r, s[i:i+n:i+n])
+	}
+	return r
+}
+
+// Dedup 返回合并相邻重复元素之后的新 slice，[1 1 2 1] 变为 [1 2 1]
+func Dedup[S ~[]E, E comparable](s S) S {
+	r := S{}
+	for i, e := range s {
+		if i == 0 || e != s[i-1] {
+			r = append(r, e)
+		}
+	}
+	return r
+}
+
+// DedupInPlace 合并相邻的重复元素，保留的元素在 s 中前移，并将 s 中结果之后的元素置为 zero value
+func DedupInPlace[S ~[]E, E comparable](s S) S {
+	n := 0
+	for i, e := range s {
+		if i == 0 || e != s[n-1] {
+			s[n] = e
+			n++
+		}
+	}
+	clear(s[n:])
+	return s[:n]
+}
+
+// Rotate 返回 s 向左旋转 k 个位置之后的新 slice，k 为负数时向右旋转，k 可以超过 len(s)
+func Rotate[S ~[]E, E any](s S, k int) S {
+	k = shift(len(s), k)
+	r := make(S, 0, len(s))
+	r = append(r, s[k:]...)
+	return append(r, s[:k]...)
+}
+
+// RotateInPlace 在 s 中将元素向左旋转 k 个位置，通过三次反转完成，不分配内存
+func RotateInPlace[S ~[]E, E any](s S, k int) S {
+	k = shift(len(s), k)
+	reverse(s[:k])
+	reverse(s[k:])
+	reverse(s)
+	return s
+}
+
+// Clone 返回 s 的副本，cap 等于 len；nil 的副本仍为 nil
+func Clone[S ~[]E, E any](s S) S {
+	if s == nil {
+		return nil
+	}
+	return append(make(S, 0, len(s)), s...)
+}
+
+// shift 将旋转位置 k 规范到 [0, n)
+func shift(n, k int) int {
+	if n == 0 {
+		return 0
+	}
+	k %= n
+	if k < 0 {
+		k += n
+	}
+	return k
+}
+
+func reverse[E any](s []E) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
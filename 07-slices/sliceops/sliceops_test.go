@@ -0,0 +1,250 @@
+package sliceops
+
+import (
+	"slices"
+	"testing"
+	"testing/quick"
+	"unsafe"
+)
+
+// op 将每个操作的两个版本以及参照实现统一为 func(s, a, b) [][]int，a、b 由 testing/quick 随机生成，
+// 在各个操作中换算为合法的下标、长度等参数；Chunk、Window 返回多个 slice，其余操作只返回一个
+type op struct {
+	name    string
+	copy    func(s []int, a, b int) [][]int
+	inPlace func(s []int, a, b int) [][]int
+	model   func(s []int, a, b int) [][]int // 参照实现，在 s 的副本上运行
+	zeroed  bool                            // in-place 版本将 s 中结果之后的元素置为 zero value
+}
+
+func one(s []int) [][]int { return [][]int{s} }
+
+func index(s []int, a int) int { return a % (len(s) + 1) }
+
+func insertValues(b int) []int { return []int{-1, -2, -3}[:b%4] }
+
+func divisible(b int) func(int) bool {
+	d := b%3 + 2
+	return func(x int) bool { return x%d == 0 }
+}
+
+var ops = []op{
+	{
+		name:    "Insert",
+		copy:    func(s []int, a, b int) [][]int { return one(Insert(s, index(s, a), insertValues(b)...)) },
+		inPlace: func(s []int, a, b int) [][]int { return one(InsertInPlace(s, index(s, a), insertValues(b)...)) },
+		model:   func(s []int, a, b int) [][]int { return one(slices.Insert(s, index(s, a), insertValues(b)...)) },
+	},
+	{
+		name: "Delete",
+		copy: func(s []int, a, b int) [][]int {
+			i := index(s, a)
+			return one(Delete(s, i, i+b%(len(s)-i+1)))
+		},
+		inPlace: func(s []int, a, b int) [][]int {
+			i := index(s, a)
+			return one(DeleteInPlace(s, i, i+b%(len(s)-i+1)))
+		},
+		model: func(s []int, a, b int) [][]int {
+			i := index(s, a)
+			return one(slices.Delete(s, i, i+b%(len(s)-i+1)))
+		},
+		zeroed: true,
+	},
+	{
+		name:    "DeleteFunc",
+		copy:    func(s []int, a, b int) [][]int { return one(DeleteFunc(s, divisible(b))) },
+		inPlace: func(s []int, a, b int) [][]int { return one(DeleteFuncInPlace(s, divisible(b))) },
+		model:   func(s []int, a, b int) [][]int { return one(slices.DeleteFunc(s, divisible(b))) },
+		zeroed:  true,
+	},
+	{
+		name:    "Filter",
+		copy:    func(s []int, a, b int) [][]int { return one(Filter(s, divisible(b))) },
+		inPlace: func(s []int, a, b int) [][]int { return one(FilterInPlace(s, divisible(b))) },
+		model: func(s []int, a, b int) [][]int {
+			keep := divisible(b)
+			return one(slices.DeleteFunc(s, func(x int) bool { return !keep(x) }))
+		},
+		zeroed: true,
+	},
+	{
+		name:    "Map",
+		copy:    func(s []int, a, b int) [][]int { return one(Map(s, func(x int) int { return x*2 + a })) },
+		inPlace: func(s []int, a, b int) [][]int { return one(MapInPlace(s, func(x int) int { return x*2 + a })) },
+		model: func(s []int, a, b int) [][]int {
+			var r []int
+			for _, x := range s {
+				r = append(r, x*2+a)
+			}
+			return one(r)
+		},
+	},
+	{
+		name:    "Chunk",
+		copy:    func(s []int, a, b int) [][]int { return Chunk(s, a%5+1) },
+		inPlace: func(s []int, a, b int) [][]int { return ChunkInPlace(s, a%5+1) },
+		model:   func(s []int, a, b int) [][]int { return slices.Collect(slices.Chunk(s, a%5+1)) },
+	},
+	{
+		name:    "Window",
+		copy:    func(s []int, a, b int) [][]int { return Window(s, a%5+1) },
+		inPlace: func(s []int, a, b int) [][]int { return WindowInPlace(s, a%5+1) },
+		model: func(s []int, a, b int) [][]int {
+			var r [][]int
+			for i := 0; i+a%5+1 <= len(s); i++ {
+				r = append(r, s[i:i+a%5+1])
+			}
+			return r
+		},
+	},
+	{
+		name:    "Dedup",
+		copy:    func(s []int, a, b int) [][]int { return one(Dedup(s)) },
+		inPlace: func(s []int, a, b int) [][]int { return one(DedupInPlace(s)) },
+		model:   func(s []int, a, b int) [][]int { return one(slices.Compact(s)) },
+		zeroed:  true,
+	},
+	{
+		name:    "Rotate",
+		copy:    func(s []int, a, b int) [][]int { return one(Rotate(s, a-b)) },
+		inPlace: func(s []int, a, b int) [][]int { return one(RotateInPlace(s, a-b)) },
+		model: func(s []int, a, b int) [][]int {
+			k := shift(len(s), a-b)
+			return one(append(slices.Clone(s[k:]), s[:k]...))
+		},
+	},
+}
+
+// check 使用 testing/quick 检查性质 f：元素取值范围较小，以便出现相邻的重复元素，
+// s 在 len 之后带有额外的容量，用来检查 copy-on-write 版本没有写入 len(s) 到 cap(s) 之间的元素
+func check(t *testing.T, f func(s []int, a, b int) bool) {
+	t.Helper()
+	prop := func(xs []uint8, extra, a, b uint8) bool {
+		s := make([]int, len(xs), len(xs)+int(extra%4))
+		for i, x := range xs {
+			s[i] = int(x % 4)
+		}
+		return f(s, int(a), int(b))
+	}
+	if err := quick.Check(prop, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// equal 比较两组结果，nil 与空 slice 视为相同
+func equal(x, y [][]int) bool {
+	return slices.EqualFunc(x, y, func(x, y []int) bool { return slices.Equal(x, y) })
+}
+
+// overlaps 表示 x 与 y 的底层数组（到 cap 为止）有重叠
+func overlaps(x, y []int) bool {
+	if cap(x) == 0 || cap(y) == 0 {
+		return false
+	}
+	xp, yp := uintptr(unsafe.Pointer(unsafe.SliceData(x))), uintptr(unsafe.Pointer(unsafe.SliceData(y)))
+	size := unsafe.Sizeof(x[0])
+	return xp < yp+uintptr(cap(y))*size && yp < xp+uintptr(cap(x))*size
+}
+
+// TestCopyKeepsInput 检查 copy-on-write 版本不修改 s 的底层数组，结果与 s 没有共享，并且与参照实现相同
+func TestCopyKeepsInput(t *testing.T) {
+	for _, o := range ops {
+		t.Run(o.name, func(t *testing.T) {
+			check(t, func(s []int, a, b int) bool {
+				before := slices.Clone(s[:cap(s)])
+				want := o.model(slices.Clone(s), a, b)
+
+				got := o.copy(s, a, b)
+				if !slices.Equal(s[:cap(s)], before) {
+					t.Logf("%v(%d, %d): array changed to %v", before[:len(s)], a, b, s[:cap(s)])
+					return false
+				}
+				for _, r := range got {
+					if overlaps(s, r) {
+						t.Logf("%v(%d, %d): result %v shares the array of s", before[:len(s)], a, b, r)
+						return false
+					}
+				}
+				return equal(got, want)
+			})
+		})
+	}
+}
+
+// TestInPlace 检查 in-place 版本与参照实现相同，结果位于 s 的底层数组中，并且按照约定修改 s
+func TestInPlace(t *testing.T) {
+	for _, o := range ops {
+		t.Run(o.name, func(t *testing.T) {
+			check(t, func(s []int, a, b int) bool {
+				orig := slices.Clone(s)
+				want := o.model(slices.Clone(s), a, b)
+
+				got := o.inPlace(s, a, b)
+				if !equal(got, want) {
+					t.Logf("%v(%d, %d) = %v, want %v", orig, a, b, got, want)
+					return false
+				}
+				for _, r := range got {
+					// 只有 Insert 会在容量不足时分配新的数组
+					if len(r) > 0 && !overlaps(s[:cap(s)], r) && len(r) <= cap(s) {
+						t.Logf("%v(%d, %d): result %v does not share the array of s", orig, a, b, r)
+						return false
+					}
+				}
+				if o.zeroed {
+					n := len(got[0])
+					if !slices.Equal(s[:n], got[0]) || slices.ContainsFunc(s[n:], func(x int) bool { return x != 0 }) {
+						t.Logf("%v(%d, %d): s = %v, want %v followed by zero values", orig, a, b, s, got[0])
+						return false
+					}
+				}
+				return true
+			})
+		})
+	}
+}
+
+// TestSubsliceCap 检查 Chunk、Window 返回的 slice 的 cap 等于 len，append 不会改写相邻的 slice
+func TestSubsliceCap(t *testing.T) {
+	s := []int{0, 1, 2, 3, 4, 5, 6}
+	for name, got := range map[string][][]int{
+		"Chunk":         Chunk(s, 3),
+		"ChunkInPlace":  ChunkInPlace(s, 3),
+		"Window":        Window(s, 3),
+		"WindowInPlace": WindowInPlace(s, 3),
+	} {
+		for i, r := range got {
+			if cap(r) != len(r) {
+				t.Errorf("%s: cap(result[%d]) = %d, want %d", name, i, cap(r), len(r))
+			}
+		}
+		_ = append(got[0], -1)
+		if got[1][0] == -1 {
+			t.Errorf("%s: append to result[0] overwrote result[1]", name)
+		}
+	}
+}
+
+func TestPanics(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		f    func()
+	}{
+		{"Insert", func() { Insert([]int{1}, 2, 0) }},
+		{"InsertInPlace", func() { InsertInPlace(make([]int, 1, 4), 2, 0) }},
+		{"Delete", func() { Delete([]int{1, 2}, 2, 1) }},
+		{"DeleteInPlace", func() { DeleteInPlace([]int{1, 2}, 0, 3) }},
+		{"Chunk", func() { Chunk([]int{1}, 0) }},
+		{"Window", func() { WindowInPlace([]int{1}, 0) }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", tt.name)
+				}
+			}()
+			tt.f()
+		})
+	}
+}
@@ -43,6 +43,9 @@ Slices:
 
 		* Slice 元素值的调整会反映到内部的数组中
 
+		* sliceview 读取 slice header，按底层数组分组并画出每个 slice 覆盖的数组元素，
+			relationOfArrayAndSlice、operationSlicing、operationAppend 使用它输出 slice 与数组的关系
+
 		* 在 Golang 中，我们其实不会经常使用数组本身，而是直接使用 Slice 来给数据存储带来足够的灵活度，
 			但鉴于 slice 其实是数组的引用，所以需要非常小心 slice 的内存占用问题
 
@@ -203,11 +206,17 @@ Slices:
 								"France", "Germany", "Spain",
 							} // can be much more
 
-							c = make([]string, 3) // made empty of length and capacity 3
+							c := make([]string, 3) // made empty of length and capacity 3
 							copy(c, countries[:3]) // copied to `c`
 							return c
 						}
 					```
+
+			- 参考下面的 memoryOptimization 用例：
+				分别运行上面两种写法，GC 之后通过 runtime.MemStats 的 HeapAlloc 比较仍然保留的堆内存，
+				并通过 runtime.SetFinalizer 在底层数组上注册 finalizer，观察底层数组何时被回收：
+				bad 写法中，只有返回的 sub-slice 不再被引用之后，底层数组才会被回收
+			- slicelint 中的 subsliceretain analyzer 检查返回、保存局部 slice 或数组中一小段的写法，通过 cmd/tourvet 运行
 	参考文章：
 		* [The anatomy of Slices in Go](https://medium.com/rungo/the-anatomy-of-slices-in-go-6450e3bb2b94)
 		* [Go Slices: usage and internals](https://blog.golang.org/go-slices-usage-and-internals)
@@ -217,6 +226,9 @@ Slices:
 package main
 
 import "fmt"
+import "runtime"
+import "time"
+import "github.com/SamHwang1990/go-tour/07-slices/sliceview"
 
 func sliceInitialize() {
 	// slice1 use zero value, slice1 == nil
@@ -252,21 +264,20 @@ func relationOfArrayAndSlice() {
 	slice4 := arr[2:4]
 
 	fmt.Println("------- relationOfArrayAndSlice -------")
-	fmt.Println("slice1 := arr[:]", slice1, len(slice1), cap(slice1))
-	fmt.Println("slice2 := arr[2:]", slice2, len(slice2), cap(slice2))
-	fmt.Println("slice3 := arr[:4]", slice3, len(slice3), cap(slice3))
-	fmt.Println("slice4 := arr[2:4]", slice4, len(slice4), cap(slice4))
+
+	// 四个 slice 都指向 arr，示意图中属于同一个 array A
+	var view sliceview.View
+	sliceview.Track(&view, "arr", arr[:])
+	sliceview.Track(&view, "slice1 := arr[:]", slice1)
+	sliceview.Track(&view, "slice2 := arr[2:]", slice2)
+	sliceview.Track(&view, "slice3 := arr[:4]", slice3)
+	sliceview.Track(&view, "slice4 := arr[2:4]", slice4)
+	fmt.Print(view.String())
 
 	arr[3] = 33
 
-	fmt.Printf(
-		"after arr[3] = 33\narr[3]=%v, slice1[3]=%v, slice2[1]=%v, slice3[3]=%v, slice4[1]=%v \n",
-		arr[3],
-		slice1[3],
-		slice2[1],
-		slice3[3],
-		slice4[1],
-	)
+	fmt.Println("\nafter arr[3] = 33")
+	fmt.Print(view.String())
 
 	fmt.Println("------- relationOfArrayAndSlice -------")
 
@@ -286,14 +297,19 @@ func operationSlicing() {
 	slice8 := slice3[6:10]
 
 	fmt.Println("------- operationSlicing -------")
-	fmt.Println("slice1 := arr[:]", slice1, len(slice1), cap(slice1))
-	fmt.Println("slice2 := slice1[2:]", slice2, len(slice2), cap(slice2))
-	fmt.Println("slice3 := slice1[:7]", slice3, len(slice3), cap(slice3))
-	fmt.Println("slice4 := slice1[2:7]", slice4, len(slice4), cap(slice4))
-	fmt.Println("slice5 := slice4[1:3]", slice5, len(slice5), cap(slice5))
-	fmt.Println("slice6 := slice4[4:]", slice6, len(slice6), cap(slice6))
-	fmt.Println("slice7 := slice4[5:]", slice7, len(slice7), cap(slice7))
-	fmt.Println("slice8 := slice3[6:]", slice8, len(slice8), cap(slice8))
+
+	// 所有 slice 共享 arr，[low:high:max] 为 slice 在 arr 中的范围，· 为容量内、长度之外的元素
+	var view sliceview.View
+	sliceview.Track(&view, "slice1 := arr[:]", slice1)
+	sliceview.Track(&view, "slice2 := slice1[2:]", slice2)
+	sliceview.Track(&view, "slice3 := slice1[:7]", slice3)
+	sliceview.Track(&view, "slice4 := slice1[2:7]", slice4)
+	sliceview.Track(&view, "slice5 := slice4[1:3]", slice5)
+	sliceview.Track(&view, "slice6 := slice4[4:]", slice6)
+	sliceview.Track(&view, "slice7 := slice4[5:]", slice7)
+	sliceview.Track(&view, "slice8 := slice3[6:10]", slice8)
+	fmt.Print(view.String())
+
 	fmt.Println("------- operationSlicing -------")
 }
 
@@ -343,35 +359,37 @@ func operationAppend() {
 
 	fmt.Println(">> origin arr and slice1")
 	arr := [...]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
-	fmt.Println("arr: ", arr)
-
 	slice1 := arr[2:4]
-	fmt.Println("slice1 := arr[2:4]", slice1, len(slice1), cap(slice1))
+
+	var view sliceview.View
+	sliceview.Track(&view, "arr", arr[:])
+	sliceview.Track(&view, "slice1 := arr[2:4]", slice1)
+	fmt.Print(view.String())
 
 	fmt.Println("")
 	fmt.Println(">> slice2 := append(slice1, -4, -5, -6)")
 	fmt.Println("slice1 has enough capacity, use the original array, thus append operation changed the original array")
 	slice2 := append(slice1, -4, -5, -6)
-	fmt.Println("arr: ", arr)
-	fmt.Println("slice1: ", slice1, len(slice1), cap(slice1))
-	fmt.Println("slice2: ", slice2, len(slice2), cap(slice2))
+	fmt.Println(sliceview.Append(&view, "slice2", slice1, slice2))
+	fmt.Print(view.String())
 
 	fmt.Println("")
 	fmt.Println(">> origin arr2 and slice3")
 
 	arr2 := [...]int{1, 2, 3}
-	fmt.Println("arr2: ", arr2)
-
 	slice3 := arr2[:]
-	fmt.Println("slice3 := arr2[:] ", slice3, len(slice3), cap(slice3))
+
+	var view2 sliceview.View
+	sliceview.Track(&view2, "arr2", arr2[:])
+	sliceview.Track(&view2, "slice3 := arr2[:]", slice3)
+	fmt.Print(view2.String())
 
 	fmt.Println("")
 	fmt.Println(">> slice4 := append(slice3, 4, 5, 6)")
 	fmt.Println("slice3 didn't have enough capacity, append operation will create new array, the original array did not change")
 	slice4 := append(slice3, 4, 5, 6)
-	fmt.Println("arr2: ", arr2)
-	fmt.Println("slice3: ", slice3, len(slice3), cap(slice3))
-	fmt.Println("slice4: ", slice4, len(slice4), cap(slice4))
+	fmt.Println(sliceview.Append(&view2, "slice4", slice3, slice4))
+	fmt.Print(view2.String())
 
 	fmt.Println("------- operationAppend -------")
 }
@@ -433,9 +451,94 @@ func sliceLoopping() {
 	fmt.Println("------- sliceLoopping -------")
 }
 
+// countryNames 为 getCountries 中的国家名称，newCountries 用它们填满一个大 slice
+var countryNames = []string{
+	"United states", "United kingdom", "Austrilia",
+	"India", "China", "Russia",
+	"France", "Germany", "Spain",
+}
+
+// countryCount 为 newCountries 构建的 slice 长度，底层数组共占用 16 MiB
+const countryCount = 1 << 20
+
+// newCountries 构建长度为 countryCount 的 slice，底层数组被回收时，finalizer 会关闭返回的 channel
+func newCountries() ([]string, <-chan struct{}) {
+	countries := make([]string, countryCount)
+	for i := range countries {
+		countries[i] = countryNames[i%len(countryNames)]
+	}
+
+	collected := make(chan struct{})
+	// &countries[0] 指向底层数组的起始位置，finalizer 随整个数组一起触发
+	runtime.SetFinalizer(&countries[0], func(*string) { close(collected) })
+	return countries, collected
+}
+
+// badCountries 直接返回 countries[:3]，整个底层数组随返回的 slice 一起保留
+func badCountries() ([]string, <-chan struct{}) {
+	countries, collected := newCountries()
+	return countries[:3], collected
+}
+
+// goodCountries 将 countries[:3] 复制到新的 slice 中，函数返回后底层数组即可被回收
+func goodCountries() ([]string, <-chan struct{}) {
+	countries, collected := newCountries()
+
+	c := make([]string, 3)
+	copy(c, countries[:3])
+	return c, collected
+}
+
+// heapAlloc 运行 GC 后返回 runtime.MemStats.HeapAlloc，即仍然可以访问的对象占用的堆内存
+func heapAlloc() uint64 {
+	runtime.GC()
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.HeapAlloc
+}
+
+// collectedAfterGC 运行 GC 并等待 finalizer 关闭 collected；finalizer 在单独的 goroutine 中执行，所以需要等待
+func collectedAfterGC(collected <-chan struct{}) bool {
+	runtime.GC()
+	select {
+	case <-collected:
+		return true
+	case <-time.After(500 * time.Millisecond):
+		return false
+	}
+}
+
 func memoryOptimization() {
 	fmt.Println("------- memoryOptimization -------")
 
+	for _, variant := range []struct {
+		name string
+		get  func() ([]string, <-chan struct{})
+	}{
+		{"bad: return countries[:3]", badCountries},
+		{"good: copy(c, countries[:3]); return c", goodCountries},
+	} {
+		fmt.Println(">>", variant.name)
+
+		before := heapAlloc()
+		countries, collected := variant.get()
+		freed := collectedAfterGC(collected)
+		// 带 finalizer 的对象在 finalizer 执行后的下一次 GC 才会释放，heapAlloc 会再运行一次 GC
+		after := heapAlloc()
+
+		fmt.Printf("countries = %v, len %d cap %d\n", countries, len(countries), cap(countries))
+		fmt.Println("backing array collected after GC:", freed)
+		// 四舍五入到 MiB，忽略 GC 之间其他对象带来的误差
+		retained := int64(after) - int64(before)
+		fmt.Printf("heap retained after GC: %d MiB\n", max(retained+1<<19, 0)>>20)
+
+		if !freed {
+			countries = nil
+			fmt.Println("backing array collected after countries = nil:", collectedAfterGC(collected))
+		}
+		fmt.Println("")
+	}
+
 	fmt.Println("------- memoryOptimization -------")
 }
 
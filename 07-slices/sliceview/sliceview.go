@@ -0,0 +1,270 @@
+/*
+Package sliceview 读取 slice header，按底层数组对 slice 分组，并画出每个 slice 覆盖的数组元素，参考 07-slices 中的 Slice is a struct
+
+	slice header 由三部分组成：指向底层数组中第一个元素的指针 Data，长度 Len 以及容量 Cap，
+	slice 的容量一直延伸到底层数组（或者 full slice expression 限定）的末尾：
+		* Data 到 Data + Cap×元素大小 之间的内存有重叠的 slice 共享同一个底层数组，View 将它们分为一组
+		* 示意图中数组按第一次出现的顺序命名为 array A、array B ...，用元素下标而不是地址表示位置，输出与运行环境无关
+		* 示意图在输出时读取数组元素的值，所以通过任意一个 slice 修改元素，都会反映到共享同一个数组的其他 slice 中
+
+	示意图举例，`·` 为 slice 容量内、长度之外的元素：
+		```text
+			array A: 10 × int
+			                       0  1  2  3  4  5  6  7  8  9
+			slice1 := arr[:]       0  1  2  3  4  5  6  7  8  9  [0:10:10] len 10 cap 10
+			slice5 := slice4[1:3]           3  4  ·  ·  ·  ·  ·  [3:5:10] len 2 cap 7
+		```
+
+	Append 比较 append 前后的 slice header，说明 append 是在原数组中原地追加，还是分配了新的数组
+*/
+package sliceview
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"unsafe"
+)
+
+// Header 为 slice header
+type Header struct {
+	Data uintptr // 第一个元素的地址，容量为 0 时没有意义
+	Len  int
+	Cap  int
+}
+
+// HeaderOf 返回 s 的 slice header
+func HeaderOf[E any](s []E) Header {
+	return Header{Data: uintptr(unsafe.Pointer(unsafe.SliceData(s))), Len: len(s), Cap: cap(s)}
+}
+
+// Slice 为 View 记录的一个 slice
+type Slice struct {
+	Name   string
+	Header Header
+	Offset int // 第一个元素在底层数组中的下标，容量为 0 时为 -1
+
+	elemSize uintptr
+	elemType reflect.Type
+	cell     func(i int) string // 返回 s[:cap(s)][i]
+}
+
+// hasArray 表示 slice 引用了可以画出的底层数组：容量为 0 或者元素大小为 0（比如 struct{}）时没有
+func (s *Slice) hasArray() bool {
+	return s.Header.Cap > 0 && s.elemSize > 0
+}
+
+// end 返回容量末尾的地址
+func (s *Slice) end() uintptr {
+	return s.Header.Data + uintptr(s.Header.Cap)*s.elemSize
+}
+
+// Group 为共享同一个底层数组的一组 slice
+type Group struct {
+	Name     string // A、B、C ...
+	Cells    int    // 数组中被这组 slice 的容量覆盖的元素数量
+	ElemType reflect.Type
+	Slices   []*Slice // 按记录的顺序排列
+
+	start uintptr
+	cell  []func() string
+}
+
+// View 记录一组 slice，按底层数组分组并画出示意图
+type View struct {
+	slices []*Slice
+}
+
+// Track 记录 s 此刻的 header，名字 name 会出现在示意图中，比如 "slice2 := slice1[2:]"
+func Track[E any](v *View, name string, s []E) {
+	full := s[:cap(s)]
+	v.slices = append(v.slices, &Slice{
+		Name:     name,
+		Header:   HeaderOf(s),
+		Offset:   -1,
+		elemSize: unsafe.Sizeof(*new(E)),
+		elemType: reflect.TypeFor[E](),
+		cell:     func(i int) string { return fmt.Sprint(full[i]) },
+	})
+}
+
+// Groups 按底层数组对记录的 slice 分组，分组按第一个 slice 记录的顺序排列；
+// 容量为 0、元素大小为 0 的 slice 不属于任何分组
+func (v *View) Groups() []*Group {
+	type span struct {
+		start, end uintptr
+		typ        reflect.Type
+		slices     []*Slice
+	}
+
+	// 按起始地址排序后合并有重叠的内存区间
+	var sorted []*Slice
+	for _, s := range v.slices {
+		if s.hasArray() {
+			sorted = append(sorted, s)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Header.Data < sorted[j].Header.Data })
+	var spans []*span
+	for _, s := range sorted {
+		if n := len(spans); n > 0 && spans[n-1].typ == s.elemType && s.Header.Data < spans[n-1].end {
+			last := spans[n-1]
+			last.end = max(last.end, s.end())
+			last.slices = append(last.slices, s)
+			continue
+		}
+		spans = append(spans, &span{start: s.Header.Data, end: s.end(), typ: s.elemType, slices: []*Slice{s}})
+	}
+
+	order := map[*Slice]int{}
+	for i, s := range v.slices {
+		order[s] = i
+	}
+	var groups []*Group
+	for _, sp := range spans {
+		sort.Slice(sp.slices, func(i, j int) bool { return order[sp.slices[i]] < order[sp.slices[j]] })
+		size := sp.slices[0].elemSize
+		g := &Group{ElemType: sp.typ, Cells: int((sp.end - sp.start) / size), Slices: sp.slices, start: sp.start}
+		g.cell = make([]func() string, g.Cells)
+		for _, s := range sp.slices {
+			s.Offset = int((s.Header.Data - sp.start) / size)
+			for i := 0; i < s.Header.Cap && s.Offset+i < g.Cells; i++ {
+				if g.cell[s.Offset+i] == nil {
+					g.cell[s.Offset+i] = func() string { return s.cell(i) }
+				}
+			}
+		}
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return order[groups[i].Slices[0]] < order[groups[j].Slices[0]] })
+	for i, g := range groups {
+		g.Name = groupName(i)
+	}
+	return groups
+}
+
+func groupName(i int) string {
+	if i < 26 {
+		return string(rune('A' + i))
+	}
+	return fmt.Sprintf("%c%d", 'A'+i%26, i/26)
+}
+
+// Write 输出所有分组的示意图，最后列出容量为 0、元素大小为 0 的 slice
+func (v *View) Write(w io.Writer) error {
+	var b strings.Builder
+	for i, g := range v.Groups() {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		g.render(&b)
+	}
+
+	var empty, zeroSize []string
+	for _, s := range v.slices {
+		switch {
+		case s.Header.Cap == 0:
+			empty = append(empty, s.Name)
+		case s.elemSize == 0:
+			zeroSize = append(zeroSize, s.Name)
+		}
+	}
+	if len(empty) > 0 {
+		fmt.Fprintf(&b, "cap 0, no backing array: %s\n", strings.Join(empty, ", "))
+	}
+	if len(zeroSize) > 0 {
+		fmt.Fprintf(&b, "zero-size elements, no backing array: %s\n", strings.Join(zeroSize, ", "))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (v *View) String() string {
+	var b strings.Builder
+	v.Write(&b)
+	return b.String()
+}
+
+func (g *Group) render(b *strings.Builder) {
+	values := make([]string, g.Cells)
+	width := len(fmt.Sprint(g.Cells - 1))
+	for i, cell := range g.cell {
+		values[i] = cell()
+		width = max(width, len(values[i]))
+	}
+	nameWidth := 0
+	for _, s := range g.Slices {
+		nameWidth = max(nameWidth, len(s.Name))
+	}
+
+	line := func(name string, cells []string, suffix string) {
+		row := fmt.Sprintf("%-*s ", nameWidth, name)
+		for _, c := range cells {
+			row += fmt.Sprintf(" %*s", width, c)
+		}
+		b.WriteString(strings.TrimRight(row+suffix, " ") + "\n")
+	}
+
+	fmt.Fprintf(b, "array %s: %d × %s\n", g.Name, g.Cells, g.ElemType)
+	index := make([]string, g.Cells)
+	for i := range index {
+		index[i] = fmt.Sprint(i)
+	}
+	line("", index, "")
+	for _, s := range g.Slices {
+		cells := make([]string, g.Cells)
+		for i := 0; i < s.Header.Cap; i++ {
+			if i < s.Header.Len {
+				cells[s.Offset+i] = values[s.Offset+i]
+			} else {
+				cells[s.Offset+i] = "·"
+			}
+		}
+		h := s.Header
+		line(s.Name, cells, fmt.Sprintf("  [%d:%d:%d] len %d cap %d", s.Offset, s.Offset+h.Len, s.Offset+h.Cap, h.Len, h.Cap))
+	}
+}
+
+// Append 记录 append 的结果 after（由 before 追加得到），并返回说明：
+// 容量足够时在 before 的底层数组中原地追加，覆盖 before 长度之后的元素，否则分配新的数组并复制 before 的元素；
+// 没有追加元素，或者元素大小为 0（比如 struct{}）时没有可以画出的数组
+func Append[E any](v *View, name string, before, after []E) string {
+	Track(v, name, after)
+	b, a := HeaderOf(before), HeaderOf(after)
+	added := a.Len - b.Len
+	if added == 0 {
+		return fmt.Sprintf("%s: nothing appended, len %d cap %d", name, a.Len, a.Cap)
+	}
+
+	groups := v.Groups()
+	ag, offset := locate(groups, a)
+	if ag == nil {
+		return fmt.Sprintf("%s: len %d + %d, no backing array", name, b.Len, added)
+	}
+	if a.Cap > 0 && b.Cap > 0 && a.Data == b.Data {
+		return fmt.Sprintf("%s: in place, len %d + %d <= cap %d, writes cells %d-%d of array %s",
+			name, b.Len, added, b.Cap, offset+b.Len, offset+a.Len-1, ag.Name)
+	}
+	msg := fmt.Sprintf("%s: reallocated, len %d + %d > cap %d, copies %d elements to new array %s with cap %d",
+		name, b.Len, added, b.Cap, b.Len, ag.Name, a.Cap)
+	if bg, _ := locate(groups, b); bg != nil {
+		msg += fmt.Sprintf(", array %s unchanged", bg.Name)
+	}
+	return msg
+}
+
+// locate 返回 h 所在的分组以及 h 的第一个元素在数组中的下标
+func locate(groups []*Group, h Header) (*Group, int) {
+	if h.Cap == 0 {
+		return nil, -1
+	}
+	for _, g := range groups {
+		size := g.Slices[0].elemSize
+		if g.start <= h.Data && h.Data < g.start+uintptr(g.Cells)*size {
+			return g, int((h.Data - g.start) / size)
+		}
+	}
+	return nil, -1
+}
@@ -0,0 +1,126 @@
+package sliceview
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHeaderOf(t *testing.T) {
+	arr := [...]int{0, 1, 2, 3, 4, 5}
+	whole, tail := HeaderOf(arr[:]), HeaderOf(arr[2:4])
+	if whole.Len != 6 || whole.Cap != 6 || tail.Len != 2 || tail.Cap != 4 {
+		t.Errorf("headers = %+v %+v", whole, tail)
+	}
+	if tail.Data-whole.Data != 2*8 {
+		t.Errorf("arr[2:4] starts %d bytes after arr[:], want 16", tail.Data-whole.Data)
+	}
+}
+
+func TestGroups(t *testing.T) {
+	arr := [...]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	other := []int{1, 2, 3}
+	var v View
+	Track(&v, "s1", arr[2:4])
+	Track(&v, "other", other)
+	Track(&v, "s2", arr[6:8:8]) // full slice expression，容量不到数组末尾
+	Track(&v, "s3", arr[:1])
+	Track(&v, "empty", arr[5:5:5])
+	Track(&v, "strings", []string{"a"})
+
+	groups := v.Groups()
+	if len(groups) != 3 {
+		t.Fatalf("%d groups, want 3", len(groups))
+	}
+	a, b := groups[0], groups[1]
+	if a.Name != "A" || a.Cells != 10 || len(a.Slices) != 3 || b.Name != "B" || b.Cells != 3 {
+		t.Errorf("groups = %+v %+v", a, b)
+	}
+	want := map[string]int{"s1": 2, "s2": 6, "s3": 0, "other": 0}
+	for _, g := range groups[:2] {
+		for _, s := range g.Slices {
+			if s.Offset != want[s.Name] {
+				t.Errorf("%s: offset %d, want %d", s.Name, s.Offset, want[s.Name])
+			}
+		}
+	}
+	if got := groups[2].ElemType.String(); got != "string" {
+		t.Errorf("third group has element type %s, want string", got)
+	}
+}
+
+// TestGroupsDisjoint 检查底层数组的不同部分：没有重叠的 slice 不属于同一组
+func TestGroupsDisjoint(t *testing.T) {
+	arr := [...]int{0, 1, 2, 3}
+	var v View
+	Track(&v, "head", arr[0:1:1])
+	Track(&v, "tail", arr[2:])
+	if groups := v.Groups(); len(groups) != 2 {
+		t.Errorf("%d groups, want 2", len(groups))
+	}
+}
+
+func TestWrite(t *testing.T) {
+	arr := [...]int{0, 1, 2, 3, 4, 5}
+	var v View
+	Track(&v, "arr", arr[:])
+	Track(&v, "s", arr[2:4])
+	Track(&v, "none", arr[6:])
+	arr[3] = 33
+
+	want := `array A: 6 × int
+      0  1  2  3  4  5
+arr   0  1  2 33  4  5  [0:6:6] len 6 cap 6
+s           2 33  ·  ·  [2:4:6] len 2 cap 4
+cap 0, no backing array: none
+`
+	if got := v.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	arr := [...]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	s1 := arr[2:4]
+	var v View
+	Track(&v, "arr", arr[:])
+	got := Append(&v, "s2", s1, append(s1, -4, -5, -6))
+	if want := "s2: in place, len 2 + 3 <= cap 8, writes cells 4-6 of array A"; got != want {
+		t.Errorf("Append = %q, want %q", got, want)
+	}
+	if arr[4] != -4 {
+		t.Errorf("arr[4] = %d, want -4", arr[4])
+	}
+
+	arr2 := [...]int{1, 2, 3}
+	s3 := arr2[:]
+	Track(&v, "arr2", arr2[:])
+	got = Append(&v, "s4", s3, append(s3, 4))
+	if !strings.HasPrefix(got, "s4: reallocated, len 3 + 1 > cap 3, copies 3 elements to new array C with cap ") || !strings.HasSuffix(got, "array B unchanged") {
+		t.Errorf("Append = %q", got)
+	}
+
+	var nilSlice []int
+	got = Append(&v, "s5", nilSlice, append(nilSlice, 1))
+	if want := "s5: reallocated, len 0 + 1 > cap 0, copies 0 elements to new array D with cap 1"; got != want {
+		t.Errorf("Append to nil = %q", got)
+	}
+
+	for _, tt := range []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"nil", Append(&v, "n", nilSlice, nilSlice), "n: nothing appended, len 0 cap 0"},
+		{"in place", Append(&v, "s6", s1, s1), "s6: nothing appended, len 2 cap 8"},
+		{"zero size", Append(&v, "z", []struct{}{}, append([]struct{}{}, struct{}{}, struct{}{})), "z: len 0 + 2, no backing array"},
+		{"zero size nil", Append(&v, "z2", []struct{}(nil), []struct{}(nil)), "z2: nothing appended, len 0 cap 0"},
+	} {
+		if tt.got != tt.want {
+			t.Errorf("Append %s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+	if s := v.String(); !strings.Contains(s, "cap 0, no backing array: n, z2\n") ||
+		!strings.Contains(s, "zero-size elements, no backing array: z\n") {
+		t.Errorf("View with empty slices:\n%s", s)
+	}
+}
@@ -0,0 +1,25 @@
+package wordcount
+
+import (
+	"sort"
+	"strings"
+)
+
+// WordCount 返回 s 中每个单词出现的次数
+func WordCount(s string) map[string]int {
+	counts := map[string]int{}
+	for _, word := range strings.Fields(s) {
+		counts[word]++
+	}
+	return counts
+}
+
+// SortedKeys 返回 m 中按字典序排列的 key
+func SortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,43 @@
+package wordcount
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWordCount(t *testing.T) {
+	tests := []struct {
+		in   string
+		want map[string]int
+	}{
+		{"", map[string]int{}},
+		{"go", map[string]int{"go": 1}},
+		{"the quick brown fox jumps over the lazy dog", map[string]int{
+			"the": 2, "quick": 1, "brown": 1, "fox": 1, "jumps": 1, "over": 1, "lazy": 1, "dog": 1,
+		}},
+		{"  a\tb\n a  ", map[string]int{"a": 2, "b": 1}},
+	}
+	for _, tt := range tests {
+		got := WordCount(tt.in)
+		if got == nil {
+			t.Errorf("WordCount(%q) = nil, want an empty map at least", tt.in)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("WordCount(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	m := map[string]int{"foo": 1, "bar": 2, "zoo": 3, "baz": 4}
+	want := []string{"bar", "baz", "foo", "zoo"}
+	for i := 0; i < 10; i++ {
+		if got := SortedKeys(m); !reflect.DeepEqual(got, want) {
+			t.Fatalf("SortedKeys(%v) = %q, want %q", m, got, want)
+		}
+	}
+	if got := SortedKeys(nil); len(got) != 0 {
+		t.Errorf("SortedKeys(nil) = %q, want empty", got)
+	}
+}
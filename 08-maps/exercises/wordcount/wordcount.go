@@ -0,0 +1,24 @@
+/*
+统计单词数量：
+
+	* WordCount 返回字符串中每个单词（以空白分隔）出现的次数
+	* SortedKeys 返回 map 中按字典序排列的 key，
+		map 的遍历顺序不固定，需要排序才能得到稳定的结果
+*/
+
+//gotour:hint map-operation
+//gotour:hint zero-value
+//gotour:demo mapLooping
+package wordcount
+
+// WordCount 返回 s 中每个单词出现的次数
+func WordCount(s string) map[string]int {
+	// TODO
+	return nil
+}
+
+// SortedKeys 返回 m 中按字典序排列的 key
+func SortedKeys(m map[string]int) []string {
+	// TODO
+	return nil
+}
@@ -29,7 +29,7 @@ Maps:
 		参考：下面的 mapInitialize 函数
 
 		** map 字面量语法：
-			```go
+			```go sketch
 				map[keyType]valueType {
 					key1: value1,
 					key2: value2,
@@ -124,6 +124,9 @@ func mapGetterAndSetter() {
 	fmt.Println("------- mapGetterAndSetter -------")
 }
 
+// map 的遍历顺序不固定，每次运行的输出顺序都可能不同
+//
+//gotour:unordered
 func mapLooping() {
 	map1 := map[int]int{
 		0: 1,
@@ -145,6 +148,7 @@ func mapLooping() {
 	fmt.Println("------- mapLooping -------")
 }
 
+//gotour:unordered
 func main() {
 	mapInitialize()
 	mapGetterAndSetter()
@@ -0,0 +1,30 @@
+package variadic
+
+// Max 返回所有参数中的最大值
+func Max(first int, rest ...int) int {
+	max := first
+	for _, n := range rest {
+		if n > max {
+			max = n
+		}
+	}
+	return max
+}
+
+// Sum 返回所有参数的和
+func Sum(nums ...int) int {
+	sum := 0
+	for _, n := range nums {
+		sum += n
+	}
+	return sum
+}
+
+// SumAll 返回所有 slice 中元素的和
+func SumAll(groups ...[]int) int {
+	sum := 0
+	for _, group := range groups {
+		sum += Sum(group...)
+	}
+	return sum
+}
@@ -0,0 +1,37 @@
+package variadic
+
+import "testing"
+
+func TestMax(t *testing.T) {
+	if got := Max(3); got != 3 {
+		t.Errorf("Max(3) = %d, want 3", got)
+	}
+	if got := Max(3, 7, -1, 5); got != 7 {
+		t.Errorf("Max(3, 7, -1, 5) = %d, want 7", got)
+	}
+	if got := Max(-3, -7); got != -3 {
+		t.Errorf("Max(-3, -7) = %d, want -3", got)
+	}
+	nums := []int{4, 9, 2}
+	if got := Max(1, nums...); got != 9 {
+		t.Errorf("Max(1, %v...) = %d, want 9", nums, got)
+	}
+}
+
+func TestSum(t *testing.T) {
+	if got := Sum(); got != 0 {
+		t.Errorf("Sum() = %d, want 0", got)
+	}
+	if got := Sum(1, 2, 3); got != 6 {
+		t.Errorf("Sum(1, 2, 3) = %d, want 6", got)
+	}
+}
+
+func TestSumAll(t *testing.T) {
+	if got := SumAll(); got != 0 {
+		t.Errorf("SumAll() = %d, want 0", got)
+	}
+	if got := SumAll([]int{1, 2}, nil, []int{3, 4, 5}); got != 15 {
+		t.Errorf("SumAll([1 2], nil, [3 4 5]) = %d, want 15", got)
+	}
+}
@@ -0,0 +1,29 @@
+/*
+可变参数函数：
+
+	* Max 返回所有参数中的最大值，至少需要一个参数
+	* Sum 返回所有参数的和，没有参数时返回 0
+	* SumAll 返回所有 slice 中元素的和，请使用 unpack operator 调用 Sum
+*/
+
+//gotour:hint pack-operator
+//gotour:hint unpack-operator
+package variadic
+
+// Max 返回所有参数中的最大值
+func Max(first int, rest ...int) int {
+	// TODO
+	return 0
+}
+
+// Sum 返回所有参数的和
+func Sum(nums ...int) int {
+	// TODO
+	return 0
+}
+
+// SumAll 返回所有 slice 中元素的和
+func SumAll(groups ...[]int) int {
+	// TODO
+	return 0
+}
@@ -0,0 +1,15 @@
+/*
+使用指针交换变量：
+
+	目前的 Swap 只交换了两个指针参数本身（它们只是函数内的局部变量），调用者的变量没有任何变化，
+	请修正 Swap，交换 a、b 指向的值：
+		` x, y := 1, 2; Swap(&x, &y) ` 之后，` x == 2 `、` y == 1 `
+*/
+
+//gotour:hint pointer-operation
+package swap
+
+// Swap 交换 a、b 指向的值
+func Swap(a, b *int) {
+	a, b = b, a
+}
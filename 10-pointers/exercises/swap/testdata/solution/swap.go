@@ -0,0 +1,6 @@
+package swap
+
+// Swap 交换 a、b 指向的值
+func Swap(a, b *int) {
+	*a, *b = *b, *a
+}
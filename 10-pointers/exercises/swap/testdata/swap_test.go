@@ -0,0 +1,25 @@
+package swap
+
+import "testing"
+
+func TestSwap(t *testing.T) {
+	x, y := 1, 2
+	px, py := &x, &y
+
+	Swap(px, py)
+
+	if x != 2 || y != 1 {
+		t.Errorf("after Swap(&x, &y), x, y = %d, %d, want 2, 1", x, y)
+	}
+	if px != &x || py != &y {
+		t.Errorf("Swap must not change where the pointers point to")
+	}
+}
+
+func TestSwapSame(t *testing.T) {
+	x := 1
+	Swap(&x, &x)
+	if x != 1 {
+		t.Errorf("after Swap(&x, &x), x = %d, want 1", x)
+	}
+}
@@ -58,7 +58,7 @@ Pointers:
 
 			** 读取 Pointer 中的数据：
 				```go
-					function pointerValueGetter(point *int) {
+					func pointerValueGetter(point *int) {
 
 						// 直接使用 `*` 操作符即可完成读取操作
 						fmt.Println(*point)
@@ -67,9 +67,9 @@ Pointers:
 
 			** 更改 Pointer 中的数据，此更改操作会影响到所有引用到该内存地址的变量的值：
 			```go
-				function pointerValueSetter(point *int) {
+				func pointerValueSetter(point *int) {
 					// 直接使用 `*` 操作符获取引用并进行赋值操作
-					*point = (*pointer) * 2
+					*point = (*point) * 2
 				}
 
 				a := 3
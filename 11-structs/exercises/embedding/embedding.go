@@ -0,0 +1,28 @@
+/*
+匿名字段与字段提升：
+
+	完成 Employee 的定义，使其：
+		* 以匿名字段的方式嵌入 Person，从而可以直接读写 ` e.First `、` e.Last `
+		* 包含 string 类型的 Company 字段
+*/
+
+//gotour:hint struct-type-定义
+//gotour:hint struct-operation-读写操作
+//gotour:demo promotedFields
+package embedding
+
+// Person 描述一个人
+type Person struct {
+	First string
+	Last  string
+}
+
+// FullName 返回 p 的全名
+func FullName(p Person) string {
+	return p.First + " " + p.Last
+}
+
+// Employee 描述一个雇员
+type Employee struct {
+	// TODO
+}
@@ -0,0 +1,27 @@
+package embedding
+
+import "testing"
+
+func TestPromotedFields(t *testing.T) {
+	p := Person{First: "Sam", Last: "Hwang"}
+	e := Employee{Person: p, Company: "Gopher Inc."}
+
+	if e.First != "Sam" || e.Last != "Hwang" {
+		t.Errorf("promoted fields: got %q %q, want %q %q", e.First, e.Last, "Sam", "Hwang")
+	}
+	if got := FullName(e.Person); got != "Sam Hwang" {
+		t.Errorf("FullName(e.Person) = %q, want %q", got, "Sam Hwang")
+	}
+	if e.Company != "Gopher Inc." {
+		t.Errorf("e.Company = %q, want %q", e.Company, "Gopher Inc.")
+	}
+
+	// 嵌入的 struct 字段是值复制，修改 e 不会影响 p
+	e.First = "Ping"
+	if e.Person.First != "Ping" {
+		t.Errorf("e.First = \"Ping\" should set e.Person.First, got %q", e.Person.First)
+	}
+	if p.First != "Sam" {
+		t.Errorf("changing e.First changed p.First to %q", p.First)
+	}
+}
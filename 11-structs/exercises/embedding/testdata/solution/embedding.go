@@ -0,0 +1,18 @@
+package embedding
+
+// Person 描述一个人
+type Person struct {
+	First string
+	Last  string
+}
+
+// FullName 返回 p 的全名
+func FullName(p Person) string {
+	return p.First + " " + p.Last
+}
+
+// Employee 描述一个雇员
+type Employee struct {
+	Person
+	Company string
+}
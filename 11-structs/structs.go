@@ -36,7 +36,7 @@ Struct:
 			```
 
 			举例：
-				```go
+				```go sketch
 					struct {
 						fieldName1 TypeName1
 						fieldName2 TypeName2
@@ -58,7 +58,7 @@ Struct:
 					structVar := struct {
 						name string
 					} {
-						name: "foo"
+						name: "foo",
 					}
 				```
 
@@ -81,7 +81,7 @@ Struct:
 						// 基础类型
 						name string
 						age int
-						male boolean
+						male bool
 
 						// 指针类型
 						weight *int
@@ -91,8 +91,8 @@ Struct:
 						sayHi func(name string, age int) string
 
 						// Nested Struct Field，嵌套 Struct Type
-						mother Person
-						father Person
+						mother *Person
+						father *Person
 					}
 				```
 			** Nested Struct Field，嵌套 Struct Type
@@ -108,7 +108,7 @@ Struct:
 
 				Struct Type 中，匿名字段的类型名不能冲突：
 					- Success：
-						```go
+						```go sketch
 							// A struct with four embedded fields of types T1, *T2, P.T3 and *P.T4
 							struct {
 								T1        // field name is T1
@@ -119,7 +119,7 @@ Struct:
 							}
 						```
 					- Error:
-						```go
+						```go sketch
 							struct {
 								T     // conflicts with embedded field *T and *P.T
 								*T    // conflicts with embedded field T and *P.T
@@ -161,6 +161,14 @@ Struct:
 				```
 
 	struct 声明及初始化
+		* 以包含匿名字段的 Person 为例：
+			```go
+				type Person struct {
+					name string
+					age  int
+					string
+				}
+			```
 		* 声明 struct，不初始化，此时使用 zero value
 			```go
 				var sam Person
@@ -172,7 +180,7 @@ Struct:
 					age: 18,
 
 					// Anonymous Field 初始化，需要声明类型名
-					string: "Bar"
+					string: "Bar",
 				}
 			```
 
@@ -203,13 +211,13 @@ Struct:
 					Person: foo,
 				}
 
-				fooEmployee.name = 'bar'
+				fooEmployee.name = "bar"
 
 				// true
-				fooEmployee.name == 'bar'
+				fooEmployee.name == "bar"
 
 				// true
-				foo.name == 'foo'
+				foo.name == "foo"
 			```
 
 	struct operation，读写操作：
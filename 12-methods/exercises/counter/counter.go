@@ -0,0 +1,25 @@
+/*
+指针类型的 Receiver：
+
+	下面的 Counter 调用 Inc 之后，计数并没有增加，
+	请修正 Inc 的 Receiver，使 ` c.Inc() ` 可以修改 c 本身
+*/
+
+//gotour:hint 指针类型的-receiver
+//gotour:hint 调用方法时-receiver-为值传递
+package counter
+
+// Counter 为计数器，zero value 可以直接使用
+type Counter struct {
+	n int
+}
+
+// Inc 将计数加一
+func (c Counter) Inc() {
+	c.n++
+}
+
+// Value 返回当前计数
+func (c Counter) Value() int {
+	return c.n
+}
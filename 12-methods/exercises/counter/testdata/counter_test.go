@@ -0,0 +1,22 @@
+package counter
+
+import "testing"
+
+func TestInc(t *testing.T) {
+	var c Counter
+	for i := 0; i < 3; i++ {
+		c.Inc()
+	}
+	if got := c.Value(); got != 3 {
+		t.Errorf("after 3 calls of Inc, Value() = %d, want 3", got)
+	}
+}
+
+func TestIncThroughPointer(t *testing.T) {
+	c := &Counter{}
+	c.Inc()
+	c.Inc()
+	if got := c.Value(); got != 2 {
+		t.Errorf("after 2 calls of Inc, Value() = %d, want 2", got)
+	}
+}
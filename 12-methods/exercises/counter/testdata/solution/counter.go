@@ -0,0 +1,16 @@
+package counter
+
+// Counter 为计数器，zero value 可以直接使用
+type Counter struct {
+	n int
+}
+
+// Inc 将计数加一
+func (c *Counter) Inc() {
+	c.n++
+}
+
+// Value 返回当前计数
+func (c Counter) Value() int {
+	return c.n
+}
@@ -18,21 +18,21 @@ Methods:
 				```go
 					// panic error: 只允许对 package 内的类型进行 Method 定义
 					func (str string) length() int {
-						...
+						return len(str)
 					}
 				```
 			- fix: 通过在 package 内对预设的 string 类型进行 type alias，即创建一个新的类型：
 					` type MyString string `
 
 				此时，就可以给 MyString 类型扩展方法了：
-					```go
+					```go sketch
 						func (str MyString) length() int {
 							...
 						}
 					```
 
 	语法：
-		```go
+		```go sketch
 			func (r ReceiverType) functionName(...ArgType) ReturnType {
 				// do something with r
 				// get receiver field: r.fieldName
@@ -48,6 +48,10 @@ Methods:
 		使用指针类型的 Receiver，可以进行引用传递，对原触发者进行修改
 		举例：
 			```go
+				type Person struct {
+					age int
+				}
+
 				func (p *Person) grow() {
 					// 获取指针指向的 struct 对象，并更改字段值
 					(*p).age++
@@ -56,7 +60,7 @@ Methods:
 				person := Person{}
 
 				// 获取 person 变量的指针对象并触发指针对象上的 grow 方法
-				(&p).grow()
+				(&person).grow()
 			```
 
 		一般来说，Method 的 Receiver 都是使用指针类型的，
@@ -67,6 +71,10 @@ Methods:
 		所以，比较好的做法是，将 Method 的 Receiver 类型设置为对应的指针类型
 
 		```go
+			type Person struct {
+				age int
+			}
+
 			// 非指针 Receiver
 			func (p Person) growToAnother() {
 				p.age++
@@ -0,0 +1,30 @@
+/*
+Foo 与 *Foo 同时实现 ILock：
+
+	Foo 的 Release 方法使用了指针类型的 Receiver，所以目前只有 *Foo 实现了 ILock，
+	` var lo ILock = Foo{} ` 无法编译
+
+	请修改 Foo 的方法，使 Foo 以及 *Foo 都实现 ILock
+*/
+
+//gotour:hint 实现-interface-implementing-interface
+//gotour:hint interface-变量使用
+//gotour:demo pointerReceiver
+package ilock
+
+// ILock 为锁的接口
+type ILock interface {
+	Acquire()
+	Release()
+}
+
+// Foo 为一个什么都不做的锁
+type Foo struct {
+}
+
+func (f Foo) Acquire() {
+}
+
+// 指针类型的 Receiver
+func (f *Foo) Release() {
+}
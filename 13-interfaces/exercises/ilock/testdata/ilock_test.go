@@ -0,0 +1,23 @@
+package ilock
+
+import "testing"
+
+func TestFooImplementsILock(t *testing.T) {
+	var lo ILock = Foo{}
+	lo.Acquire()
+	lo.Release()
+
+	if _, ok := lo.(Foo); !ok {
+		t.Errorf("lo.(Foo) failed, dynamic type is %T", lo)
+	}
+}
+
+func TestPointerFooImplementsILock(t *testing.T) {
+	var lo ILock = &Foo{}
+	lo.Acquire()
+	lo.Release()
+
+	if _, ok := lo.(*Foo); !ok {
+		t.Errorf("lo.(*Foo) failed, dynamic type is %T", lo)
+	}
+}
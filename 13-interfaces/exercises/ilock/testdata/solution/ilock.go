@@ -0,0 +1,17 @@
+package ilock
+
+// ILock 为锁的接口
+type ILock interface {
+	Acquire()
+	Release()
+}
+
+// Foo 为一个什么都不做的锁
+type Foo struct {
+}
+
+func (f Foo) Acquire() {
+}
+
+func (f Foo) Release() {
+}
@@ -23,7 +23,7 @@ Interface
 			仅创建了一个 interface 定义，但没有声明为一个类型，
 			多用于函数参数类型声明
 
-			```go
+			```go sketch
 				interface {
 					Read()  (int, error)
 					Write() (int, error)
@@ -49,7 +49,7 @@ Interface
 			- 被嵌套的 Interface 必须是具名 Interface，即 Interface Type，
 				不支持嵌套匿名 Interface
 
-			```go
+			```go sketch
 				type ReadWriter interface {
 					Read(b Buffer) bool
 					Write(b Buffer) bool
@@ -76,7 +76,7 @@ Interface
 
 				func (f Foo) Lock() {}
 
-				func (f Foo) UnLock() {}
+				func (f Foo) Unlock() {}
 			```
 			例子中，
 				-- 创建了一个 Interface Type：Lock，该接口需要实现两个方法：Lock、Unlock
@@ -95,7 +95,7 @@ Interface
 		- Go 中所有的类型都实现了 Empty Interface，即相当于 Typescript 中的 any
 		- 多用于函数参数类型声明
 		- 语法：
-			```go
+			```go sketch
 				interface{}
 			```
 
@@ -122,16 +122,16 @@ Interface
 
 				func (f Foo) Lock() {}
 
-				func (f Foo) UnLock() {}
+				func (f Foo) Unlock() {}
 			```
 
 		- 变量声明：
-			` Lock lo `
+			` var lo Lock `
 		- 变量初始化
 			```go
 				foo := Foo{}
 
-				Lock lo = foo
+				var lo Lock = foo
 
 				lo.Unlock()
 				lo.Lock()
@@ -140,7 +140,7 @@ Interface
 
 	类型推断（ Type Assertion ）
 		- 语法：
-			```go
+			```go sketch
 				typeInstance, isOk := x.(Type)
 			```
 
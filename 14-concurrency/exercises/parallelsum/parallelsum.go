@@ -0,0 +1,16 @@
+/*
+并发求和：
+
+	实现 Sum，将 nums 分成 workers 份，每一份在单独的 goroutine 中求和，
+	再通过 channel 汇总各个 goroutine 的结果：
+		* workers 小于 1 时，按 1 处理
+		* workers 大于 len(nums) 时，不需要启动多余的 goroutine
+*/
+
+package parallelsum
+
+// Sum 使用 workers 个 goroutine 并发计算 nums 的和
+func Sum(nums []int, workers int) int {
+	// TODO
+	return 0
+}
@@ -0,0 +1,46 @@
+package parallelsum
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestSum(t *testing.T) {
+	nums := make([]int, 1000)
+	want := 0
+	for i := range nums {
+		nums[i] = i
+		want += i
+	}
+
+	for _, workers := range []int{-1, 0, 1, 2, 3, 7, 1000, 2000} {
+		if got := Sum(nums, workers); got != want {
+			t.Errorf("Sum(0..999, %d) = %d, want %d", workers, got, want)
+		}
+	}
+}
+
+func TestSumEmpty(t *testing.T) {
+	for _, workers := range []int{0, 1, 4} {
+		if got := Sum(nil, workers); got != 0 {
+			t.Errorf("Sum(nil, %d) = %d, want 0", workers, got)
+		}
+	}
+}
+
+func TestSumNoLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+	for i := 0; i < 100; i++ {
+		Sum([]int{1, 2, 3, 4, 5}, 4)
+	}
+
+	// 给已经返回结果的 goroutine 一点退出的时间
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("%d goroutines still running after Sum returned", n-before)
+	}
+}
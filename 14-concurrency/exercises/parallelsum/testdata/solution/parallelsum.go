@@ -0,0 +1,38 @@
+package parallelsum
+
+// Sum 使用 workers 个 goroutine 并发计算 nums 的和
+func Sum(nums []int, workers int) int {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(nums) {
+		workers = len(nums)
+	}
+	if workers == 0 {
+		return 0
+	}
+
+	results := make(chan int, workers)
+	size := (len(nums) + workers - 1) / workers
+	n := 0
+	for start := 0; start < len(nums); start += size {
+		end := start + size
+		if end > len(nums) {
+			end = len(nums)
+		}
+		n++
+		go func(part []int) {
+			sum := 0
+			for _, v := range part {
+				sum += v
+			}
+			results <- sum
+		}(nums[start:end])
+	}
+
+	sum := 0
+	for i := 0; i < n; i++ {
+		sum += <-results
+	}
+	return sum
+}
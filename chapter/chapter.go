@@ -0,0 +1,354 @@
+/*
+Package chapter 负责发现并描述 tour 中的章节
+
+	章节约定：
+		* 仓库根目录下，名字符合 `NN-topic` 格式的目录即为一个章节，比如 `07-slices`
+		* 章节目录是一个 main package，`go run` 即可运行
+		* 章节标题取自 package doc comment 的第一行，比如 `Slices:`
+		* 章节中，顶级作用域内无参数、无返回值的函数（main、init 除外）均视为 demo 函数，比如 `operationAppend`
+		* 函数的 doc comment 中可以使用 `//gotour:` 指令标记函数的特性，比如：
+			** `//gotour:unordered`，输出的行顺序不固定，比如遍历 map 的输出
+
+	章节列表完全从目录结构以及源码中推断出来，不需要手动维护索引
+*/
+package chapter
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ImportPath 为仓库在 GOPATH 中的 import path
+const ImportPath = "github.com/SamHwang1990/go-tour"
+
+var dirPattern = regexp.MustCompile(`^(\d{2})-([a-z0-9-]+)$`)
+
+// Chapter 描述一个章节目录
+type Chapter struct {
+	Number int    // 章节序号，比如 7
+	Slug   string // 章节主题，比如 "slices"
+	Dir    string // 章节目录的绝对路径
+	Title  string // 章节标题，取自 package doc comment
+
+	// Doc 为 package doc comment 的原始文本，已去掉注释符号，但保留缩进以及空行，
+	// Doc 的第 i 行（从 0 开始）对应 DocFile 中的第 DocLine + i 行
+	Doc     string
+	DocFile string
+	DocLine int
+
+	Main  Demo   // main 函数
+	Demos []Demo // demo 函数，按源码出现顺序排列
+}
+
+// Demo 描述章节中的一个 demo 函数（或 main 函数）
+type Demo struct {
+	Name       string
+	File       string // 所在文件名，不含目录
+	Line       int
+	Directives []string // doc comment 中的 `//gotour:` 指令，不含前缀，比如 "unordered"
+}
+
+// Has 判断函数是否声明了指定指令
+func (d Demo) Has(directive string) bool {
+	for _, name := range d.Directives {
+		if name == directive {
+			return true
+		}
+	}
+	return false
+}
+
+// Name 返回章节目录名，比如 "07-slices"
+func (c Chapter) Name() string {
+	return fmt.Sprintf("%02d-%s", c.Number, c.Slug)
+}
+
+// Demo 按名字查找 demo 函数
+func (c Chapter) Demo(name string) (Demo, bool) {
+	for _, d := range c.Demos {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return Demo{}, false
+}
+
+// Discover 扫描 root 目录，返回按序号排列的所有章节
+func Discover(root string) ([]Chapter, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var chapters []Chapter
+	for _, entry := range entries {
+		if !entry.IsDir() || !dirPattern.MatchString(entry.Name()) {
+			continue
+		}
+		c, err := Load(filepath.Join(root, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		chapters = append(chapters, c)
+	}
+
+	sort.Slice(chapters, func(i, j int) bool {
+		return chapters[i].Number < chapters[j].Number
+	})
+
+	return chapters, nil
+}
+
+// Load 解析章节目录中的源码，提取标题、doc comment 以及 demo 函数
+func Load(dir string) (Chapter, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return Chapter{}, err
+	}
+
+	c := Chapter{Dir: abs}
+	if m := dirPattern.FindStringSubmatch(filepath.Base(abs)); m != nil {
+		c.Number, _ = strconv.Atoi(m[1])
+		c.Slug = m[2]
+	}
+
+	files, err := parseDir(abs)
+	if err != nil {
+		return Chapter{}, err
+	}
+
+	var docs []*sourceDoc
+	var mainDoc *sourceDoc
+	for _, f := range files {
+		doc := leadingComment(f)
+		if doc != nil {
+			docs = append(docs, doc)
+		}
+		for _, decl := range f.ast.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil {
+				continue
+			}
+			d := Demo{
+				Name:       fn.Name.Name,
+				File:       f.name,
+				Line:       f.fset.Position(fn.Pos()).Line,
+				Directives: directives(fn.Doc),
+			}
+			if fn.Name.Name == "main" {
+				mainDoc = doc
+				c.Main = d
+			}
+			if isDemo(fn) {
+				c.Demos = append(c.Demos, d)
+			}
+		}
+	}
+
+	// 优先使用 main 函数所在文件的 doc comment，
+	// 若 package 中只有一个文件有 doc comment，则使用该 comment，
+	// 否则（比如 14-concurrency 拆分成了多个主题文件），使用目录名作为标题
+	if mainDoc == nil && len(docs) == 1 {
+		mainDoc = docs[0]
+	}
+	if mainDoc != nil {
+		c.Doc, c.DocFile, c.DocLine = mainDoc.text, mainDoc.file, mainDoc.line
+	}
+
+	c.Title = titleOf(c.Doc)
+	if c.Title == "" {
+		c.Title = titleFromSlug(c.Slug)
+	}
+
+	return c, nil
+}
+
+// Find 根据参数查找章节，支持 "07"、"7"、"slices"、"07-slices" 几种写法
+func Find(chapters []Chapter, key string) (Chapter, error) {
+	if n, err := strconv.Atoi(key); err == nil {
+		for _, c := range chapters {
+			if c.Number == n {
+				return c, nil
+			}
+		}
+	}
+	for _, c := range chapters {
+		if c.Slug == key || c.Name() == key {
+			return c, nil
+		}
+	}
+	return Chapter{}, fmt.Errorf("chapter %q not found", key)
+}
+
+// FindRoot 查找仓库根目录：
+//   - 从 start 开始逐级向上查找包含章节目录的目录
+//   - 若找不到，则在 GOPATH 中查找 ImportPath
+func FindRoot(start string) (string, error) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if hasChapters(dir) {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	if pkg, err := build.Import(ImportPath, "", build.FindOnly); err == nil && hasChapters(pkg.Dir) {
+		return pkg.Dir, nil
+	}
+
+	return "", errors.New("cannot find go-tour root directory, use -root to specify it")
+}
+
+func hasChapters(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && dirPattern.MatchString(entry.Name()) {
+			return true
+		}
+	}
+	return false
+}
+
+type sourceFile struct {
+	name string
+	fset *token.FileSet
+	ast  *ast.File
+}
+
+// parseDir 按文件名顺序解析目录中非测试的 go 文件
+func parseDir(dir string) ([]sourceFile, error) {
+	names, err := goFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []sourceFile
+	for _, name := range names {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, sourceFile{name: name, fset: fset, ast: f})
+	}
+	return files, nil
+}
+
+// goFiles 返回目录中非测试的 go 文件名，按文件名排序
+func goFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+type sourceDoc struct {
+	text string
+	file string
+	line int
+}
+
+// leadingComment 返回 package 声明之前的注释，
+// 章节文件中，doc comment 与 package 声明之间通常隔了一个空行，所以不能直接使用 ast.File.Doc
+func leadingComment(f sourceFile) *sourceDoc {
+	group := f.ast.Doc
+	if group == nil && len(f.ast.Comments) > 0 && f.ast.Comments[0].End() < f.ast.Package {
+		group = f.ast.Comments[0]
+	}
+	if group == nil {
+		return nil
+	}
+
+	// 不使用 CommentGroup.Text，因为它会去掉首尾空行以及缩进，导致行号无法与源码对应
+	var lines []string
+	for _, c := range group.List {
+		if strings.HasPrefix(c.Text, "/*") {
+			lines = append(lines, strings.TrimSuffix(c.Text[2:], "*/"))
+		} else {
+			lines = append(lines, strings.TrimPrefix(c.Text[2:], " "))
+		}
+	}
+
+	return &sourceDoc{
+		text: strings.Join(lines, "\n"),
+		file: f.name,
+		line: f.fset.Position(group.Pos()).Line,
+	}
+}
+
+func isDemo(fn *ast.FuncDecl) bool {
+	name := fn.Name.Name
+	if name == "main" || name == "init" || name == "_" {
+		return false
+	}
+	t := fn.Type
+	return t.TypeParams == nil && t.Params.NumFields() == 0 && t.Results.NumFields() == 0
+}
+
+const directivePrefix = "//gotour:"
+
+// directives 返回函数 doc comment 中 `//gotour:` 指令的名字
+func directives(doc *ast.CommentGroup) []string {
+	if doc == nil {
+		return nil
+	}
+	var names []string
+	for _, c := range doc.List {
+		if strings.HasPrefix(c.Text, directivePrefix) {
+			names = append(names, strings.TrimSpace(c.Text[len(directivePrefix):]))
+		}
+	}
+	return names
+}
+
+// titleOf 取 doc comment 的第一行非空文本作为标题，并去掉末尾的冒号
+func titleOf(doc string) string {
+	for _, line := range strings.Split(doc, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		return strings.TrimSpace(strings.TrimRight(line, ":："))
+	}
+	return ""
+}
+
+func titleFromSlug(slug string) string {
+	words := strings.Split(slug, "-")
+	for i, w := range words {
+		if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,128 @@
+package chapter
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 运行 `go test ./chapter -update` 重新生成 golden 文件
+var update = flag.Bool("update", false, "update golden files in <chapter>/testdata")
+
+// 指针地址、函数地址在不同的运行环境中会变化，比较前统一替换掉
+var addressPattern = regexp.MustCompile(`0x[0-9a-f]{6,}`)
+
+const addressPlaceholder = "0xADDR"
+
+// TestGolden 运行每个章节的 main 函数以及每个 demo 函数，并将标准输出与 <chapter>/testdata/<name>.golden 比较
+//
+// 输出中的地址会被替换为 0xADDR；声明了 `//gotour:unordered` 指令的函数，比较时忽略行的顺序
+func TestGolden(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping golden tests in short mode: every chapter is compiled with go run")
+	}
+
+	chapters, err := Discover("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range chapters {
+		t.Run(c.Name(), func(t *testing.T) {
+			t.Parallel()
+
+			t.Run("main", func(t *testing.T) {
+				testGolden(t, c, c.Main, "")
+			})
+			for _, d := range c.Demos {
+				t.Run(d.Name, func(t *testing.T) {
+					testGolden(t, c, d, d.Name)
+				})
+			}
+		})
+	}
+}
+
+func testGolden(t *testing.T, c Chapter, fn Demo, demo string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	if err := Run(ctx, c, demo, &stdout, &stderr); err != nil {
+		t.Fatalf("%s %s: %v\n%s", c.Name(), fn.Name, err, stderr.String())
+	}
+	got := normalize(stdout.String())
+
+	golden := filepath.Join(c.Dir, "testdata", fn.Name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	data, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("%v (run `go test ./chapter -update` to create it)", err)
+	}
+	want := string(data)
+
+	if fn.Has("unordered") {
+		got, want = sortLines(got), sortLines(want)
+	}
+	if got != want {
+		t.Errorf("%s %s: output differs from %s\n--- got\n%s\n--- want\n%s", c.Name(), fn.Name, golden, got, want)
+	}
+}
+
+func normalize(out string) string {
+	return addressPattern.ReplaceAllString(out, addressPlaceholder)
+}
+
+func sortLines(s string) string {
+	lines := strings.Split(s, "\n")
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"pointer value: 0xc000012345, memory value: 1", "pointer value: 0xADDR, memory value: 1"},
+		{"{Foo Lueng 0x49c540 140 175}", "{Foo Lueng 0xADDR 140 175}"},
+		{"small hex 0x10 is kept", "small hex 0x10 is kept"},
+	}
+	for _, tt := range tests {
+		if got := normalize(tt.in); got != tt.want {
+			t.Errorf("normalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDirectives(t *testing.T) {
+	c, err := Load("../08-maps")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.Main.Has("unordered") {
+		t.Errorf("08-maps main: want unordered directive, got %q", c.Main.Directives)
+	}
+	d, ok := c.Demo("mapLooping")
+	if !ok || !d.Has("unordered") {
+		t.Errorf("08-maps mapLooping: want unordered directive, got %q", d.Directives)
+	}
+	if d, _ := c.Demo("mapInitialize"); d.Has("unordered") {
+		t.Errorf("08-maps mapInitialize: unexpected unordered directive")
+	}
+}
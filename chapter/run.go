@@ -0,0 +1,100 @@
+package chapter
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// renamedMain 为单独运行 demo 函数时，章节原 main 函数被重命名后的名字
+const renamedMain = "gotourChapterMain"
+
+// Run 运行章节，stdout、stderr 分别接收程序的标准输出及标准错误
+//   - 若 demo 为空，则运行章节的 main 函数，等同于在章节目录中执行 `go run .`
+//   - 若 demo 不为空，则只运行该 demo 函数，参考 Workspace
+func Run(ctx context.Context, c Chapter, demo string, stdout, stderr io.Writer) error {
+	return goCommand(ctx, c, demo, stdout, stderr, "run", ".")
+}
+
+// Build 将章节编译为可执行文件 output，编译错误输出到 stderr，demo 的含义与 Run 相同
+func Build(ctx context.Context, c Chapter, demo, output string, stderr io.Writer) error {
+	output, err := filepath.Abs(output)
+	if err != nil {
+		return err
+	}
+	return goCommand(ctx, c, demo, stderr, stderr, "build", "-o", output, ".")
+}
+
+// goCommand 在章节目录中执行 go 命令，若 demo 不为空，则在 demo 的 Workspace 中执行
+func goCommand(ctx context.Context, c Chapter, demo string, stdout, stderr io.Writer, args ...string) error {
+	dir := c.Dir
+	if demo != "" {
+		ws, err := Workspace(c, demo)
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(ws)
+		dir = ws
+	}
+
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	return cmd.Run()
+}
+
+// Workspace 在临时目录中生成一个只运行 demo 函数的 main package，返回临时目录路径，由调用者负责删除
+//
+// 生成过程：
+//   - 复制章节中所有非测试的 go 文件
+//   - 将原 main 函数重命名为 gotourChapterMain，这样 package 中的其他代码（包括 init 函数）保持不变
+//   - 新增 gotour_main.go，其 main 函数只调用指定的 demo 函数
+func Workspace(c Chapter, demo string) (string, error) {
+	if _, ok := c.Demo(demo); !ok {
+		return "", fmt.Errorf("%s: demo %q not found", c.Name(), demo)
+	}
+
+	files, err := parseDir(c.Dir)
+	if err != nil {
+		return "", err
+	}
+
+	dir, err := os.MkdirTemp("", "gotour-"+c.Name()+"-")
+	if err != nil {
+		return "", err
+	}
+
+	for _, f := range files {
+		for _, decl := range f.ast.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "main" {
+				fn.Name.Name = renamedMain
+			}
+		}
+
+		out, err := os.Create(filepath.Join(dir, f.name))
+		if err != nil {
+			os.RemoveAll(dir)
+			return "", err
+		}
+		err = format.Node(out, f.fset, f.ast)
+		out.Close()
+		if err != nil {
+			os.RemoveAll(dir)
+			return "", err
+		}
+	}
+
+	main := fmt.Sprintf("package main\n\nfunc main() {\n\t%s()\n}\n", demo)
+	if err := os.WriteFile(filepath.Join(dir, "gotour_main.go"), []byte(main), 0644); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+
+	return dir, nil
+}
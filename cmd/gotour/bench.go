@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/SamHwang1990/go-tour/06-arrays/arraycopy"
+)
+
+// cmdBench 运行基准测试并以表格输出结果，目前只有 arrays：数组复制的开销，参考 06-arrays/arraycopy
+func cmdBench(t *tour, args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	benchtime := fs.Duration("benchtime", 100*time.Millisecond, "run each benchmark for at least this long")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 1 || fs.Arg(0) != "arrays" {
+		return errors.New("usage: gotour bench [-benchtime d] arrays [op...]")
+	}
+
+	ops := map[string]bool{}
+	for _, op := range fs.Args()[1:] {
+		found := false
+		for _, known := range arraycopy.Ops {
+			found = found || op == known
+		}
+		if !found {
+			return fmt.Errorf("unknown op %q, want one of %v", op, arraycopy.Ops)
+		}
+		ops[op] = true
+	}
+
+	// 编译器的决定只是辅助信息，没有 go 命令时只输出基准测试的结果
+	diags, err := arraycopy.Compile(filepath.Join(t.root, "06-arrays", "arraycopy"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gotour: skipping -gcflags=-m notes: %v\n", err)
+	}
+
+	var cases []arraycopy.Case
+	for _, c := range arraycopy.Cases() {
+		if len(ops) == 0 || ops[c.Op] {
+			cases = append(cases, c)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "running %d benchmarks, %v each\n", len(cases), *benchtime)
+	var results []arraycopy.Result
+	for _, c := range cases {
+		results = append(results, arraycopy.Measure(c, *benchtime))
+	}
+	return arraycopy.Write(os.Stdout, results, diags)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/SamHwang1990/go-tour/04-functions/calc"
+)
+
+// cmdCalc 计算参数中的表达式；没有参数时进入 REPL，从标准输入逐行读取表达式
+func cmdCalc(t *tour, args []string) error {
+	r := calc.Default()
+	if len(args) > 0 {
+		n, err := r.Eval(strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+		fmt.Println(n)
+		return nil
+	}
+
+	// 只有标准输入为终端时才输出提示符，这样可以通过管道批量计算
+	prompt := ""
+	if fi, err := os.Stdin.Stat(); err == nil && fi.Mode()&os.ModeCharDevice != 0 {
+		prompt = "calc> "
+		fmt.Println("type :help for help, :quit to exit")
+	}
+	return r.REPL(os.Stdin, os.Stdout, prompt)
+}
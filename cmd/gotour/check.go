@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/SamHwang1990/go-tour/chapter"
+	"github.com/SamHwang1990/go-tour/exercise"
+)
+
+// cmdCheck 检查练习，未指定练习时列出所有练习
+func cmdCheck(t *tour, args []string) error {
+	exercises, err := exercise.DiscoverAll(t.chapters)
+	if err != nil {
+		return err
+	}
+
+	switch len(args) {
+	case 0:
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		for _, e := range exercises {
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", e.Name, e.Chapter, e.Title)
+		}
+		return w.Flush()
+	case 1:
+	default:
+		return errors.New("usage: gotour check [exercise]")
+	}
+
+	e, err := exercise.Find(t.chapters, exercises, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "==> %s: %s\n", e.ID(), e.Title)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	r, err := e.Check(ctx)
+	if err != nil {
+		return err
+	}
+	if r.Passed {
+		fmt.Printf("PASS  %s\n", e.ID())
+		return nil
+	}
+
+	fmt.Print(r.Output)
+	fmt.Printf("FAIL  %s\n", e.ID())
+
+	c, err := t.find(e.Chapter)
+	if err != nil {
+		return err
+	}
+	if err := t.printHints(c, e); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("exercise %s failed", e.Name)
+}
+
+// printHints 输出练习相关的课程小节以及 demo 函数
+func (t *tour) printHints(c chapter.Chapter, e exercise.Exercise) error {
+	l, err := t.lesson(c)
+	if err != nil {
+		return err
+	}
+	sections := e.HintSections(l)
+	if len(sections) == 0 && len(e.Demos) == 0 {
+		return nil
+	}
+
+	fmt.Println("\nhints:")
+	for _, s := range sections {
+		fmt.Printf("\n  %s (%s:%d)\n", s.Title, c.Name()+"/"+c.DocFile, s.Line)
+		for _, b := range s.Blocks {
+			text := b.Text
+			switch {
+			case b.Spec != nil:
+				text = b.Spec.Source
+			case b.Example != nil:
+				text = b.Example.Code
+			}
+			for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+	}
+	for _, d := range e.Demos {
+		fmt.Printf("\n  see: gotour run %02d %s\n", c.Number, d)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/SamHwang1990/go-tour/consteval"
+	"github.com/SamHwang1990/go-tour/lesson"
+)
+
+// cmdConsts 展开 const 声明中的隐式重复以及 iota，参数可以是章节、go 文件，或者 `-`（从标准输入读取代码片段），
+// 参数为章节时，同时计算章节源码以及 doc comment 代码块中的 const 声明
+func cmdConsts(t *tour, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: gotour consts <chapter|file|->")
+	}
+
+	var failed bool
+	eval := func(name string, src []byte, firstLine int) error {
+		blocks, err := consteval.Source(name, src, firstLine)
+		if err != nil {
+			return err
+		}
+		if len(blocks) == 0 {
+			return nil
+		}
+		if err := consteval.Write(os.Stdout, name, blocks); err != nil {
+			return err
+		}
+		fmt.Println()
+		for _, b := range blocks {
+			for _, s := range b.Specs {
+				failed = failed || !s.OK()
+			}
+		}
+		return nil
+	}
+
+	switch c, err := t.find(args[0]); {
+	case args[0] == "-":
+		src, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		if err := eval("stdin", src, 1); err != nil {
+			return err
+		}
+
+	case err == nil:
+		l := lesson.Load(c)
+		for _, e := range l.Examples() {
+			if e.Lang != "go" || e.Has("sketch") || !strings.Contains(e.Code, "const") {
+				continue
+			}
+			if err := eval(c.Name()+"/"+c.DocFile, []byte(e.Code), e.Line); err != nil {
+				return err
+			}
+		}
+		files, err := filepath.Glob(filepath.Join(c.Dir, "*.go"))
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			if strings.HasSuffix(file, "_test.go") {
+				continue
+			}
+			src, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			if err := eval(c.Name()+"/"+filepath.Base(file), src, 1); err != nil {
+				return err
+			}
+		}
+
+	default:
+		src, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		if err := eval(args[0], src, 1); err != nil {
+			return err
+		}
+	}
+
+	if failed {
+		return errors.New("some constants do not match the values claimed in their comments")
+	}
+	return nil
+}
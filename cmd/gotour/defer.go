@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+
+	"github.com/SamHwang1990/go-tour/defertrace"
+)
+
+// cmdDefer 改写章节（或者目录）中的函数并运行，输出 defer 调用入栈、出栈的时间线，
+// func 为运行的入口，默认为 main 函数
+func cmdDefer(t *tour, args []string) error {
+	fs := flag.NewFlagSet("defer", flag.ContinueOnError)
+	trace := fs.String("trace", "", "comma-separated functions to trace (default: every function with a defer statement)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		return errors.New("usage: gotour defer [-trace func,...] <chapter|dir|import path> [func]")
+	}
+
+	dir, err := t.packageDir(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	var funcs []string
+	if *trace != "" {
+		funcs = strings.Split(*trace, ",")
+	}
+
+	ws, err := defertrace.Workspace(dir, fs.Arg(1), funcs)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(ws)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	cmd := exec.CommandContext(ctx, "go", "run", ".")
+	cmd.Dir = ws
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/SamHwang1990/go-tour/chapter"
+	"github.com/SamHwang1990/go-tour/lesson"
+)
+
+// cmdExport 将章节的 doc comment 导出为 JSON 或 Markdown，未指定章节时导出所有章节
+func cmdExport(t *tour, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", "json", "output format: json or markdown")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	chapters := t.chapters
+	switch fs.NArg() {
+	case 0:
+	case 1:
+		c, err := t.find(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		chapters = []chapter.Chapter{c}
+	default:
+		return errors.New("usage: gotour export [-format json|markdown] [chapter]")
+	}
+
+	var lessons []*lesson.Lesson
+	for _, c := range chapters {
+		l, err := t.lesson(c)
+		if err != nil {
+			return err
+		}
+		lessons = append(lessons, l)
+	}
+
+	switch *format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		if len(lessons) == 1 {
+			return enc.Encode(lessons[0])
+		}
+		return enc.Encode(lessons)
+
+	case "markdown", "md":
+		for i, l := range lessons {
+			if i > 0 {
+				fmt.Println("\n---")
+				fmt.Println()
+			}
+			if err := l.WriteMarkdown(os.Stdout); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+}
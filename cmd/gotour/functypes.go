@@ -0,0 +1,24 @@
+package main
+
+import (
+	"errors"
+	"os"
+
+	"github.com/SamHwang1990/go-tour/functype"
+)
+
+// cmdFuncTypes 按函数类型对 package 中的函数分组，并报告可以赋值给各个命名函数类型的函数
+func cmdFuncTypes(t *tour, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: gotour functypes <chapter|dir|import path>")
+	}
+	dir, err := t.packageDir(args[0])
+	if err != nil {
+		return err
+	}
+	p, err := functype.Load(dir)
+	if err != nil {
+		return err
+	}
+	return p.Write(os.Stdout)
+}
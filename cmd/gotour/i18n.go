@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/SamHwang1990/go-tour/chapter"
+	"github.com/SamHwang1990/go-tour/lesson"
+	"github.com/SamHwang1990/go-tour/lesson/i18n"
+)
+
+// cmdI18n 管理翻译目录：sync 根据 doc comment 更新翻译目录，status 列出翻译进度，
+// 未指定章节时处理所有章节，未指定 -lang 时处理所有翻译语言
+func cmdI18n(t *tour, args []string) error {
+	const usage = "usage: gotour i18n sync|status [-lang lang] [chapter...]"
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	fs := flag.NewFlagSet("i18n "+args[0], flag.ContinueOnError)
+	lang := fs.String("lang", "", "translation language (default: all of "+strings.Join(i18n.Languages, ", ")+")")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	langs := i18n.Languages
+	if *lang != "" {
+		if !i18n.Valid(*lang) || *lang == i18n.Source || *lang == i18n.Both {
+			return fmt.Errorf("unknown translation language %q", *lang)
+		}
+		langs = []string{*lang}
+	}
+
+	chapters := t.chapters
+	if fs.NArg() > 0 {
+		chapters = nil
+		for _, key := range fs.Args() {
+			c, err := t.find(key)
+			if err != nil {
+				return err
+			}
+			chapters = append(chapters, c)
+		}
+	}
+
+	switch args[0] {
+	case "sync":
+		return i18nSync(chapters, langs)
+	case "status":
+		return i18nStatus(chapters, langs)
+	default:
+		return errors.New(usage)
+	}
+}
+
+func i18nSync(chapters []chapter.Chapter, langs []string) error {
+	for _, c := range chapters {
+		l := lesson.Load(c)
+		for _, lang := range langs {
+			cat, err := i18n.Load(c, lang)
+			if err != nil {
+				return err
+			}
+			r := i18n.Sync(cat, l)
+			if !r.Changed() {
+				continue
+			}
+			if err := cat.Save(c); err != nil {
+				return err
+			}
+			fmt.Printf("%s: %d added, %d outdated, %d updated, %d removed\n",
+				catalogPath(c, lang), len(r.Added), len(r.Outdated), len(r.Updated), len(r.Removed))
+			for _, id := range r.Outdated {
+				fmt.Printf("  outdated  %s\n", id)
+			}
+		}
+	}
+	return nil
+}
+
+func i18nStatus(chapters []chapter.Chapter, langs []string) error {
+	var total, translated int
+	var unsynced []string
+	for _, c := range chapters {
+		l := lesson.Load(c)
+		for _, lang := range langs {
+			cat, err := i18n.Load(c, lang)
+			if err != nil {
+				return err
+			}
+			cov := i18n.Check(cat, l)
+			total += cov.Total
+			translated += cov.Translated
+			if cov.Unsynced {
+				unsynced = append(unsynced, catalogPath(c, lang))
+			}
+
+			fmt.Printf("%-28s %s  %3d/%-3d translated\n", c.Name(), lang, cov.Translated, cov.Total)
+			for _, e := range cov.Untranslated {
+				state := "untranslated"
+				if e.Translation.Title != "" {
+					state = "partial"
+				}
+				fmt.Printf("  %-12s  %s\n", state, e.ID)
+			}
+			for _, e := range cov.Outdated {
+				fmt.Printf("  %-12s  %s\n", "outdated", e.ID)
+			}
+		}
+	}
+
+	fmt.Printf("\n%d/%d sections translated\n", translated, total)
+	if len(unsynced) > 0 {
+		fmt.Printf("out of sync with doc comments, run `gotour i18n sync`:\n")
+		for _, path := range unsynced {
+			fmt.Printf("  %s\n", path)
+		}
+	}
+	return nil
+}
+
+// catalogPath 返回翻译目录相对于 go-tour 根目录的路径，比如 `07-slices/i18n/en.json`
+func catalogPath(c chapter.Chapter, lang string) string {
+	return filepath.Join(c.Name(), "i18n", lang+".json")
+}
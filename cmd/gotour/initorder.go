@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/build"
+	"os"
+
+	"github.com/SamHwang1990/go-tour/initorder"
+)
+
+// cmdInitOrder 输出 package 的初始化顺序，参数可以是章节、目录或者 import path
+func cmdInitOrder(t *tour, args []string) error {
+	fs := flag.NewFlagSet("initorder", flag.ContinueOnError)
+	std := fs.Bool("std", false, "expand standard library packages")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("usage: gotour initorder [-std] <chapter|dir|import path>")
+	}
+
+	dir, err := t.packageDir(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	prog, err := initorder.Load(dir)
+	if err != nil {
+		return err
+	}
+	if err := prog.Write(os.Stdout, *std); err != nil {
+		return err
+	}
+
+	for _, p := range prog.All {
+		if len(p.Cycles) > 0 {
+			return fmt.Errorf("initialization cycle in %s", p.Path)
+		}
+	}
+	return nil
+}
+
+// packageDir 依次将 key 作为章节、目录、import path 查找 package 所在目录
+func (t *tour) packageDir(key string) (string, error) {
+	if c, err := t.find(key); err == nil {
+		return c.Dir, nil
+	}
+	if fi, err := os.Stat(key); err == nil && fi.IsDir() {
+		return key, nil
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	bp, err := build.Import(key, wd, build.FindOnly)
+	if err != nil {
+		return "", fmt.Errorf("%s is not a chapter, directory or package: %v", key, err)
+	}
+	return bp.Dir, nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"os"
+
+	"github.com/SamHwang1990/go-tour/chapter"
+	"github.com/SamHwang1990/go-tour/lesson"
+	"github.com/SamHwang1990/go-tour/lesson/i18n"
+)
+
+// defaultLang 返回 -lang 的默认值：环境变量 GOTOUR_LANG，未设置时为原文
+func defaultLang() string {
+	if lang := os.Getenv("GOTOUR_LANG"); lang != "" {
+		return lang
+	}
+	return i18n.Source
+}
+
+// lesson 按 -lang 加载章节课程
+func (t *tour) lesson(c chapter.Chapter) (*lesson.Lesson, error) {
+	return i18n.LoadLesson(c, t.lang)
+}
+
+// title 返回章节在 -lang 下的标题，翻译目录读取失败时返回原文标题
+func (t *tour) title(c chapter.Chapter) string {
+	if t.lang == i18n.Source {
+		return c.Title
+	}
+	l, err := t.lesson(c)
+	if err != nil {
+		return c.Title
+	}
+	return l.Title
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+func cmdList(t *tour, args []string) error {
+	switch len(args) {
+	case 0:
+		current, _ := loadPosition()
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		for _, c := range t.chapters {
+			mark := " "
+			if c.Name() == current {
+				mark = "*"
+			}
+			fmt.Fprintf(w, "%s %02d\t%s\t%d demos\t%s\n", mark, c.Number, c.Slug, len(c.Demos), t.title(c))
+		}
+		return w.Flush()
+
+	case 1:
+		c, err := t.find(args[0])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s: %s\n", c.Name(), t.title(c))
+		for _, d := range c.Demos {
+			fmt.Printf("  %s\t%s:%d\n", d.Name, d.File, d.Line)
+		}
+		return nil
+
+	default:
+		return errors.New("usage: gotour list [chapter]")
+	}
+}
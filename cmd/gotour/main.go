@@ -0,0 +1,160 @@
+/*
+gotour 是 go-tour 的命令行入口，可以在仓库任意位置浏览、运行各个章节
+
+	用法：
+		gotour [-root dir] [-lang zh|en|both] <command> [arguments]
+
+	命令：
+		list [chapter]        列出所有章节及标题；指定章节时，列出该章节的 demo 函数
+		run <chapter> [demo]  运行章节的 main 函数，或只运行指定的 demo 函数
+		next                  运行下一个章节
+		prev                  运行上一个章节
+		export [-format json|markdown] [chapter]
+		                      将章节的 doc comment 导出为结构化的课程数据
+		verify [-v] [chapter...]
+		                      检查 doc comment 中的代码块能否编译
+		check [exercise]      运行练习的测试；未指定练习时，列出所有练习
+		serve [-http addr] [-timeout d] [-max-output n]
+		                      启动本地 HTTP 服务，在浏览器中阅读课程并运行 demo
+		i18n sync|status [-lang lang] [chapter...]
+		                      同步翻译目录，或者列出还没有翻译的小节
+		initorder [-std] <chapter|dir|import path>
+		                      输出 package 的初始化顺序：global variables、init 函数以及 import 树，并报告初始化循环
+		consts <chapter|file|->
+		                      展开 const 声明中的隐式重复以及 iota，给出每个常量的表达式、值以及类型
+		utf8 [-q] [string]    逐个字符地展示字符串的 utf-8 编码以及等价的字面量写法，-q 表示参数为字符串字面量的内容，比如 "\xff"
+		defer [-trace func,...] <chapter|dir|import path> [func]
+		                      改写并运行 package，输出 defer 调用入栈、出栈的时间线，func 为运行的入口，默认为 main
+		calc [expression]     计算整数表达式，未指定表达式时进入 REPL，参考 04-functions/calc
+		functypes <chapter|dir|import path>
+		                      按函数类型对函数、方法值以及函数字面量分组，报告可以赋值给各个命名函数类型的函数以及不能赋值的原因
+		switch [-expr] [-reverse] [-func f,...] [-w] <chapter|file|->
+		                      将 type switch（-expr 时为 expression switch，包括 fallthrough）改写为等价的 if-else，-reverse 时反过来改写
+		range [-trials n] [-seed n] [-timeout d] [scenario...]
+		                      多次试验 map、channel 的 range 规则并统计观察到的结果，参考 05-flow-control-statements/rangesim
+		bench [-benchtime d] arrays [op...]
+		                      测量不同大小的数组在赋值、传值、传指针以及 range 时复制的开销，并给出 -gcflags=-m 中编译器的决定
+
+	-lang 选择 list、run、export、check、serve 显示课程内容所用的语言：
+		zh 为原文，en 为英文译文（没有译文的部分显示原文），both 为中英文对照，
+		默认取环境变量 GOTOUR_LANG，未设置时为 zh
+
+	章节参数支持 "07"、"7"、"slices"、"07-slices" 几种写法，举例：
+		```
+			gotour run 07
+			gotour run 07 operationAppend
+			gotour run structs promotedFields
+		```
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/SamHwang1990/go-tour/chapter"
+	"github.com/SamHwang1990/go-tour/lesson/i18n"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(tour *tour, args []string) error
+}
+
+var commands = []command{
+	{"list", "list [chapter]", cmdList},
+	{"run", "run <chapter> [demo]", cmdRun},
+	{"next", "next", cmdNext},
+	{"prev", "prev", cmdPrev},
+	{"export", "export [-format json|markdown] [chapter]", cmdExport},
+	{"verify", "verify [-v] [chapter...]", cmdVerify},
+	{"check", "check [exercise]", cmdCheck},
+	{"serve", "serve [-http addr] [-timeout d] [-max-output n]", cmdServe},
+	{"i18n", "i18n sync|status [-lang lang] [chapter...]", cmdI18n},
+	{"initorder", "initorder [-std] <chapter|dir|import path>", cmdInitOrder},
+	{"consts", "consts <chapter|file|->", cmdConsts},
+	{"utf8", "utf8 [-q] [string]", cmdUTF8},
+	{"defer", "defer [-trace func,...] <chapter|dir|import path> [func]", cmdDefer},
+	{"calc", "calc [expression]", cmdCalc},
+	{"functypes", "functypes <chapter|dir|import path>", cmdFuncTypes},
+	{"switch", "switch [-expr] [-reverse] [-func f,...] [-w] <chapter|file|->", cmdSwitch},
+	{"range", "range [-trials n] [-seed n] [-timeout d] [scenario...]", cmdRange},
+	{"bench", "bench [-benchtime d] arrays [op...]", cmdBench},
+}
+
+// tour 为命令执行时的上下文
+type tour struct {
+	root     string
+	lang     string // zh、en 或 both
+	chapters []chapter.Chapter
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: gotour [-root dir] [-lang zh|en|both] <command> [arguments]")
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %s\n", c.usage)
+	}
+	fmt.Fprintln(os.Stderr, "\nflags:")
+	flag.PrintDefaults()
+}
+
+func main() {
+	root := flag.String("root", "", "go-tour root directory (default: search from the current directory, then GOPATH)")
+	lang := flag.String("lang", defaultLang(), "lesson language: zh, en or both (default: $GOTOUR_LANG, then zh)")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	name, args := flag.Arg(0), flag.Args()[1:]
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+		t, err := newTour(*root, *lang)
+		if err == nil {
+			err = c.run(t, args)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "gotour:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "gotour: unknown command %q\n\n", name)
+	usage()
+	os.Exit(2)
+}
+
+func newTour(root, lang string) (*tour, error) {
+	if !i18n.Valid(lang) {
+		return nil, fmt.Errorf("unknown language %q, want zh, en or both", lang)
+	}
+
+	if root == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		if root, err = chapter.FindRoot(wd); err != nil {
+			return nil, err
+		}
+	}
+
+	chapters, err := chapter.Discover(root)
+	if err != nil {
+		return nil, err
+	}
+	if len(chapters) == 0 {
+		return nil, fmt.Errorf("no chapters found in %s", root)
+	}
+
+	return &tour{root: root, lang: lang, chapters: chapters}, nil
+}
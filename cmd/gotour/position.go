@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// positionFile 返回记录当前章节的文件路径，位于用户配置目录中，比如 `~/.config/gotour/position`
+func positionFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gotour", "position"), nil
+}
+
+// loadPosition 返回最近一次运行的章节名，若从未运行过，返回空字符串
+func loadPosition() (string, error) {
+	file, err := positionFile()
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	return strings.TrimSpace(string(data)), err
+}
+
+func savePosition(name string) error {
+	file, err := positionFile()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, []byte(name+"\n"), 0644)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/SamHwang1990/go-tour/05-flow-control-statements/rangesim"
+)
+
+// cmdRange 运行 range 语义的试验并输出每种结果出现的次数，未指定场景时运行所有场景
+func cmdRange(t *tour, args []string) error {
+	c := rangesim.DefaultConfig()
+	fs := flag.NewFlagSet("range", flag.ContinueOnError)
+	fs.IntVar(&c.Trials, "trials", c.Trials, "number of trials per scenario")
+	fs.Int64Var(&c.Seed, "seed", c.Seed, "seed for the trial parameters")
+	fs.DurationVar(&c.Timeout, "timeout", c.Timeout, "treat a range that has not finished after this long as blocked")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if c.Trials <= 0 {
+		return fmt.Errorf("invalid -trials %d", c.Trials)
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("invalid -timeout %v", c.Timeout)
+	}
+
+	scenarios := rangesim.Scenarios()
+	if fs.NArg() > 0 {
+		scenarios = nil
+		for _, name := range fs.Args() {
+			s, err := rangesim.Lookup(name)
+			if err != nil {
+				return err
+			}
+			scenarios = append(scenarios, s)
+		}
+	}
+
+	for i, s := range scenarios {
+		if i > 0 {
+			fmt.Println()
+		}
+		if err := rangesim.Run(s, c).Write(os.Stdout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
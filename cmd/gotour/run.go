@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/SamHwang1990/go-tour/chapter"
+)
+
+func cmdRun(t *tour, args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return errors.New("usage: gotour run <chapter> [demo]")
+	}
+
+	c, err := t.find(args[0])
+	if err != nil {
+		return err
+	}
+
+	demo := ""
+	if len(args) == 2 {
+		demo = args[1]
+	}
+
+	return t.run(c, demo)
+}
+
+func cmdNext(t *tour, args []string) error {
+	return t.step(1)
+}
+
+func cmdPrev(t *tour, args []string) error {
+	return t.step(-1)
+}
+
+func (t *tour) find(key string) (chapter.Chapter, error) {
+	return chapter.Find(t.chapters, key)
+}
+
+// step 从当前位置前进或后退 delta 个章节并运行，
+// 若还没有运行过任何章节，next 从第一个章节开始，prev 从最后一个章节开始
+func (t *tour) step(delta int) error {
+	current, err := loadPosition()
+	if err != nil {
+		return err
+	}
+
+	index := -1
+	for i, c := range t.chapters {
+		if c.Name() == current {
+			index = i
+			break
+		}
+	}
+
+	switch {
+	case index < 0 && delta > 0:
+		index = 0
+	case index < 0:
+		index = len(t.chapters) - 1
+	default:
+		index += delta
+	}
+
+	if index < 0 {
+		return errors.New("already at the first chapter")
+	}
+	if index >= len(t.chapters) {
+		return errors.New("already at the last chapter")
+	}
+
+	return t.run(t.chapters[index], "")
+}
+
+// run 运行章节并记录当前位置，供 next、prev 使用
+func (t *tour) run(c chapter.Chapter, demo string) error {
+	if err := savePosition(c.Name()); err != nil {
+		return err
+	}
+
+	header := c.Name() + ": " + t.title(c)
+	if demo != "" {
+		header += " / " + demo
+	}
+	fmt.Fprintf(os.Stderr, "==> %s\n", header)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	return chapter.Run(ctx, c, demo, os.Stdout, os.Stderr)
+}
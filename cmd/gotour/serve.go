@@ -0,0 +1,37 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/SamHwang1990/go-tour/web"
+)
+
+// cmdServe 启动本地 HTTP 服务，在浏览器中阅读课程并运行 demo
+func cmdServe(t *tour, args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("http", "localhost:3999", "HTTP listen address")
+	timeout := fs.Duration("timeout", 0, "run timeout for each demo (default 10s)")
+	maxOutput := fs.Int("max-output", 0, "output limit in bytes for each demo (default 64KB)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errors.New("usage: gotour serve [-http addr] [-timeout d] [-max-output n]")
+	}
+
+	s := web.New(t.chapters)
+	s.Lang = t.lang
+	if *timeout > 0 {
+		s.Timeout = *timeout
+	}
+	if *maxOutput > 0 {
+		s.MaxOutput = *maxOutput
+	}
+
+	fmt.Fprintf(os.Stderr, "serving %s at http://%s\n", t.root, *addr)
+	return http.ListenAndServe(*addr, s)
+}
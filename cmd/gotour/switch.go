@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/SamHwang1990/go-tour/switchconv"
+)
+
+// cmdSwitch 将 switch 语句改写为 if-else，或者反过来，参数可以是章节、go 文件，或者 `-`（从标准输入读取源码）；
+// 默认将改写后的源码输出到标准输出，-w 表示写回文件
+func cmdSwitch(t *tour, args []string) error {
+	fs := flag.NewFlagSet("switch", flag.ContinueOnError)
+	expr := fs.Bool("expr", false, "rewrite expression switches (including fallthrough) instead of type switches")
+	reverse := fs.Bool("reverse", false, "rewrite if-else chains back into switch statements")
+	funcs := fs.String("func", "", "comma-separated functions to rewrite, methods as T.Method")
+	write := fs.Bool("w", false, "write the result back to the files")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("usage: gotour switch [-expr] [-reverse] [-func f,...] [-w] <chapter|file|->")
+	}
+
+	opts := switchconv.Options{Reverse: *reverse}
+	if *expr {
+		opts.Mode = switchconv.ExprSwitch
+	}
+	if *funcs != "" {
+		opts.Funcs = strings.Split(*funcs, ",")
+	}
+
+	var files []string
+	switch c, err := t.find(fs.Arg(0)); {
+	case fs.Arg(0) == "-":
+		if *write {
+			return errors.New("cannot use -w with standard input")
+		}
+		src, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		out, n, err := switchconv.Rewrite("stdin.go", src, opts)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "rewrote %d statements\n", n)
+		_, err = os.Stdout.Write(out)
+		return err
+	case err == nil:
+		matches, err := filepath.Glob(filepath.Join(c.Dir, "*.go"))
+		if err != nil {
+			return err
+		}
+		for _, file := range matches {
+			if !strings.HasSuffix(file, "_test.go") {
+				files = append(files, file)
+			}
+		}
+	default:
+		files = []string{fs.Arg(0)}
+	}
+
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		// 指定了函数时，只改写包含这些函数的文件
+		out, n, err := switchconv.Rewrite(file, src, opts)
+		if err != nil && !(len(files) > 1 && opts.Funcs != nil && strings.Contains(err.Error(), "not found")) {
+			return err
+		}
+		if n == 0 {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "%s: rewrote %d statements\n", file, n)
+		if *write {
+			if err := os.WriteFile(file, out, 0644); err != nil {
+				return err
+			}
+			continue
+		}
+		if len(files) > 1 {
+			fmt.Printf("// %s\n", filepath.Base(file))
+		}
+		if _, err := os.Stdout.Write(out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
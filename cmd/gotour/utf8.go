@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/SamHwang1990/go-tour/03-strings/utf8inspect"
+)
+
+// cmdUTF8 逐个字符地展示字符串的 utf-8 编码，未指定字符串时从标准输入读取
+func cmdUTF8(t *tour, args []string) error {
+	fs := flag.NewFlagSet("utf8", flag.ContinueOnError)
+	quoted := fs.Bool("q", false, `interpret the argument as the body of a Go string literal, e.g. "\xff中"`)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var s string
+	switch fs.NArg() {
+	case 0:
+		b, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		s = strings.TrimSuffix(string(b), "\n")
+	case 1:
+		s = fs.Arg(0)
+	default:
+		return errors.New("usage: gotour utf8 [-q] [string]")
+	}
+
+	if *quoted {
+		unquoted, err := strconv.Unquote(`"` + s + `"`)
+		if err != nil {
+			return errors.New("invalid string literal: " + strconv.Quote(s))
+		}
+		s = unquoted
+	}
+	return utf8inspect.Write(os.Stdout, s)
+}
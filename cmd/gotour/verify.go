@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/SamHwang1990/go-tour/lesson"
+	"github.com/SamHwang1990/go-tour/lesson/verify"
+)
+
+// cmdVerify 检查章节 doc comment 中的代码块，未指定章节时检查所有章节
+func cmdVerify(t *tour, args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	verbose := fs.Bool("v", false, "print every example, including passed and skipped ones")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	chapters := t.chapters
+	if fs.NArg() > 0 {
+		chapters = nil
+		for _, key := range fs.Args() {
+			c, err := t.find(key)
+			if err != nil {
+				return err
+			}
+			chapters = append(chapters, c)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	v := verify.New()
+	var checked, skipped, failed int
+	for _, c := range chapters {
+		for _, r := range v.Lesson(ctx, lesson.Load(c)) {
+			switch {
+			case r.Skipped != "":
+				skipped++
+				if *verbose {
+					fmt.Printf("skip  %s:%d (%s)\n", r.File, r.Line, r.Skipped)
+				}
+			case !r.OK():
+				failed++
+				fmt.Printf("FAIL  %s:%d\n", r.File, r.Line)
+				for _, p := range r.Problems {
+					fmt.Printf("      %s\n", p)
+				}
+			default:
+				checked++
+				if *verbose {
+					fmt.Printf("ok    %s:%d (%d claims, %d errors)\n", r.File, r.Line, r.Claims, r.Errors)
+				}
+			}
+		}
+	}
+
+	fmt.Printf("%d ok, %d skipped, %d failed\n", checked, skipped, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d examples failed", failed)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+/*
+tourvet 是 go vet 的 vettool，运行 go-tour 中的 analyzer
+
+	用法：
+		```
+			go build -o /tmp/tourvet ./cmd/tourvet
+			go vet -vettool=/tmp/tourvet ./05-flow-control-statements
+		```
+
+	analyzer：
+		fallthroughdefault、dupcase、caseconv  switch 语句中隐藏的控制流，参考 switchlint
+		appendalias、subsliceretain           append 原地改写仍在使用的底层数组、sub-slice 保留整个底层数组，参考 slicelint
+*/
+package main
+
+import (
+	"slices"
+
+	"golang.org/x/tools/go/analysis/unitchecker"
+
+	"github.com/SamHwang1990/go-tour/slicelint"
+	"github.com/SamHwang1990/go-tour/switchlint"
+)
+
+func main() {
+	unitchecker.Main(slices.Concat(switchlint.Analyzers, slicelint.Analyzers)...)
+}
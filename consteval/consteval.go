@@ -0,0 +1,196 @@
+/*
+Package consteval 展开 const 声明中的隐式重复（implicit repetition）以及 iota，参考 02-variables 中的 Constant 变量
+
+	对于 const 声明中的每一行（ConstSpec），给出：
+		* 该行实际使用的表达式：省略表达式的行，使用之前最近的非空表达式（以及类型）
+		* 该行 iota 的值，即该行在括号声明中的索引，从 0 开始
+		* 每个常量的值（go/constant）以及类型，比如 `untyped int`、`int`
+
+	支持完整的 go 文件，也支持代码片段：
+		* 没有 package 声明的代码片段，先尝试作为顶级声明，再尝试放到函数体中
+		* 代码片段中的类型检查错误不影响其他常量的计算，记录在 Block.Errors 中
+
+	行尾注释中的 ` name == value `、` iota == n ` 为结果声明，与计算结果不一致时记录在 Const.Want、Spec.WantIota 中
+*/
+package consteval
+
+import (
+	"errors"
+	"go/ast"
+	"go/constant"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Block 为一个 const 声明，比如 `const ( ... )` 或者 `const a, b = 1, 2`
+type Block struct {
+	Line   int
+	Paren  bool // 括号声明
+	Specs  []Spec
+	Errors []string
+}
+
+// Spec 为 const 声明中的一行
+type Spec struct {
+	Line     int
+	Source   string // 该行的原始代码，比如 `_, _`
+	Implicit bool   // 省略了表达式，使用之前最近的非空表达式
+	Iota     int
+	WantIota *int // 行尾注释中声明的 iota 值
+	Consts   []Const
+}
+
+// Const 为一个常量的计算结果
+type Const struct {
+	Name  string
+	Expr  string // 实际使用的表达式，声明了类型时为 `Type(expr)`
+	Value string // 常量的值，计算失败时为空
+	Type  string
+	Typed bool
+	Want  string // 行尾注释中声明的值，为空表示没有声明
+}
+
+// OK 表示常量的值与声明的值一致，或没有声明
+func (c Const) OK() bool {
+	return c.Want == "" || c.Want == c.Value
+}
+
+// OK 表示该行的 iota 以及所有常量都与声明一致
+func (s Spec) OK() bool {
+	if s.WantIota != nil && *s.WantIota != s.Iota {
+		return false
+	}
+	for _, c := range s.Consts {
+		if !c.OK() {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	iotaClaimPattern  = regexp.MustCompile(`\biota\s*==\s*(\d+)`)
+	valueClaimPattern = regexp.MustCompile(`\b([A-Za-z_]\w*)\s*==\s*(-?\d+(?:\.\d+)?|true|false|"[^"]*")`)
+)
+
+// 代码片段依次尝试的包装方式，%s 为代码片段
+var wrappers = []string{
+	"package p\n%s",
+	"package p\nfunc _() {\n%s\n}",
+}
+
+// Source 计算源码中所有 const 声明，filename 以及 firstLine 用于确定行号：firstLine 为 src 第一行所在的行号
+func Source(filename string, src []byte, firstLine int) ([]Block, error) {
+	fset := token.NewFileSet()
+	text := string(src)
+	f, err := parser.ParseFile(fset, filename, text, parser.ParseComments)
+	offset := firstLine - 1
+	if err != nil && !strings.HasPrefix(strings.TrimSpace(text), "package ") {
+		for _, w := range wrappers {
+			prefix := w[:strings.Index(w, "%s")]
+			wrapped := strings.Replace(w, "%s", text, 1)
+			if f, err = parser.ParseFile(fset, filename, wrapped, parser.ParseComments); err == nil {
+				text = wrapped
+				offset -= strings.Count(prefix, "\n")
+				break
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var typeErrors []types.Error
+	info := &types.Info{Defs: map[*ast.Ident]types.Object{}}
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error: func(err error) {
+			var terr types.Error
+			if errors.As(err, &terr) && !(terr.Soft && strings.Contains(terr.Msg, "not used")) {
+				typeErrors = append(typeErrors, terr)
+			}
+		},
+	}
+	conf.Check(f.Name.Name, fset, []*ast.File{f}, info)
+
+	line := func(pos token.Pos) int { return fset.Position(pos).Line + offset }
+
+	var blocks []Block
+	ast.Inspect(f, func(n ast.Node) bool {
+		decl, ok := n.(*ast.GenDecl)
+		if !ok || decl.Tok != token.CONST {
+			return true
+		}
+
+		b := Block{Line: line(decl.Pos()), Paren: decl.Lparen.IsValid()}
+		var last *ast.ValueSpec // 最近的非空表达式
+		for i, spec := range decl.Specs {
+			vs := spec.(*ast.ValueSpec)
+			s := Spec{
+				Line:     line(vs.Pos()),
+				Source:   text[fset.Position(vs.Pos()).Offset:fset.Position(vs.End()).Offset],
+				Implicit: len(vs.Values) == 0,
+				Iota:     i,
+			}
+			if !s.Implicit {
+				last = vs
+			}
+
+			claims := ""
+			if vs.Comment != nil {
+				claims = vs.Comment.Text()
+			}
+			if m := iotaClaimPattern.FindStringSubmatch(claims); m != nil {
+				n, _ := strconv.Atoi(m[1])
+				s.WantIota = &n
+			}
+			want := map[string]string{}
+			for _, m := range valueClaimPattern.FindAllStringSubmatch(claims, -1) {
+				if m[1] != "iota" {
+					want[m[1]] = m[2]
+				}
+			}
+
+			for j, name := range vs.Names {
+				c := Const{Name: name.Name, Want: want[name.Name]}
+				if last != nil && j < len(last.Values) {
+					c.Expr = types.ExprString(last.Values[j])
+					if last.Type != nil {
+						c.Expr = types.ExprString(last.Type) + "(" + c.Expr + ")"
+					}
+				}
+				if obj, ok := info.Defs[name].(*types.Const); ok {
+					c.Value = format(obj.Val())
+					c.Type = obj.Type().String()
+					if basic, ok := obj.Type().(*types.Basic); !ok || basic.Info()&types.IsUntyped == 0 {
+						c.Typed = true
+					}
+				}
+				s.Consts = append(s.Consts, c)
+			}
+			b.Specs = append(b.Specs, s)
+		}
+
+		for _, terr := range typeErrors {
+			if terr.Pos >= decl.Pos() && terr.Pos < decl.End() {
+				b.Errors = append(b.Errors, strconv.Itoa(line(terr.Pos))+": "+terr.Msg)
+			}
+		}
+		blocks = append(blocks, b)
+		return false
+	})
+	return blocks, nil
+}
+
+// format 返回常量值的 go 字面量形式，整数以及布尔值是精确的，浮点数为近似值
+func format(v constant.Value) string {
+	if v.Kind() == constant.Unknown {
+		return ""
+	}
+	return v.String()
+}
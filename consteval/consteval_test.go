@@ -0,0 +1,102 @@
+package consteval
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/SamHwang1990/go-tour/chapter"
+	"github.com/SamHwang1990/go-tour/lesson"
+)
+
+// TestChapter 计算 02-variables 源码以及 doc comment 中所有的 const 声明，并检查注释中的结果声明
+func TestChapter(t *testing.T) {
+	c, err := chapter.Load("../02-variables")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var blocks []Block
+	for _, e := range lesson.Load(c).Examples() {
+		if e.Lang == "go" && !e.Has("sketch") && strings.Contains(e.Code, "const") {
+			b, err := Source(c.DocFile, []byte(e.Code), e.Line)
+			if err != nil {
+				t.Fatalf("%s:%d: %v", c.DocFile, e.Line, err)
+			}
+			blocks = append(blocks, b...)
+		}
+	}
+	src, err := os.ReadFile("../02-variables/variables.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Source("variables.go", src, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks = append(blocks, b...)
+
+	if len(blocks) < 7 {
+		t.Fatalf("found %d const blocks, want at least 7", len(blocks))
+	}
+	claims := 0
+	for _, b := range blocks {
+		for _, e := range b.Errors {
+			t.Errorf("line %d: %s", b.Line, e)
+		}
+		for _, s := range b.Specs {
+			if s.WantIota != nil {
+				claims++
+			}
+			if !s.OK() {
+				t.Errorf("line %d: %+v does not match its comment", s.Line, s)
+			}
+			for _, c := range s.Consts {
+				if c.Value == "" || c.Expr == "" {
+					t.Errorf("line %d: %s was not evaluated", s.Line, c.Name)
+				}
+			}
+		}
+	}
+	if claims == 0 {
+		t.Errorf("no iota claims found in the doc comment")
+	}
+}
+
+func TestSource(t *testing.T) {
+	src := `const (
+	KB int64 = 1 << (10 * (iota + 1))
+	MB
+	_
+	TB // TB == 1099511627776 (iota == 3)
+)
+const greeting, pi = "hi", 3.5 // pi == 3`
+
+	blocks, err := Source("snippet.go", []byte(src), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 2 {
+		t.Fatalf("got %d blocks, want 2", len(blocks))
+	}
+
+	specs := blocks[0].Specs
+	mb := specs[1]
+	if mb.Line != 12 || !mb.Implicit || mb.Iota != 1 {
+		t.Errorf("MB spec = %+v", mb)
+	}
+	if c := mb.Consts[0]; c.Expr != "int64(1 << (10 * (iota + 1)))" || c.Value != "1048576" || c.Type != "int64" || !c.Typed {
+		t.Errorf("MB = %+v", c)
+	}
+	if !specs[3].OK() {
+		t.Errorf("TB claims should hold: %+v", specs[3])
+	}
+
+	consts := blocks[1].Specs[0].Consts
+	if consts[0].Value != `"hi"` || consts[0].Type != "untyped string" || consts[0].Typed {
+		t.Errorf("greeting = %+v", consts[0])
+	}
+	if consts[1].OK() || consts[1].Want != "3" {
+		t.Errorf("pi claim should fail: %+v", consts[1])
+	}
+}
@@ -0,0 +1,45 @@
+package consteval
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Write 以表格的形式输出 const 声明的计算结果，file 为 Block.Line 所在的文件：
+// 省略了表达式的行，表达式后面标记 (implied)；与注释中的结果声明不一致时，标记 (want ...)
+func Write(w io.Writer, file string, blocks []Block) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for i, b := range blocks {
+		if i > 0 {
+			fmt.Fprintln(tw)
+		}
+		fmt.Fprintf(tw, "%s:%d\n", file, b.Line)
+		fmt.Fprintf(tw, "  line\tiota\tname\texpression\tvalue\ttype\n")
+		for _, s := range b.Specs {
+			iota := fmt.Sprint(s.Iota)
+			if s.WantIota != nil && *s.WantIota != s.Iota {
+				iota += fmt.Sprintf(" (want %d)", *s.WantIota)
+			}
+			for j, c := range s.Consts {
+				line := ""
+				if j == 0 {
+					line = fmt.Sprint(s.Line)
+				}
+				expr := c.Expr
+				if s.Implicit {
+					expr += " (implied)"
+				}
+				value := c.Value
+				if !c.OK() {
+					value += fmt.Sprintf(" (want %s)", c.Want)
+				}
+				fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\t%s\n", line, iota, c.Name, expr, value, c.Type)
+			}
+		}
+		for _, err := range b.Errors {
+			fmt.Fprintf(tw, "  error: %s\n", err)
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,176 @@
+package defertrace
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 运行 `go test ./defertrace -update` 重新生成 golden 文件
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// TestInstrument 检查改写后的代码能通过类型检查，并且保持原有的行号
+func TestInstrument(t *testing.T) {
+	files, err := Instrument("testdata/timeline", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	var parsed []*ast.File
+	for _, f := range files {
+		src, err := os.ReadFile(filepath.Join("testdata/timeline", f.Name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := bytes.Count(f.Src, []byte("\n")), bytes.Count(src, []byte("\n")); got != want {
+			t.Errorf("%s: %d lines after instrumenting, want %d", f.Name, got, want)
+		}
+		if !bytes.Contains(f.Src, []byte(ImportPath)) {
+			t.Errorf("%s: %s is not imported", f.Name, ImportPath)
+		}
+
+		af, err := parser.ParseFile(fset, f.Name, f.Src, 0)
+		if err != nil {
+			t.Fatalf("%v\n%s", err, f.Src)
+		}
+		parsed = append(parsed, af)
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("main", fset, parsed, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestInstrumentSelection 检查指定了函数时只跟踪这些函数，其他包含 defer 的函数不受影响
+func TestInstrumentSelection(t *testing.T) {
+	files, err := Instrument("testdata/timeline", []string{"loop", "captured"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var traced []string
+	for _, f := range files {
+		for _, m := range regexp.MustCompile(`\.Enter\("(\w+)"\)`).FindAllSubmatch(f.Src, -1) {
+			traced = append(traced, string(m[1]))
+		}
+	}
+	if !slices.Equal(traced, []string{"captured", "loop"}) {
+		t.Errorf("traced functions = %q, want [captured loop]", traced)
+	}
+}
+
+func TestInstrumentErrors(t *testing.T) {
+	if _, err := Instrument("testdata/timeline", []string{"loop", "missing"}); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("tracing a missing function: err = %v", err)
+	}
+	if _, err := Workspace("testdata/timeline", "show", nil); err == nil || !strings.Contains(err.Error(), "parameters") {
+		t.Errorf("entry with parameters: err = %v", err)
+	}
+}
+
+// TestTracer 按照改写后的形式直接调用 Tracer，检查 panicking、recover 的识别
+func TestTracer(t *testing.T) {
+	var buf bytes.Buffer
+	Output = &buf
+	defer func() { Output = os.Stdout }()
+
+	traced := func() (err error) {
+		tr := Enter("traced")
+		tr.Named("err", &err)
+		defer tr.Exit()
+
+		tr.Defer(1, "func() {...}()", "traced.go:1")
+		defer tr.Done(1)
+		defer func() {
+			if r := recover(); r != nil {
+				err = errors.New("recovered")
+			}
+		}()
+		defer tr.Run(1)
+
+		tr.Defer(2, "f(x)", "traced.go:2", "x", 1)
+		defer tr.Done(2)
+		defer func(int) {}(1)
+		defer tr.Run(2)
+
+		panic("boom")
+	}
+	if err := traced(); err == nil {
+		t.Fatal("panic was not recovered")
+	}
+
+	want := []string{
+		"[defer] → traced",
+		"[defer]   defer #1 func() {...}()  (traced.go:1)",
+		"[defer]       stack: #1",
+		"[defer]   defer #2 f(x)  (traced.go:2)",
+		"[defer]       captured: x = 1",
+		"[defer]       stack: #2(x = 1) #1",
+		"[defer] traced panicking  [err = nil], stack: #2(x = 1) #1",
+		"[defer]   run #2 f(x) (panicking)  [err = nil]",
+		"[defer]   done #2  [err = nil]",
+		"[defer]   run #1 func() {...}() (panicking)  [err = nil]",
+		`[defer]   done #1  [err = error("recovered")]`,
+		`[defer] ← traced (recovered)  [err = error("recovered")]`,
+	}
+	if got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("timeline:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+// TestTimeline 运行改写后的 testdata/timeline，并将输出与 testdata/<entry>.golden 比较
+func TestTimeline(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping timeline tests in short mode: the instrumented package is compiled with go run")
+	}
+
+	for _, entry := range []string{"main", "namedResult"} {
+		t.Run(entry, func(t *testing.T) {
+			ws, err := Workspace("testdata/timeline", entry, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(ws)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+			var stdout, stderr bytes.Buffer
+			cmd := exec.CommandContext(ctx, "go", "run", ".")
+			cmd.Dir = ws
+			cmd.Stdout, cmd.Stderr = &stdout, &stderr
+			if err := cmd.Run(); err != nil {
+				t.Fatalf("%v\n%s", err, stderr.String())
+			}
+
+			golden := filepath.Join("testdata", entry+".golden")
+			if *update {
+				if err := os.WriteFile(golden, stdout.Bytes(), 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := stdout.String(); got != string(want) {
+				t.Errorf("output differs from %s\n--- got\n%s\n--- want\n%s", golden, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,448 @@
+/*
+Package defertrace 改写函数的源码，在运行时输出 defer 调用的时间线，参考 04-functions 中的 `defer` 语句
+
+	时间线包括：
+		* defer 语句执行时：defer 调用入栈，以及此时取得的实参值（实参在 defer 语句执行时就已经取值）
+		* 函数返回或者 panicking 时：defer 调用按 LIFO 顺序出栈、执行
+		* 命名返回值在每一步的值，比如 `return 6` 之后 defer 调用通过 `result *= 7` 修改返回值
+
+	改写方式与 `go tool cover` 类似，直接编辑源码文本，保持原有的行号：
+		```go sketch
+		defer fmt.Println(d, e)
+		```
+	改写为（实际在同一行内）：
+		```go sketch
+		gotourDefer3a0 := d; gotourDefer3a1 := e
+		gotourTrace.Defer(3, "fmt.Println(d, e)", "functions.go:197", "d", gotourDefer3a0, "e", gotourDefer3a1)
+		defer gotourTrace.Done(3)                        // 最先入栈，在 defer 调用之后执行
+		defer fmt.Println(gotourDefer3a0, gotourDefer3a1) // defer 调用本身保持不变，recover 仍然有效
+		defer gotourTrace.Run(3)                         // 最后入栈，在 defer 调用之前执行
+		```
+
+	限制：
+		* 实参会在函数表达式之前取值，比如 `defer getLogger().Print(x)` 中 x 先于 getLogger() 取值
+		* 只改写函数体中直接出现的 defer 语句，函数字面量中的 defer 语句保持不变
+		* 只记录一个全局的调用层数，不适合同时跟踪多个 goroutine
+*/
+package defertrace
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ImportPath 为改写后的代码引用本 package 所用的 import path
+const ImportPath = "github.com/SamHwang1990/go-tour/defertrace"
+
+const (
+	importName  = "gotourDefertrace"  // 改写后的代码引用本 package 所用的名字
+	tracerName  = "gotourTrace"       // 函数中 Tracer 变量的名字
+	renamedMain = "gotourChapterMain" // 入口不是 main 函数时，原 main 函数被重命名后的名字
+	mainFile    = "gotour_main.go"
+)
+
+// File 为改写后的源码文件
+type File struct {
+	Name string
+	Src  []byte
+}
+
+// Instrument 改写 dir 中的 package，跟踪 funcs 中的函数，为空时跟踪所有包含 defer 语句的函数，方法写作 `T.Method`
+func Instrument(dir string, funcs []string) ([]File, error) {
+	return instrument(dir, funcs, "")
+}
+
+// Workspace 在临时目录中生成改写后的 main package，返回临时目录路径，由调用者负责删除：
+//   - entry 为空或者为 main 时，运行 main 函数
+//   - 否则原 main 函数被重命名，新的 main 函数只调用 entry，entry 不能有参数，有返回值时输出返回值
+func Workspace(dir, entry string, funcs []string) (string, error) {
+	files, err := instrument(dir, funcs, entry)
+	if err != nil {
+		return "", err
+	}
+
+	ws, err := os.MkdirTemp("", "gotour-defer-")
+	if err != nil {
+		return "", err
+	}
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(ws, f.Name), f.Src, 0644); err != nil {
+			os.RemoveAll(ws)
+			return "", err
+		}
+	}
+	return ws, nil
+}
+
+// source 为 package 中的一个源码文件
+type source struct {
+	name   string
+	src    []byte
+	ast    *ast.File
+	edits  []edit
+	traced bool // 包含被跟踪的函数，需要 import 本 package
+}
+
+// edit 将 src[start:end] 替换为 text
+type edit struct {
+	start, end int
+	text       string
+}
+
+func instrument(dir string, funcs []string, entry string) ([]File, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*source
+	var astFiles []*ast.File
+	for _, name := range bp.GoFiles {
+		src, err := os.ReadFile(filepath.Join(bp.Dir, name))
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(bp.Dir, name), src, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, &source{name: name, src: src, ast: f})
+		astFiles = append(astFiles, f)
+	}
+
+	info := &types.Info{
+		Types: map[ast.Expr]types.TypeAndValue{},
+		Defs:  map[*ast.Ident]types.Object{},
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check(bp.Name, fset, astFiles, info)
+	if err != nil {
+		return nil, err
+	}
+
+	// want 为 -trace 指定的函数，只读；found 记录其中已经找到的函数
+	want := map[string]bool{}
+	for _, name := range funcs {
+		want[name] = true
+	}
+	found := map[string]bool{}
+
+	var entryDecl *ast.FuncDecl
+	for _, f := range files {
+		for _, decl := range f.ast.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			name := funcName(fn)
+			if fn.Recv == nil && name == entry {
+				entryDecl = fn
+			}
+			if len(want) > 0 && !want[name] || len(want) == 0 && !hasDefer(fn.Body) {
+				continue
+			}
+			found[name] = true
+			w := &rewriter{fset: fset, pkg: pkg, info: info, file: f}
+			w.function(fn, name)
+		}
+	}
+	var missing []string
+	for name := range want {
+		if !found[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("%s: function %s not found", bp.Dir, strings.Join(missing, ", "))
+	}
+
+	var main *File
+	if entry != "" && entry != "main" {
+		if entryDecl == nil {
+			return nil, fmt.Errorf("%s: function %s not found", bp.Dir, entry)
+		}
+		if entryDecl.Type.TypeParams != nil || entryDecl.Type.Params.NumFields() > 0 {
+			return nil, fmt.Errorf("%s: function %s must not have parameters", bp.Dir, entry)
+		}
+		if bp.Name != "main" {
+			return nil, errors.New(bp.Dir + ": not a main package")
+		}
+		for _, f := range files {
+			for _, decl := range f.ast.Decls {
+				if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "main" {
+					start, end := fset.Position(fn.Name.Pos()).Offset, fset.Position(fn.Name.End()).Offset
+					f.edits = append(f.edits, edit{start, end, renamedMain})
+				}
+			}
+		}
+		main = entryMain(entry, info.Defs[entryDecl.Name].Type().(*types.Signature))
+	}
+
+	var out []File
+	for _, f := range files {
+		out = append(out, File{Name: f.name, Src: f.apply(fset)})
+	}
+	if main != nil {
+		out = append(out, *main)
+	}
+	return out, nil
+}
+
+// entryMain 生成只调用 entry 的 main 函数
+func entryMain(entry string, sig *types.Signature) *File {
+	var b strings.Builder
+	b.WriteString("package main\n\n")
+	if sig.Results().Len() > 0 {
+		fmt.Fprintf(&b, "import %s %q\n\nfunc main() {\n\t%s.Returned(%s())\n}\n", importName, ImportPath, importName, entry)
+	} else {
+		fmt.Fprintf(&b, "func main() {\n\t%s()\n}\n", entry)
+	}
+	return &File{Name: mainFile, Src: []byte(b.String())}
+}
+
+// apply 按位置依次应用所有编辑，包含被跟踪的函数时，在 package 声明之后 import 本 package（同一行，保持行号不变）
+func (f *source) apply(fset *token.FileSet) []byte {
+	edits := f.edits
+	if f.traced {
+		end := fset.Position(f.ast.Name.End()).Offset
+		edits = append(edits, edit{end, end, fmt.Sprintf("; import %s %q", importName, ImportPath)})
+	}
+	if len(edits) == 0 {
+		return f.src
+	}
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+
+	var out []byte
+	offset := 0
+	for _, e := range edits {
+		out = append(out, f.src[offset:e.start]...)
+		out = append(out, e.text...)
+		offset = e.end
+	}
+	return append(out, f.src[offset:]...)
+}
+
+// rewriter 改写一个函数
+type rewriter struct {
+	fset *token.FileSet
+	pkg  *types.Package
+	info *types.Info
+	file *source
+	id   int // defer 语句的编号，按源码顺序从 1 开始
+}
+
+func (w *rewriter) function(fn *ast.FuncDecl, name string) {
+	w.file.traced = true
+	prologue := fmt.Sprintf(" %s := %s.Enter(%q);", tracerName, importName, name)
+	if fn.Type.Results != nil {
+		for _, field := range fn.Type.Results.List {
+			for _, id := range field.Names {
+				if id.Name != "_" {
+					prologue += fmt.Sprintf(" %s.Named(%q, &%s);", tracerName, id.Name, id.Name)
+				}
+			}
+		}
+	}
+	prologue += fmt.Sprintf(" defer %s.Exit();", tracerName)
+	lbrace := w.offset(fn.Body.Lbrace) + 1
+	w.file.edits = append(w.file.edits, edit{lbrace, lbrace, prologue})
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.DeferStmt:
+			w.id++
+			w.file.edits = append(w.file.edits, edit{w.offset(n.Pos()), w.offset(n.End()), w.deferStmt(n)})
+		}
+		return true
+	})
+}
+
+// deferStmt 返回改写后的 defer 语句，参考 package 文档
+func (w *rewriter) deferStmt(d *ast.DeferStmt) string {
+	call := d.Call
+	var hoisted, args, captured []string
+	for i, arg := range call.Args {
+		tv := w.info.Types[arg]
+		text := w.text(arg)
+		label := strconv.Quote(w.describe(arg))
+
+		switch {
+		case tv.Value != nil || tv.IsNil():
+			// 常量以及 nil 没有副作用，不需要提前取值
+			args = append(args, text)
+			captured = append(captured, label, text)
+
+		case isTuple(tv.Type):
+			// f(g()) 形式，g 有多个返回值
+			tuple := tv.Type.(*types.Tuple)
+			var names []string
+			for j := 0; j < tuple.Len(); j++ {
+				name := fmt.Sprintf("gotourDefer%da%d", w.id, j)
+				names = append(names, name)
+				captured = append(captured, strconv.Quote(fmt.Sprintf("%s[%d]", w.describe(arg), j)), name)
+			}
+			hoisted = append(hoisted, fmt.Sprintf("%s := %s", strings.Join(names, ", "), text))
+			args = append(args, names...)
+
+		default:
+			name := fmt.Sprintf("gotourDefer%da%d", w.id, i)
+			hoisted = append(hoisted, fmt.Sprintf("%s := %s", name, w.convert(arg, tv.Type, text)))
+			args = append(args, name)
+			captured = append(captured, label, name)
+		}
+	}
+
+	ellipsis := ""
+	if call.Ellipsis.IsValid() {
+		ellipsis = "..."
+	}
+	pos := w.fset.Position(d.Pos())
+	register := fmt.Sprintf("%s.Defer(%d, %q, \"%s:%d\"", tracerName, w.id, w.describe(call), filepath.Base(pos.Filename), pos.Line)
+	for _, c := range captured {
+		register += ", " + c
+	}
+	register += ")"
+
+	stmts := append(hoisted,
+		register,
+		fmt.Sprintf("defer %s.Done(%d)", tracerName, w.id),
+		fmt.Sprintf("defer %s(%s%s)", w.text(call.Fun), strings.Join(args, ", "), ellipsis),
+		fmt.Sprintf("defer %s.Run(%d)", tracerName, w.id),
+	)
+	return strings.Join(stmts, "; ")
+}
+
+// convert 处理 untyped 的非常量表达式，比如 `a == b`、`1 << n`：
+// 这类表达式的类型由上下文决定，`:=` 会使用默认类型，需要显式转换为实际的类型
+func (w *rewriter) convert(arg ast.Expr, t types.Type, text string) string {
+	if !w.untyped(arg) {
+		return text
+	}
+	qualified := true
+	name := types.TypeString(t, func(p *types.Package) string {
+		if p == w.pkg {
+			return ""
+		}
+		for _, spec := range w.file.ast.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			if path != p.Path() {
+				continue
+			}
+			if spec.Name != nil {
+				return spec.Name.Name
+			}
+			return p.Name()
+		}
+		qualified = false
+		return p.Name()
+	})
+	if !qualified {
+		return text
+	}
+	return name + "(" + text + ")"
+}
+
+// untyped 表示表达式的结果可能是 untyped 的非常量：比较、逻辑运算，以及常量按变量移位
+func (w *rewriter) untyped(e ast.Expr) bool {
+	switch e := e.(type) {
+	case *ast.ParenExpr:
+		return w.untyped(e.X)
+	case *ast.UnaryExpr:
+		return w.untyped(e.X)
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			return true
+		case token.SHL, token.SHR:
+			return w.info.Types[e.X].Value != nil || w.untyped(e.X)
+		}
+		return w.untyped(e.X) && w.untyped(e.Y)
+	}
+	return false
+}
+
+func (w *rewriter) offset(pos token.Pos) int {
+	return w.fset.Position(pos).Offset
+}
+
+// text 返回节点的源码
+func (w *rewriter) text(n ast.Node) string {
+	return string(w.file.src[w.offset(n.Pos()):w.offset(n.End())])
+}
+
+// describe 返回节点的单行描述，函数字面量的函数体省略为 {...}
+func (w *rewriter) describe(n ast.Node) string {
+	var b strings.Builder
+	offset := w.offset(n.Pos())
+	ast.Inspect(n, func(n ast.Node) bool {
+		lit, ok := n.(*ast.FuncLit)
+		if !ok {
+			return true
+		}
+		b.Write(w.file.src[offset:w.offset(lit.Body.Pos())])
+		b.WriteString("{...}")
+		offset = w.offset(lit.Body.End())
+		return false
+	})
+	b.Write(w.file.src[offset:w.offset(n.End())])
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// funcName 返回函数名，方法写作 `T.Method`
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	t := fn.Recv.List[0].Type
+	for {
+		switch x := t.(type) {
+		case *ast.StarExpr:
+			t = x.X
+			continue
+		case *ast.IndexExpr:
+			t = x.X
+			continue
+		case *ast.IndexListExpr:
+			t = x.X
+			continue
+		case *ast.Ident:
+			return x.Name + "." + fn.Name.Name
+		}
+		return fn.Name.Name
+	}
+}
+
+// hasDefer 表示函数体中直接包含 defer 语句，不包括函数字面量中的 defer 语句
+func hasDefer(body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.DeferStmt:
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+func isTuple(t types.Type) bool {
+	_, ok := t.(*types.Tuple)
+	return ok
+}
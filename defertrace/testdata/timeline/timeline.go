@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+type flag bool
+
+func namedResult() (result int) {
+	defer func() {
+		result *= 7
+	}()
+	return 6
+}
+
+func captured() {
+	time := "1 PM"
+	defer func(in string) {
+		fmt.Println(in)
+	}(time)
+
+	time = "2 PM"
+}
+
+func loop() {
+	for i := 0; i <= 3; i++ {
+		defer fmt.Print(i)
+	}
+	fmt.Print("loop: ")
+}
+
+func recovered() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("recovered: %v", r)
+		}
+	}()
+	defer fmt.Println("cleanup")
+	panic("boom")
+}
+
+func pair() (int, error) {
+	return 1, errors.New("pair")
+}
+
+func show(n int, err error) {
+	fmt.Println("show", n, err)
+}
+
+func check(f flag) {
+	fmt.Println("check", f)
+}
+
+func arguments(a, b int) {
+	defer show(pair())
+	defer check(a == b)
+	defer fmt.Println([]any{a, b}...)
+}
+
+func main() {
+	fmt.Println(namedResult())
+	captured()
+	loop()
+	fmt.Println()
+	fmt.Println(recovered())
+	arguments(1, 2)
+}
@@ -0,0 +1,186 @@
+package defertrace
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// Output 为时间线的输出位置，默认与程序输出一样写到标准输出，这样两者按发生顺序交织在一起
+var Output io.Writer = os.Stdout
+
+// depth 为正在跟踪的函数调用层数，用于缩进；只记录一个全局的层数，多个 goroutine 同时跟踪时缩进会错乱
+var depth int
+
+// Tracer 记录一次函数调用中 defer 调用的注册与执行，由 Instrument 插入的代码调用
+type Tracer struct {
+	fn        string
+	depth     int
+	results   []namedResult
+	stack     []*call // 已注册、还没有执行的 defer 调用，栈顶在末尾
+	returning bool    // 已开始执行 defer 调用
+	panicked  bool    // 执行 defer 调用时处于 panicking 状态
+}
+
+type namedResult struct {
+	name string
+	ptr  any
+}
+
+type call struct {
+	id       int
+	expr     string
+	captured string // 入栈时取得的实参值，循环中的同一条 defer 语句靠它区分
+}
+
+// Enter 在函数入口调用，输出 `→ fn`
+func Enter(fn string) *Tracer {
+	t := &Tracer{fn: fn, depth: depth}
+	depth++
+	t.printf("→ %s", fn)
+	return t
+}
+
+// Named 登记命名返回值，ptr 为指向返回值变量的指针，之后每一步都会输出返回值的当前值
+func (t *Tracer) Named(name string, ptr any) {
+	t.results = append(t.results, namedResult{name, ptr})
+}
+
+// Defer 在 defer 语句执行时调用，即 defer 调用入栈：
+// expr 为 defer 的调用表达式，pos 为 defer 语句的位置，
+// args 为按 `表达式, 值` 成对排列的实参，值为 defer 语句执行时取得的值
+func (t *Tracer) Defer(id int, expr, pos string, args ...any) {
+	t.printf("  defer #%d %s  (%s)", id, expr, pos)
+
+	var captured []string
+	for i := 0; i+1 < len(args); i += 2 {
+		label, value := fmt.Sprint(args[i]), format(args[i+1])
+		if label == value {
+			captured = append(captured, value)
+		} else {
+			captured = append(captured, label+" = "+value)
+		}
+	}
+	c := &call{id: id, expr: expr, captured: strings.Join(captured, ", ")}
+	if c.captured != "" {
+		t.printf("      captured: %s", c.captured)
+	}
+	t.stack = append(t.stack, c)
+	t.printf("      stack: %s", t.stackString())
+}
+
+// Run 在 defer 调用执行之前调用，即 defer 调用出栈
+func (t *Tracer) Run(id int) {
+	if !t.returning {
+		t.returning = true
+		t.panicked = panicking()
+		state := "returning"
+		if t.panicked {
+			state = "panicking"
+		}
+		t.printf("%s %s%s, stack: %s", t.fn, state, t.resultString(), t.stackString())
+	}
+
+	// 栈顶总是即将执行的调用，按 id 查找只是为了容错
+	i := len(t.stack) - 1
+	for i >= 0 && t.stack[i].id != id {
+		i--
+	}
+	if i < 0 {
+		t.printf("  run #%d", id)
+		return
+	}
+	c := t.stack[i]
+	t.stack = append(t.stack[:i], t.stack[i+1:]...)
+
+	state := ""
+	if panicking() {
+		state = " (panicking)"
+	}
+	t.printf("  run #%d %s%s%s", id, c.expr, state, t.resultString())
+}
+
+// Done 在 defer 调用正常返回之后调用
+func (t *Tracer) Done(id int) {
+	t.printf("  done #%d%s", id, t.resultString())
+}
+
+// Exit 作为函数中第一个注册、最后一个执行的 defer 调用，输出 `← fn` 以及最终的返回值
+func (t *Tracer) Exit() {
+	depth = t.depth
+	state := ""
+	switch {
+	case panicking():
+		state = " (panicking)"
+	case t.panicked:
+		state = " (recovered)"
+	}
+	t.printf("← %s%s%s", t.fn, state, t.resultString())
+}
+
+// Returned 输出入口函数的返回值，参考 Workspace
+func Returned(values ...any) {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = format(v)
+	}
+	fmt.Fprintf(Output, "[defer] returned: %s\n", strings.Join(s, ", "))
+}
+
+func (t *Tracer) printf(format string, args ...any) {
+	fmt.Fprintf(Output, "[defer] %s%s\n", strings.Repeat("    ", t.depth), fmt.Sprintf(format, args...))
+}
+
+// stackString 从栈顶开始列出还没有执行的 defer 调用，即之后的执行顺序
+func (t *Tracer) stackString() string {
+	if len(t.stack) == 0 {
+		return "(empty)"
+	}
+	s := make([]string, len(t.stack))
+	for i, c := range t.stack {
+		s[len(t.stack)-1-i] = fmt.Sprintf("#%d", c.id)
+		if c.captured != "" {
+			s[len(t.stack)-1-i] += "(" + c.captured + ")"
+		}
+	}
+	return strings.Join(s, " ")
+}
+
+func (t *Tracer) resultString() string {
+	if len(t.results) == 0 {
+		return ""
+	}
+	s := make([]string, len(t.results))
+	for i, r := range t.results {
+		s[i] = r.name + " = " + format(reflect.ValueOf(r.ptr).Elem().Interface())
+	}
+	return "  [" + strings.Join(s, ", ") + "]"
+}
+
+// panicking 表示调用 Tracer 方法的是 runtime.gopanic，即 defer 调用因为 panic 而执行：
+// 正常返回时，defer 调用的 caller 是函数本身；
+// 不能使用 recover 判断，recover 会终止 panicking
+func panicking() bool {
+	pcs := make([]uintptr, 1)
+	n := runtime.Callers(3, pcs) // 跳过 runtime.Callers、panicking 以及 Tracer 的方法
+	if n == 0 {
+		return false
+	}
+	f, _ := runtime.CallersFrames(pcs[:n]).Next()
+	return f.Function == "runtime.gopanic"
+}
+
+func format(v any) string {
+	switch v := v.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case error:
+		return fmt.Sprintf("error(%q)", v.Error())
+	case nil:
+		return "nil"
+	}
+	return fmt.Sprintf("%v", v)
+}
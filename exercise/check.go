@@ -0,0 +1,110 @@
+package exercise
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Result 为检查练习的结果
+type Result struct {
+	Passed bool
+	Output string // go test 的输出，包括编译错误
+}
+
+// Check 将 stub 与隐藏的测试放到临时目录中运行 go test
+func (e Exercise) Check(ctx context.Context) (Result, error) {
+	return e.check(ctx, e.Dir)
+}
+
+// CheckSolution 使用参考答案代替 stub 运行隐藏的测试，用于验证测试本身
+func (e Exercise) CheckSolution(ctx context.Context) (Result, error) {
+	return e.check(ctx, filepath.Join(e.Dir, "testdata", "solution"))
+}
+
+func (e Exercise) check(ctx context.Context, srcDir string) (Result, error) {
+	dir, err := e.workspace(srcDir)
+	if err != nil {
+		return Result{}, err
+	}
+	defer os.RemoveAll(dir)
+
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, "go", "test", "-count=1", ".")
+	cmd.Dir = dir
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err = cmd.Run()
+
+	r := Result{Output: strings.ReplaceAll(out.String(), dir, e.Dir)}
+	if err == nil {
+		r.Passed = true
+		return r, nil
+	}
+	if _, ok := err.(*exec.ExitError); ok && ctx.Err() == nil {
+		return r, nil
+	}
+	return r, err
+}
+
+// workspace 在临时目录中生成用于检查的 package，返回临时目录路径，由调用者负责删除：
+//   - 复制 srcDir 中非测试的 go 文件，srcDir 为练习目录或参考答案目录
+//   - 复制 testdata 中隐藏的测试
+func (e Exercise) workspace(srcDir string) (string, error) {
+	stubs, err := stubFiles(srcDir)
+	if err != nil {
+		return "", err
+	}
+	testdata := filepath.Join(e.Dir, "testdata")
+	tests, err := goFiles(testdata, func(name string) bool { return strings.HasSuffix(name, "_test.go") })
+	if err != nil {
+		return "", err
+	}
+	if len(tests) == 0 {
+		return "", fmt.Errorf("exercise %s: no tests in %s", e.Name, testdata)
+	}
+
+	dir, err := os.MkdirTemp("", "gotour-check-"+e.Name+"-")
+	if err != nil {
+		return "", err
+	}
+
+	copies := map[string]string{}
+	for _, name := range stubs {
+		copies[filepath.Join(srcDir, name)] = filepath.Join(dir, name)
+	}
+	for _, name := range tests {
+		copies[filepath.Join(testdata, name)] = filepath.Join(dir, name)
+	}
+	for src, dst := range copies {
+		if err := copyFile(dst, src); err != nil {
+			os.RemoveAll(dir)
+			return "", err
+		}
+	}
+
+	return dir, nil
+}
+
+func copyFile(dst, src string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
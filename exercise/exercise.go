@@ -0,0 +1,189 @@
+/*
+Package exercise 负责发现并检查章节中的练习
+
+	练习约定：
+		* 章节目录下的 `exercises/<name>` 目录为一个练习，<name> 即练习名，在整个 tour 中唯一，比如 `07-slices/exercises/slicedelete`
+		* 练习目录中非测试的 go 文件为 stub，学习者在 stub 中完成练习
+		* stub 的 doc comment 为练习说明，第一行非空文本为练习标题
+		* stub 中 package 声明之前的 `//gotour:` 指令描述练习的提示：
+			** `//gotour:hint <section>`，相关的课程小节，值为小节 ID 或标题，检查失败时输出该小节的内容
+			** `//gotour:demo <name>`，相关的 demo 函数，检查失败时提示使用 `gotour run` 运行
+		* `testdata/*_test.go` 为隐藏的测试，检查练习时才会与 stub 放到一起运行，
+			所以 `go test ./...` 不会因为未完成的练习而失败
+		* `testdata/solution/*.go` 为参考答案，用于验证隐藏的测试本身是正确的
+*/
+package exercise
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/SamHwang1990/go-tour/chapter"
+	"github.com/SamHwang1990/go-tour/lesson"
+)
+
+// Exercise 描述一个练习
+type Exercise struct {
+	Name    string // 练习名，即目录名，比如 "slicedelete"
+	Chapter string // 所属章节目录名，比如 "07-slices"
+	Dir     string // 练习目录的绝对路径
+	Title   string
+	Doc     string   // 练习说明，已去掉注释符号
+	Hints   []string // 相关的课程小节，小节 ID 或标题
+	Demos   []string // 相关的 demo 函数
+}
+
+// ID 返回带章节前缀的练习名，比如 "07-slices/slicedelete"
+func (e Exercise) ID() string {
+	return e.Chapter + "/" + e.Name
+}
+
+// Discover 返回章节中的所有练习，按练习名排序
+func Discover(c chapter.Chapter) ([]Exercise, error) {
+	entries, err := os.ReadDir(filepath.Join(c.Dir, "exercises"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var exercises []Exercise
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		e, err := Load(filepath.Join(c.Dir, "exercises", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		e.Chapter = c.Name()
+		exercises = append(exercises, e)
+	}
+	return exercises, nil
+}
+
+// DiscoverAll 按章节顺序返回所有章节的练习
+func DiscoverAll(chapters []chapter.Chapter) ([]Exercise, error) {
+	var exercises []Exercise
+	for _, c := range chapters {
+		list, err := Discover(c)
+		if err != nil {
+			return nil, err
+		}
+		exercises = append(exercises, list...)
+	}
+	return exercises, nil
+}
+
+// Load 解析练习目录中的 stub，提取练习说明以及提示
+func Load(dir string) (Exercise, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return Exercise{}, err
+	}
+	e := Exercise{Name: filepath.Base(abs), Dir: abs}
+
+	names, err := stubFiles(abs)
+	if err != nil {
+		return Exercise{}, err
+	}
+	if len(names) == 0 {
+		return Exercise{}, fmt.Errorf("exercise %s: no stub files", e.Name)
+	}
+
+	fset := token.NewFileSet()
+	for _, name := range names {
+		f, err := parser.ParseFile(fset, filepath.Join(abs, name), nil, parser.PackageClauseOnly|parser.ParseComments)
+		if err != nil {
+			return Exercise{}, err
+		}
+		for _, group := range f.Comments {
+			if group.Pos() > f.Package {
+				break
+			}
+			for _, c := range group.List {
+				switch {
+				case strings.HasPrefix(c.Text, "/*") && e.Doc == "":
+					e.Doc = strings.TrimSuffix(c.Text[2:], "*/")
+				case strings.HasPrefix(c.Text, "//gotour:hint "):
+					e.Hints = append(e.Hints, strings.TrimSpace(strings.TrimPrefix(c.Text, "//gotour:hint ")))
+				case strings.HasPrefix(c.Text, "//gotour:demo "):
+					e.Demos = append(e.Demos, strings.TrimSpace(strings.TrimPrefix(c.Text, "//gotour:demo ")))
+				}
+			}
+		}
+	}
+
+	for _, line := range strings.Split(e.Doc, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			e.Title = strings.TrimSpace(strings.TrimRight(line, ":："))
+			break
+		}
+	}
+	if e.Title == "" {
+		e.Title = e.Name
+	}
+
+	return e, nil
+}
+
+// Find 查找练习，支持 "slicedelete"、"07/slicedelete"、"slices/slicedelete"、"07-slices/slicedelete" 几种写法
+func Find(chapters []chapter.Chapter, exercises []Exercise, key string) (Exercise, error) {
+	chapterKey, name := "", key
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		chapterKey, name = key[:i], key[i+1:]
+	}
+
+	var c chapter.Chapter
+	if chapterKey != "" {
+		var err error
+		if c, err = chapter.Find(chapters, chapterKey); err != nil {
+			return Exercise{}, err
+		}
+	}
+
+	for _, e := range exercises {
+		if e.Name == name && (chapterKey == "" || e.Chapter == c.Name()) {
+			return e, nil
+		}
+	}
+	return Exercise{}, fmt.Errorf("exercise %q not found", key)
+}
+
+// HintSections 返回练习提示对应的课程小节，找不到的小节会被忽略
+func (e Exercise) HintSections(l *lesson.Lesson) []*lesson.Section {
+	var sections []*lesson.Section
+	for _, key := range e.Hints {
+		if s := l.Section(key); s != nil {
+			sections = append(sections, s)
+		}
+	}
+	return sections
+}
+
+// stubFiles 返回练习目录中非测试的 go 文件名，按文件名排序
+func stubFiles(dir string) ([]string, error) {
+	return goFiles(dir, func(name string) bool { return !strings.HasSuffix(name, "_test.go") })
+}
+
+func goFiles(dir string, keep func(name string) bool) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasSuffix(name, ".go") && keep(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,98 @@
+package exercise
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SamHwang1990/go-tour/chapter"
+	"github.com/SamHwang1990/go-tour/lesson"
+)
+
+func discover(t *testing.T) ([]chapter.Chapter, []Exercise) {
+	t.Helper()
+	chapters, err := chapter.Discover("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exercises, err := DiscoverAll(chapters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exercises) == 0 {
+		t.Fatal("no exercises found")
+	}
+	return chapters, exercises
+}
+
+// TestHints 检查练习中的提示都能在课程中找到对应的小节以及 demo 函数
+func TestHints(t *testing.T) {
+	chapters, exercises := discover(t)
+	for _, e := range exercises {
+		c, err := chapter.Find(chapters, e.Chapter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		l := lesson.Load(c)
+		if got := e.HintSections(l); len(got) != len(e.Hints) {
+			t.Errorf("%s: hints %q, only %d found in %s", e.ID(), e.Hints, len(got), c.Name())
+		}
+		for _, d := range e.Demos {
+			if _, ok := c.Demo(d); !ok {
+				t.Errorf("%s: demo %q not found in %s", e.ID(), d, c.Name())
+			}
+		}
+	}
+}
+
+// TestSolutions 检查参考答案能通过隐藏的测试，而未完成的 stub 不能通过
+func TestSolutions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping exercise checks in short mode: every exercise is compiled with go test")
+	}
+
+	_, exercises := discover(t)
+	for _, e := range exercises {
+		t.Run(e.Name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+
+			r, err := e.CheckSolution(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !r.Passed {
+				t.Errorf("solution fails:\n%s", r.Output)
+			}
+
+			r, err = e.Check(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Passed {
+				t.Errorf("stub passes the hidden tests without any change")
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	chapters, exercises := discover(t)
+	for _, key := range []string{"slicedelete", "07/slicedelete", "slices/slicedelete", "07-slices/slicedelete"} {
+		e, err := Find(chapters, exercises, key)
+		if err != nil {
+			t.Errorf("Find(%q): %v", key, err)
+			continue
+		}
+		if e.ID() != "07-slices/slicedelete" {
+			t.Errorf("Find(%q) = %s, want 07-slices/slicedelete", key, e.ID())
+		}
+	}
+	for _, key := range []string{"nonexistent", "08/slicedelete", "99/slicedelete"} {
+		if _, err := Find(chapters, exercises, key); err == nil {
+			t.Errorf("Find(%q): want error", key)
+		}
+	}
+}
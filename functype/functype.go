@@ -0,0 +1,277 @@
+/*
+Package functype 按函数类型对 package 中的函数分组，参考 04-functions 中的函数类型（Function Type）
+
+	函数类型只由形参列表（类型、数量）、返回值类型（类型、数量）以及是否为可变参数决定，与函数名、形参名无关：
+		* 收集 package 中的函数、方法值（method value）以及函数字面量，使用 go/types 的 types.Identical 比较类型并分组
+		* 方法值不包含 receiver，比如 `func (l List) Contains(x Type) bool` 的方法值 l.Contains 的类型为 `func(Type) bool`
+		* 函数字面量的命名与编译器（panic 时的 stack trace）一致，比如 main 函数中的第一个函数字面量为 main.func1
+		* 泛型函数在实例化之前没有函数值，不参与分组；init 函数不能被引用，也不参与分组
+
+	对 package 中声明的每个命名函数类型（比如 `type calcFunc func(int, int) int`）：
+		* 列出可以赋值给它的函数，即类型相同的函数
+		* 参数、返回值数量相同但类型不同的函数，逐项解释原因，比如可变参数 `...T` 与 slice 参数 `[]T` 的区别
+*/
+package functype
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// Package 为一个 package 中的函数类型分组
+type Package struct {
+	Path   string // import path，不在 GOPATH 中的目录（比如章节目录）为目录名
+	Name   string
+	Groups []*Group // 按第一个函数在源码中出现的顺序排列
+	Named  []*Named // 命名函数类型，按名字排序
+}
+
+// Func 为一个函数、方法值或者函数字面量
+type Func struct {
+	Name string // 比如 calcNumber、List.Contains、(*List).Push、main.func1
+	Kind string // func、method 或者 literal
+	Pos  token.Position
+	Sig  *types.Signature // 不包含 receiver
+}
+
+// Group 为类型相同的一组函数
+type Group struct {
+	Type  string // 不包含形参名的函数类型，比如 func(int, int) int
+	Sig   *types.Signature
+	Funcs []*Func
+	Named []*Named // 可以赋值给的命名函数类型
+}
+
+// Named 为 package 中声明的命名函数类型
+type Named struct {
+	Name       string
+	Type       string // underlying 函数类型
+	Pos        token.Position
+	Obj        *types.TypeName
+	Assignable []*Group
+	Mismatches []Mismatch
+}
+
+// Mismatch 解释一组函数为什么不能赋值给命名函数类型
+type Mismatch struct {
+	Group   *Group
+	Reasons []string
+}
+
+// Load 加载 dir 目录中的 package，并按函数类型分组
+func Load(dir string) (*Package, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range bp.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(bp.Dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	path := bp.ImportPath
+	if path == "." || path == "" {
+		path = filepath.Base(bp.Dir)
+	}
+	info := &types.Info{
+		Types: map[ast.Expr]types.TypeAndValue{},
+		Defs:  map[*ast.Ident]types.Object{},
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check(path, fset, files, info)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Package{Path: path, Name: bp.Name}
+	qualifier := types.RelativeTo(pkg)
+	add := func(f *Func) {
+		for _, g := range p.Groups {
+			if types.Identical(g.Sig, f.Sig) {
+				g.Funcs = append(g.Funcs, f)
+				return
+			}
+		}
+		p.Groups = append(p.Groups, &Group{Type: TypeString(f.Sig, qualifier), Sig: f.Sig, Funcs: []*Func{f}})
+	}
+
+	// package 级别变量初始值中的函数字面量共用一个编号，编译器将其依次命名为 glob..func1、glob..func2
+	globals := 0
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if f := declFunc(fset, info, decl); f != nil {
+					add(f)
+				}
+				if decl.Body != nil {
+					literals(fset, info, decl.Body, declName(decl), false, new(int), add)
+				}
+			case *ast.GenDecl:
+				literals(fset, info, decl, "glob.", false, &globals, add)
+			}
+		}
+	}
+
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || obj.IsAlias() {
+			continue
+		}
+		if n, ok := obj.Type().(*types.Named); !ok || n.TypeParams().Len() > 0 {
+			continue
+		}
+		sig, ok := obj.Type().Underlying().(*types.Signature)
+		if !ok {
+			continue
+		}
+
+		named := &Named{Name: name, Type: TypeString(sig, qualifier), Pos: fset.Position(obj.Pos()), Obj: obj}
+		for _, g := range p.Groups {
+			switch {
+			case types.AssignableTo(g.Sig, obj.Type()):
+				named.Assignable = append(named.Assignable, g)
+				g.Named = append(g.Named, named)
+			case g.Sig.Params().Len() == sig.Params().Len() && g.Sig.Results().Len() == sig.Results().Len():
+				named.Mismatches = append(named.Mismatches, Mismatch{Group: g, Reasons: Diff(g.Sig, sig, qualifier)})
+			}
+		}
+		p.Named = append(p.Named, named)
+	}
+	return p, nil
+}
+
+// declFunc 返回函数或者方法值，泛型函数、init 函数以及 `_` 返回 nil
+func declFunc(fset *token.FileSet, info *types.Info, decl *ast.FuncDecl) *Func {
+	obj, ok := info.Defs[decl.Name].(*types.Func)
+	if !ok || decl.Name.Name == "_" || decl.Recv == nil && decl.Name.Name == "init" {
+		return nil
+	}
+	sig := obj.Type().(*types.Signature)
+	if sig.TypeParams().Len() > 0 || sig.RecvTypeParams().Len() > 0 {
+		return nil
+	}
+
+	f := &Func{Name: declName(decl), Kind: "func", Pos: fset.Position(decl.Name.Pos()), Sig: sig}
+	if sig.Recv() != nil {
+		f.Kind = "method"
+		f.Sig = types.NewSignatureType(nil, nil, nil, sig.Params(), sig.Results(), sig.Variadic())
+	}
+	return f
+}
+
+// declName 返回函数名，方法写作 T.Method 或者 (*T).Method
+func declName(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return decl.Name.Name
+	}
+	t, pointer := decl.Recv.List[0].Type, false
+	if star, ok := t.(*ast.StarExpr); ok {
+		t, pointer = star.X, true
+	}
+	name := types.ExprString(t)
+	if pointer {
+		name = "(*" + name + ")"
+	}
+	return name + "." + decl.Name.Name
+}
+
+// literals 收集 node 中的函数字面量，第 n 个为 parent.funcN，嵌套在函数字面量中的为 parent.N，比如 main.func1.1，
+// n 为 parent 中已经编号的函数字面量数量
+func literals(fset *token.FileSet, info *types.Info, node ast.Node, parent string, nested bool, n *int, add func(*Func)) {
+	ast.Inspect(node, func(node ast.Node) bool {
+		lit, ok := node.(*ast.FuncLit)
+		if !ok {
+			return true
+		}
+		*n++
+		name := parent + ".func" + strconv.Itoa(*n)
+		if nested {
+			name = parent + "." + strconv.Itoa(*n)
+		}
+		if sig, ok := info.Types[lit].Type.(*types.Signature); ok {
+			add(&Func{Name: name, Kind: "literal", Pos: fset.Position(lit.Pos()), Sig: sig})
+		}
+		literals(fset, info, lit.Body, name, true, new(int), add)
+		return false
+	})
+}
+
+// TypeString 返回不包含形参名、返回值名的函数类型，比如 `func([]Type, ...Type) []Type`
+func TypeString(sig *types.Signature, qualifier types.Qualifier) string {
+	unnamed := types.NewSignatureType(nil, nil, nil, unnamedTuple(sig.Params()), unnamedTuple(sig.Results()), sig.Variadic())
+	return types.TypeString(unnamed, qualifier)
+}
+
+func unnamedTuple(t *types.Tuple) *types.Tuple {
+	vars := make([]*types.Var, t.Len())
+	for i := range vars {
+		vars[i] = types.NewParam(token.NoPos, nil, "", t.At(i).Type())
+	}
+	return types.NewTuple(vars...)
+}
+
+// Diff 逐项解释 have 与 want 两个函数类型的区别，类型相同时返回 nil
+func Diff(have, want *types.Signature, qualifier types.Qualifier) []string {
+	var reasons []string
+	reasons = append(reasons, diffTuple("parameter", have.Params(), want.Params(), have.Variadic(), want.Variadic(), qualifier)...)
+	reasons = append(reasons, diffTuple("result", have.Results(), want.Results(), false, false, qualifier)...)
+	return reasons
+}
+
+func diffTuple(kind string, have, want *types.Tuple, haveVariadic, wantVariadic bool, qualifier types.Qualifier) []string {
+	if have.Len() != want.Len() {
+		return []string{fmt.Sprintf("%d %ss, want %d", have.Len(), kind, want.Len())}
+	}
+
+	var reasons []string
+	for i := 0; i < have.Len(); i++ {
+		ht, wt := have.At(i).Type(), want.At(i).Type()
+		last := i == have.Len()-1
+		hv, wv := haveVariadic && last, wantVariadic && last
+		if types.Identical(ht, wt) && hv == wv {
+			continue
+		}
+
+		reason := fmt.Sprintf("%s %d: %s, want %s", kind, i+1, paramString(ht, hv, qualifier), paramString(wt, wv, qualifier))
+		if types.Identical(ht, wt) {
+			// 可变参数 ...T 在函数体内的类型就是 []T，但调用方式不同，所以函数类型也不同
+			reason += fmt.Sprintf(": both are %s inside the function, but a variadic parameter takes separate arguments (or s...) while a slice parameter takes a single slice, so the function types differ",
+				types.TypeString(ht, qualifier))
+		}
+		reasons = append(reasons, reason)
+	}
+	return reasons
+}
+
+func paramString(t types.Type, variadic bool, qualifier types.Qualifier) string {
+	if variadic {
+		return "..." + types.TypeString(t.(*types.Slice).Elem(), qualifier)
+	}
+	return types.TypeString(t, qualifier)
+}
+
+// Funcs 返回所有函数，按名字排序
+func (p *Package) Funcs() []*Func {
+	var funcs []*Func
+	for _, g := range p.Groups {
+		funcs = append(funcs, g.Funcs...)
+	}
+	sort.Slice(funcs, func(i, j int) bool { return funcs[i].Name < funcs[j].Name })
+	return funcs
+}
@@ -0,0 +1,116 @@
+package functype
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	p, err := Load("testdata/appenders")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var groups []string
+	for _, g := range p.Groups {
+		groups = append(groups, g.Type+": "+funcNames(g))
+	}
+	want := []string{
+		"func(Type) bool: glob..func1, glob..func2, List.Contains, Evens.func1, Evens.func1.1",
+		"func([]Type, ...Type) []Type: Append, Prepend",
+		"func([]Type, []Type) []Type: Concat",
+		"func(int) bool: Positive",
+		"func(...Type): (*List).Push",
+		"func([]Type, Predicate) []Type: Filter",
+		"func([]Type) []Type: Evens",
+	}
+	if got := strings.Join(groups, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("groups:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+}
+
+func TestNamed(t *testing.T) {
+	p, err := Load("testdata/appenders")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		assignable string
+		mismatches []string
+	}{
+		{"Appender", "Append, Prepend", []string{
+			"Concat: parameter 2: []Type, want ...Type: both are []Type inside the function",
+			"Filter: parameter 2: Predicate, want ...Type",
+		}},
+		{"Predicate", "glob..func1, glob..func2, List.Contains, Evens.func1, Evens.func1.1", []string{
+			"Positive: parameter 1: int, want Type",
+			"Evens: parameter 1: []Type, want Type; result 1: []Type, want bool",
+		}},
+		{"SliceAppender", "Concat", []string{
+			"Append, Prepend: parameter 2: ...Type, want []Type: both are []Type inside the function",
+			"Filter: parameter 2: Predicate, want []Type",
+		}},
+	}
+	if len(p.Named) != len(tests) {
+		t.Fatalf("got %d named function types, want %d", len(p.Named), len(tests))
+	}
+	for i, tt := range tests {
+		n := p.Named[i]
+		if n.Name != tt.name {
+			t.Errorf("named type %d = %s, want %s", i, n.Name, tt.name)
+			continue
+		}
+		var assignable []string
+		for _, g := range n.Assignable {
+			assignable = append(assignable, funcNames(g))
+		}
+		if got := strings.Join(assignable, ", "); got != tt.assignable {
+			t.Errorf("%s assignable = %s, want %s", n.Name, got, tt.assignable)
+		}
+		if len(n.Mismatches) != len(tt.mismatches) {
+			t.Errorf("%s: got %d mismatches, want %d", n.Name, len(n.Mismatches), len(tt.mismatches))
+			continue
+		}
+		for j, m := range n.Mismatches {
+			got := funcNames(m.Group) + ": " + strings.Join(m.Reasons, "; ")
+			if !strings.HasPrefix(got, tt.mismatches[j]) {
+				t.Errorf("%s mismatch %d = %s, want prefix %s", n.Name, j, got, tt.mismatches[j])
+			}
+		}
+	}
+}
+
+// TestChapter 检查 04-functions 中与 calcFunc 类型相同的函数，main.func1 为 defer 的 func(int)
+func TestChapter(t *testing.T) {
+	p, err := Load("../04-functions")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groups := map[string]string{}
+	for _, g := range p.Groups {
+		groups[g.Type] = funcNames(g)
+	}
+	if got, want := groups["func(int) (int, int)"], "namedReturnValue, returnMultipleValue"; got != want {
+		t.Errorf("func(int) (int, int) = %s, want %s", got, want)
+	}
+
+	for _, n := range p.Named {
+		if n.Name != "calcFunc" {
+			continue
+		}
+		if len(n.Assignable) != 1 {
+			t.Fatalf("calcFunc assignable = %d groups, want 1", len(n.Assignable))
+		}
+		names := funcNames(n.Assignable[0])
+		for _, want := range []string{"main.func2", "main.func3", "calculator.func1"} {
+			if !strings.Contains(names, want) {
+				t.Errorf("calcFunc assignable = %s, want %s", names, want)
+			}
+		}
+		return
+	}
+	t.Error("calcFunc not found")
+}
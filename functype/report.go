@@ -0,0 +1,80 @@
+package functype
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+)
+
+// Write 输出函数类型报告：按类型分组的函数，以及每个命名函数类型可以赋值的函数、不能赋值的原因
+func (p *Package) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	pw := &printer{w: tw}
+
+	pw.printf("function types in %s (package %s)\n", p.Path, p.Name)
+
+	pw.printf("\nfunctions, grouped by type:\n")
+	for _, g := range p.Groups {
+		var names []string
+		for _, n := range g.Named {
+			names = append(names, n.Name)
+		}
+		mark := ""
+		if len(names) > 0 {
+			mark = " (assignable to " + strings.Join(names, ", ") + ")"
+		}
+		pw.printf("\n  %s%s\n", g.Type, mark)
+		for _, f := range g.Funcs {
+			pw.printf("    %s\t%s\t%s\n", f.Name, f.Kind, position(f))
+		}
+	}
+
+	if len(p.Named) > 0 {
+		pw.printf("\nnamed function types:\n")
+	}
+	for _, n := range p.Named {
+		pw.printf("\n  %s %s (%s:%d)\n", n.Name, n.Type, filepath.Base(n.Pos.Filename), n.Pos.Line)
+		if len(n.Assignable) == 0 {
+			pw.printf("    assignable: none\n")
+		}
+		for _, g := range n.Assignable {
+			pw.printf("    assignable: %s\n", funcNames(g))
+		}
+		for _, m := range n.Mismatches {
+			pw.printf("    not assignable: %s (%s)\n", funcNames(m.Group), m.Group.Type)
+			for _, reason := range m.Reasons {
+				pw.printf("      %s\n", reason)
+			}
+		}
+	}
+
+	if pw.err != nil {
+		return pw.err
+	}
+	return tw.Flush()
+}
+
+type printer struct {
+	w   io.Writer
+	err error
+}
+
+func (pw *printer) printf(format string, args ...interface{}) {
+	if pw.err == nil {
+		_, pw.err = fmt.Fprintf(pw.w, format, args...)
+	}
+}
+
+func position(f *Func) string {
+	return fmt.Sprintf("%s:%d", filepath.Base(f.Pos.Filename), f.Pos.Line)
+}
+
+func funcNames(g *Group) string {
+	names := make([]string, len(g.Funcs))
+	for i, f := range g.Funcs {
+		names[i] = f.Name
+	}
+	return strings.Join(names, ", ")
+}
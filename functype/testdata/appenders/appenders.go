@@ -0,0 +1,74 @@
+package appenders
+
+type Type int
+
+// Appender 与 Append、Prepend 的类型相同
+type Appender func([]Type, ...Type) []Type
+
+// SliceAppender 的最后一个参数为 slice 而不是可变参数
+type SliceAppender func([]Type, []Type) []Type
+
+type Predicate func(Type) bool
+
+type List []Type
+
+var isZero = func(x Type) bool { return x == 0 }
+
+// 单独的 var 声明，函数字面量与 isZero 中的共用编号
+var isOne = func(x Type) bool { return x == 1 }
+
+func Append(slice []Type, elems ...Type) []Type {
+	return append(slice, elems...)
+}
+
+func Prepend(slice []Type, elems ...Type) []Type {
+	return append(elems, slice...)
+}
+
+func Concat(a, b []Type) []Type {
+	return append(a, b...)
+}
+
+func Positive(x int) bool {
+	return x > 0
+}
+
+func (l List) Contains(x Type) bool {
+	for _, y := range l {
+		if y == x {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *List) Push(elems ...Type) {
+	*l = append(*l, elems...)
+}
+
+func Filter(s []Type, keep Predicate) []Type {
+	var out []Type
+	for _, x := range s {
+		if keep(x) {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+func Evens(s []Type) []Type {
+	return Filter(s, func(x Type) bool {
+		odd := func(x Type) bool { return x%2 == 1 }
+		return !odd(x)
+	})
+}
+
+func Map[T any](s []Type, f func(Type) T) []T {
+	var out []T
+	for _, x := range s {
+		out = append(out, f(x))
+	}
+	return out
+}
+
+func init() {}
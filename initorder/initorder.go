@@ -0,0 +1,265 @@
+/*
+Package initorder 使用 go/types 计算 package 的初始化顺序，参考 01-packages 中的 Program execution order
+
+	初始化顺序：
+		* package 之间：按 import path 排序所有 package，每次初始化第一个依赖均已初始化的 package，
+			即被依赖的 package 总是先完成初始化（参考 Order）
+		* package 内部：
+			** 先按依赖顺序初始化 global variables，顺序由 go/types 计算，即 `types.Info.InitOrder`，
+				没有初始值的 global variables 使用 zero value，不出现在 InitOrder 中
+			** 再按文件名顺序、文件内的声明顺序调用 init 函数
+
+	依赖循环：
+		* global variables 之间的初始化循环由 go/types 报告，记录在 Package.Cycles 中
+		* package 之间的 import 循环会导致 Load 返回 *ImportCycleError
+*/
+package initorder
+
+import (
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Program 为一个 package 以及其所有直接、间接依赖
+type Program struct {
+	Fset *token.FileSet
+	Root *Package
+	All  []*Package // 按 import path 排序
+}
+
+// Package 为一个 package 的初始化信息
+type Package struct {
+	Path    string // import path，不在 GOPATH 中的目录（比如章节目录）为目录名
+	Name    string
+	Dir     string
+	Goroot  bool       // 标准库
+	Imports []*Package // 直接依赖，按 import path 排序
+	Vars    []Var      // 按初始化顺序排列
+	Inits   []Func     // 按调用顺序排列
+	Cycles  []Cycle    // global variables 之间的初始化循环
+	Errors  []error    // 除初始化循环以外的类型检查错误
+}
+
+// Var 为一条 global variables 初始化语句，比如 `var foo, bar = f()` 中的 foo、bar 一起初始化
+type Var struct {
+	Names []string
+	Expr  string // 初始值表达式
+	Pos   token.Position
+}
+
+// Func 为一个 init 函数
+type Func struct {
+	Pos token.Position
+}
+
+// Cycle 为 go/types 报告的初始化循环，Steps 形如 "a refers to b"，最后一步回到第一个变量
+type Cycle struct {
+	Pos   token.Position
+	Steps []string
+}
+
+// ImportCycleError 为 package 之间的 import 循环，Path 的首尾为同一个 package
+type ImportCycleError struct {
+	Path []string
+}
+
+func (e *ImportCycleError) Error() string {
+	return "import cycle not allowed: " + strings.Join(e.Path, " -> ")
+}
+
+// Load 加载 dir 目录中的 package，以及其所有依赖，并对每个 package 进行类型检查
+func Load(dir string) (*Program, error) {
+	ctx := build.Default
+	ctx.CgoEnabled = false // 不处理 cgo，使用纯 go 的实现
+
+	l := &loader{
+		ctx:      &ctx,
+		fset:     token.NewFileSet(),
+		packages: map[string]*Package{},
+		types:    map[string]*types.Package{},
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	bp, err := l.ctx.ImportDir(abs, 0)
+	if err != nil {
+		return nil, err
+	}
+	root, err := l.load(bp)
+	if err != nil {
+		return nil, err
+	}
+
+	prog := &Program{Fset: l.fset, Root: root}
+	for _, p := range l.packages {
+		prog.All = append(prog.All, p)
+	}
+	sort.Slice(prog.All, func(i, j int) bool { return prog.All[i].Path < prog.All[j].Path })
+	return prog, nil
+}
+
+// Order 返回所有 package 的初始化顺序，Root 总是最后一个
+func (prog *Program) Order() []*Package {
+	done := map[*Package]bool{}
+	ready := func(p *Package) bool {
+		for _, imp := range p.Imports {
+			if !done[imp] {
+				return false
+			}
+		}
+		return true
+	}
+
+	var order []*Package
+	for len(order) < len(prog.All) {
+		for _, p := range prog.All {
+			if !done[p] && ready(p) {
+				done[p] = true
+				order = append(order, p)
+				break
+			}
+		}
+	}
+	return order
+}
+
+// loader 递归加载 package，同时作为类型检查时的 types.ImporterFrom
+type loader struct {
+	ctx      *build.Context
+	fset     *token.FileSet
+	packages map[string]*Package // key 为 package 目录
+	types    map[string]*types.Package
+	stack    []*Package // 正在加载的 package，用于检查 import 循环
+	cycle    *ImportCycleError
+}
+
+func (l *loader) Import(path string) (*types.Package, error) {
+	return l.ImportFrom(path, "", 0)
+}
+
+func (l *loader) ImportFrom(path, dir string, mode types.ImportMode) (*types.Package, error) {
+	if path == "unsafe" {
+		return types.Unsafe, nil
+	}
+	bp, err := l.ctx.Import(path, dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := l.load(bp); err != nil {
+		return nil, err
+	}
+	return l.types[bp.Dir], nil
+}
+
+func (l *loader) load(bp *build.Package) (*Package, error) {
+	if p, ok := l.packages[bp.Dir]; ok {
+		return p, nil
+	}
+
+	path := bp.ImportPath
+	if path == "." || path == "" {
+		path = filepath.Base(bp.Dir)
+	}
+	for i, loading := range l.stack {
+		if loading.Dir == bp.Dir {
+			if l.cycle == nil {
+				l.cycle = &ImportCycleError{}
+				for _, p := range l.stack[i:] {
+					l.cycle.Path = append(l.cycle.Path, p.Path)
+				}
+				l.cycle.Path = append(l.cycle.Path, loading.Path)
+			}
+			return nil, l.cycle
+		}
+	}
+
+	var files []*ast.File
+	for _, name := range bp.GoFiles {
+		f, err := parser.ParseFile(l.fset, filepath.Join(bp.Dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	p := &Package{Path: path, Name: bp.Name, Dir: bp.Dir, Goroot: bp.Goroot}
+	l.stack = append(l.stack, p)
+	defer func() { l.stack = l.stack[:len(l.stack)-1] }()
+
+	info := &types.Info{}
+	conf := types.Config{
+		Importer: l,
+		Sizes:    types.SizesFor("gc", l.ctx.GOARCH),
+		Error: func(err error) {
+			terr, ok := err.(types.Error)
+			if !ok {
+				p.Errors = append(p.Errors, err)
+				return
+			}
+			switch {
+			case strings.HasPrefix(terr.Msg, "initialization cycle: "):
+				// 变量引用了自身："initialization cycle: a refers to itself"
+				step := strings.TrimPrefix(terr.Msg, "initialization cycle: ")
+				p.Cycles = append(p.Cycles, Cycle{Pos: l.fset.Position(terr.Pos), Steps: []string{step}})
+			case strings.HasPrefix(terr.Msg, "initialization cycle for "):
+				// 之后的每条错误信息为循环中的一步："\ta refers to b"
+				p.Cycles = append(p.Cycles, Cycle{Pos: l.fset.Position(terr.Pos)})
+			case strings.HasPrefix(terr.Msg, "\t") && len(p.Cycles) > 0:
+				c := &p.Cycles[len(p.Cycles)-1]
+				c.Steps = append(c.Steps, strings.TrimSpace(terr.Msg))
+			default:
+				p.Errors = append(p.Errors, err)
+			}
+		},
+	}
+	tp, _ := conf.Check(path, l.fset, files, info)
+	if l.cycle != nil {
+		// import 循环需要中断整个加载过程，而不是作为普通的类型检查错误
+		return nil, l.cycle
+	}
+
+	for _, init := range info.InitOrder {
+		v := Var{Expr: types.ExprString(init.Rhs), Pos: l.fset.Position(init.Lhs[0].Pos())}
+		for _, lhs := range init.Lhs {
+			v.Names = append(v.Names, lhs.Name())
+		}
+		p.Vars = append(p.Vars, v)
+	}
+
+	// go/build 按文件名排序返回 GoFiles，即 init 函数的调用顺序
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "init" {
+				p.Inits = append(p.Inits, Func{Pos: l.fset.Position(fn.Pos())})
+			}
+		}
+	}
+
+	imports := map[string]bool{}
+	for _, path := range bp.Imports {
+		if path == "unsafe" || imports[path] {
+			continue
+		}
+		imports[path] = true
+		dep, err := l.ctx.Import(path, bp.Dir, 0)
+		if err != nil {
+			return nil, err
+		}
+		if imp, ok := l.packages[dep.Dir]; ok {
+			p.Imports = append(p.Imports, imp)
+		}
+	}
+	sort.Slice(p.Imports, func(i, j int) bool { return p.Imports[i].Path < p.Imports[j].Path })
+
+	l.packages[bp.Dir] = p
+	l.types[bp.Dir] = tp
+	return p, nil
+}
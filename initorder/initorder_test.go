@@ -0,0 +1,80 @@
+package initorder
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	prog, err := Load("testdata/order")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := prog.Root
+
+	var vars []string
+	for _, v := range p.Vars {
+		vars = append(vars, strings.Join(v.Names, ","))
+	}
+	// second 在 a.go 中声明，但 sum 依赖 second，所以 second 先于 sum 初始化
+	if got, want := strings.Join(vars, " "), "second first sum total"; got != want {
+		t.Errorf("variables = %s, want %s", got, want)
+	}
+
+	var inits []string
+	for _, f := range p.Inits {
+		inits = append(inits, filepath.Base(f.Pos.Filename))
+	}
+	if got, want := strings.Join(inits, " "), "a.go a.go b.go"; got != want {
+		t.Errorf("init functions = %s, want %s", got, want)
+	}
+
+	order := prog.Order()
+	if order[len(order)-1] != p {
+		t.Errorf("root package is not initialized last")
+	}
+	index := map[string]int{}
+	for i, pkg := range order {
+		index[pkg.Path] = i
+	}
+	for _, pkg := range order {
+		for _, imp := range pkg.Imports {
+			if index[imp.Path] > index[pkg.Path] {
+				t.Errorf("%s is initialized before its import %s", pkg.Path, imp.Path)
+			}
+		}
+	}
+}
+
+func TestCycle(t *testing.T) {
+	prog, err := Load("testdata/cycle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cycles := prog.Root.Cycles
+	if len(cycles) != 2 {
+		t.Fatalf("got %d cycles, want 2: %+v", len(cycles), cycles)
+	}
+	if got, want := strings.Join(cycles[0].Steps, "; "), "a refers to b; b refers to f; f refers to a"; got != want {
+		t.Errorf("cycle = %s, want %s", got, want)
+	}
+	if got, want := strings.Join(cycles[1].Steps, "; "), "c refers to itself"; got != want {
+		t.Errorf("cycle = %s, want %s", got, want)
+	}
+	if len(prog.Root.Errors) > 0 {
+		t.Errorf("unexpected errors: %v", prog.Root.Errors)
+	}
+}
+
+func TestImportCycle(t *testing.T) {
+	_, err := Load("testdata/importcycle/a")
+	var cycle *ImportCycleError
+	if !errors.As(err, &cycle) {
+		t.Fatalf("got %v, want an import cycle", err)
+	}
+	if n := len(cycle.Path); n < 3 || cycle.Path[0] != cycle.Path[n-1] {
+		t.Errorf("cycle path %q should start and end with the same package", cycle.Path)
+	}
+}
@@ -0,0 +1,146 @@
+package initorder
+
+import (
+	"fmt"
+	"go/token"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+)
+
+// Write 输出初始化报告：package 的初始化顺序、import 树，以及每个 package 中 global variables、init 函数的初始化顺序
+//
+// std 为 false 时，初始化顺序中连续的标准库只显示数量，import 树不展开标准库的依赖，也不显示标准库内部的初始化顺序
+func (prog *Program) Write(w io.Writer, std bool) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	pw := &printer{w: tw}
+	order := prog.Order()
+
+	pw.printf("initialization order of %s (package %s)\n", prog.Root.Path, prog.Root.Name)
+
+	pw.printf("\npackages, in initialization order:\n")
+	for i := 0; i < len(order); i++ {
+		p := order[i]
+		if p.Goroot && !std {
+			j := i
+			for j+1 < len(order) && order[j+1].Goroot {
+				j++
+			}
+			pw.printf("  %d-%d\t(%d standard library packages, %s ... %s)\n", i+1, j+1, j-i+1, p.Path, order[j].Path)
+			i = j
+			continue
+		}
+		mark := ""
+		if p.Goroot {
+			mark = " (std)"
+		}
+		pw.printf("  %d\t%s%s\n", i+1, p.Path, mark)
+	}
+
+	pw.printf("\nimport tree:\n")
+	pw.tree(prog.Root, "  ", "", std, map[*Package]bool{})
+
+	for _, p := range order {
+		if p.Goroot && !std {
+			continue
+		}
+		pw.printf("\npackage %s\n", p.Path)
+		pw.pkg(p)
+	}
+
+	if pw.err != nil {
+		return pw.err
+	}
+	return tw.Flush()
+}
+
+type printer struct {
+	w   io.Writer
+	err error
+}
+
+func (pw *printer) printf(format string, args ...interface{}) {
+	if pw.err == nil {
+		_, pw.err = fmt.Fprintf(pw.w, format, args...)
+	}
+}
+
+// tree 输出 import 树，已经展开过的 package 不再重复展开
+func (pw *printer) tree(p *Package, indent, branch string, std bool, seen map[*Package]bool) {
+	label := p.Path
+	switch {
+	case p.Goroot && !std && branch != "":
+		if n := countDeps(p, map[*Package]bool{}); n > 1 {
+			label += fmt.Sprintf(" (std, %d packages)", n)
+		} else {
+			label += " (std)"
+		}
+		pw.printf("%s%s%s\n", indent, branch, label)
+		return
+	case seen[p] && len(p.Imports) > 0:
+		pw.printf("%s%s%s (see above)\n", indent, branch, label)
+		return
+	}
+	seen[p] = true
+	pw.printf("%s%s%s\n", indent, branch, label)
+
+	switch branch {
+	case "├── ":
+		indent += "│   "
+	case "└── ":
+		indent += "    "
+	}
+	for i, imp := range p.Imports {
+		next := "├── "
+		if i == len(p.Imports)-1 {
+			next = "└── "
+		}
+		pw.tree(imp, indent, next, std, seen)
+	}
+}
+
+// countDeps 返回 p 以及其所有直接、间接依赖的数量
+func countDeps(p *Package, seen map[*Package]bool) int {
+	if seen[p] {
+		return 0
+	}
+	seen[p] = true
+	n := 1
+	for _, imp := range p.Imports {
+		n += countDeps(imp, seen)
+	}
+	return n
+}
+
+func (pw *printer) pkg(p *Package) {
+	pw.printf("  global variables, in initialization order:\n")
+	if len(p.Vars) == 0 {
+		pw.printf("    (none)\n")
+	}
+	for i, v := range p.Vars {
+		pw.printf("    %d. %s = %s\t%s\n", i+1, strings.Join(v.Names, ", "), v.Expr, position(v.Pos))
+	}
+
+	pw.printf("  init functions, in call order:\n")
+	if len(p.Inits) == 0 {
+		pw.printf("    (none)\n")
+	}
+	for i, f := range p.Inits {
+		pw.printf("    %d. init\t%s\n", i+1, position(f.Pos))
+	}
+
+	for _, c := range p.Cycles {
+		pw.printf("  initialization cycle at %s:\n", position(c.Pos))
+		for _, step := range c.Steps {
+			pw.printf("    %s\n", step)
+		}
+	}
+	for _, err := range p.Errors {
+		pw.printf("  error: %v\n", err)
+	}
+}
+
+func position(pos token.Position) string {
+	return fmt.Sprintf("%s:%d", filepath.Base(pos.Filename), pos.Line)
+}
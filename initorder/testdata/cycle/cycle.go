@@ -0,0 +1,9 @@
+package cycle
+
+var a = b + 1
+
+var b = f()
+
+var c = c
+
+func f() int { return a }
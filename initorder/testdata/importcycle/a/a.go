@@ -0,0 +1,5 @@
+package a
+
+import "github.com/SamHwang1990/go-tour/initorder/testdata/importcycle/b"
+
+var A = b.B
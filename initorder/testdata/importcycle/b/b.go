@@ -0,0 +1,5 @@
+package b
+
+import "github.com/SamHwang1990/go-tour/initorder/testdata/importcycle/a"
+
+var B = a.A
@@ -0,0 +1,15 @@
+package order
+
+import "strings"
+
+var second = len(strings.Fields("a b"))
+
+var unset int
+
+func init() {
+	unset = total
+}
+
+func init() {
+	unset++
+}
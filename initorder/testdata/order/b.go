@@ -0,0 +1,13 @@
+package order
+
+import "fmt"
+
+var (
+	total = sum + 1
+	sum   = first + second
+	first = 1
+)
+
+func init() {
+	fmt.Println("b.go init")
+}
@@ -0,0 +1,187 @@
+/*
+Package i18n 管理课程的翻译目录（catalog），支持中英文对照阅读
+
+	翻译目录约定：
+		* 章节 doc comment 使用中文编写，即源语言为 zh
+		* 每个章节、每种语言一个翻译目录：`<chapter>/i18n/<lang>.json`，比如 `07-slices/i18n/en.json`
+		* 翻译目录按小节组织，以小节 ID 为 key，课程标题以及课程简介的 key 为 `_intro`
+		* 每个小节只翻译标题以及普通文本，代码块、SpecBlock 保持原样：
+			** source 为翻译所依据的原文，由 Sync 维护
+			** translation 为译文，译文的 blocks 与原文的 blocks 一一对应
+			** outdated 表示原文在翻译之后发生了变化，需要更新译文，更新后删除该字段即可
+
+	保持同步：
+		修改章节 doc comment 之后，运行 `gotour i18n sync` 更新翻译目录：
+			* 新增的小节会加入到翻译目录中，译文为空
+			* 原文有变化的小节，更新 source，并将已有的译文标记为 outdated
+			* 已经不存在的小节会从翻译目录中删除
+*/
+package i18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/SamHwang1990/go-tour/chapter"
+	"github.com/SamHwang1990/go-tour/lesson"
+)
+
+const (
+	// Source 为章节 doc comment 所使用的语言
+	Source = "zh"
+
+	// Both 表示中英文对照显示，原文之后紧跟英文译文
+	Both = "both"
+
+	// IntroID 为课程标题以及课程简介在翻译目录中的 key
+	IntroID = "_intro"
+)
+
+// Languages 为支持的翻译语言
+var Languages = []string{"en"}
+
+// Valid 判断 lang 是否为可以显示的语言：Source、Languages 中的语言或者 Both
+func Valid(lang string) bool {
+	if lang == Source || lang == Both {
+		return true
+	}
+	for _, l := range Languages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// Catalog 为一个章节在某种语言下的翻译目录
+type Catalog struct {
+	Chapter  string   `json:"chapter"`
+	Lang     string   `json:"lang"`
+	Sections []*Entry `json:"sections"` // 按文档顺序排列
+}
+
+// Entry 为一个小节的翻译
+type Entry struct {
+	ID          string `json:"id"`
+	Source      Text   `json:"source"`
+	Translation Text   `json:"translation"`
+	Outdated    bool   `json:"outdated,omitempty"`
+}
+
+// Text 为小节中需要翻译的内容：标题以及普通文本
+type Text struct {
+	Title  string   `json:"title"`
+	Blocks []string `json:"blocks,omitempty"`
+}
+
+func (t Text) empty() bool {
+	return t.Title == "" && len(t.Blocks) == 0
+}
+
+func (t Text) equal(other Text) bool {
+	if t.Title != other.Title || len(t.Blocks) != len(other.Blocks) {
+		return false
+	}
+	for i := range t.Blocks {
+		if t.Blocks[i] != other.Blocks[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// complete 表示 t 为 source 的完整译文：标题以及每段普通文本都已翻译
+func (t Text) complete(source Text) bool {
+	if t.Title == "" || len(t.Blocks) != len(source.Blocks) {
+		return false
+	}
+	for _, b := range t.Blocks {
+		if b == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Translated 表示小节已经完整翻译，且译文没有过期
+func (e *Entry) Translated() bool {
+	return e.Translation.complete(e.Source) && !e.Outdated
+}
+
+// Entry 按小节 ID 查找翻译，找不到时返回 nil
+func (c *Catalog) Entry(id string) *Entry {
+	for _, e := range c.Sections {
+		if e.ID == id {
+			return e
+		}
+	}
+	return nil
+}
+
+// Path 返回章节在指定语言下的翻译目录文件路径
+func Path(c chapter.Chapter, lang string) string {
+	return filepath.Join(c.Dir, "i18n", lang+".json")
+}
+
+// Load 读取章节在指定语言下的翻译目录，文件不存在时返回空的翻译目录
+func Load(c chapter.Chapter, lang string) (*Catalog, error) {
+	cat := &Catalog{Chapter: c.Name(), Lang: lang}
+	data, err := os.ReadFile(Path(c, lang))
+	if os.IsNotExist(err) {
+		return cat, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, cat); err != nil {
+		return nil, fmt.Errorf("%s: %v", Path(c, lang), err)
+	}
+	return cat, nil
+}
+
+// Save 将翻译目录写回文件
+func (cat *Catalog) Save(c chapter.Chapter) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(cat); err != nil {
+		return err
+	}
+
+	path := Path(c, cat.Lang)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// unit 为课程中一个可翻译的单元：课程简介或者小节
+type unit struct {
+	id     string
+	text   Text
+	blocks []lesson.Block // 单元中的所有 Block，普通文本按顺序对应 text.Blocks
+}
+
+// units 按文档顺序返回课程中所有可翻译的单元
+func units(l *lesson.Lesson) []unit {
+	list := []unit{{id: IntroID, text: sourceText(l.Title, l.Intro), blocks: l.Intro}}
+	l.Walk(func(s *lesson.Section) bool {
+		list = append(list, unit{id: s.ID, text: sourceText(s.Title, s.Blocks), blocks: s.Blocks})
+		return true
+	})
+	return list
+}
+
+func sourceText(title string, blocks []lesson.Block) Text {
+	t := Text{Title: title}
+	for _, b := range blocks {
+		if b.Spec == nil && b.Example == nil {
+			t.Blocks = append(t.Blocks, b.Text)
+		}
+	}
+	return t
+}
@@ -0,0 +1,120 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/SamHwang1990/go-tour/chapter"
+	"github.com/SamHwang1990/go-tour/lesson"
+)
+
+// TestCatalogsInSync 检查每个章节的翻译目录都与 doc comment 同步，修改 doc comment 之后需要运行 `gotour i18n sync`
+func TestCatalogsInSync(t *testing.T) {
+	chapters, err := chapter.Discover("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range chapters {
+		for _, lang := range Languages {
+			cat, err := Load(c, lang)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cov := Check(cat, lesson.Load(c)); cov.Unsynced {
+				t.Errorf("%s is out of sync with %s/%s, run `gotour i18n sync`", Path(c, lang), c.Name(), c.DocFile)
+			}
+			for _, e := range cat.Sections {
+				if n := len(e.Translation.Blocks); n > 0 && n != len(e.Source.Blocks) {
+					t.Errorf("%s: %s has %d translated blocks, want %d", Path(c, lang), e.ID, n, len(e.Source.Blocks))
+				}
+			}
+		}
+	}
+}
+
+const doc = `
+Arrays:
+
+	简介
+
+	特性：
+		数组长度固定
+
+		` + "```go" + `
+		var a [3]int
+		` + "```" + `
+
+		数组属于值类型
+
+	比较：
+		只有类型相同的数组才能比较
+`
+
+func TestSync(t *testing.T) {
+	cat := &Catalog{Lang: "en"}
+	r := Sync(cat, lesson.Parse(doc, 1))
+	if len(r.Added) != 3 || !r.Changed() {
+		t.Fatalf("first sync: %+v", r)
+	}
+	if got := cat.Entry("特性").Source.Blocks; len(got) != 2 {
+		t.Fatalf("source blocks of 特性 = %q, want 2 text blocks", got)
+	}
+
+	cat.Entry("特性").Translation = Text{Title: "Characteristics", Blocks: []string{"fixed length", "value type"}}
+	if r := Sync(cat, lesson.Parse(doc, 1)); r.Changed() {
+		t.Errorf("sync without changes: %+v", r)
+	}
+
+	changed := `
+Arrays:
+
+	简介
+
+	特性：
+		数组长度固定，不可修改
+
+	零值：
+		元素均为 zero value
+`
+	r = Sync(cat, lesson.Parse(changed, 1))
+	if len(r.Added) != 1 || len(r.Outdated) != 1 || len(r.Removed) != 1 {
+		t.Fatalf("sync after changes: %+v", r)
+	}
+	if e := cat.Entry("特性"); !e.Outdated || e.Translated() {
+		t.Errorf("特性 should be outdated: %+v", e)
+	}
+	if cat.Entry("比较") != nil {
+		t.Errorf("removed section 比较 is still in the catalog")
+	}
+
+	cov := Check(cat, lesson.Parse(changed, 1))
+	if cov.Unsynced || cov.Total != 3 || len(cov.Outdated) != 1 || len(cov.Untranslated) != 2 {
+		t.Errorf("coverage: %+v", cov)
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	l := lesson.Parse(doc, 1)
+	cat := &Catalog{Lang: "en"}
+	Sync(cat, l)
+	cat.Entry(IntroID).Translation = Text{Title: "Arrays", Blocks: []string{"Intro"}}
+	cat.Entry("特性").Translation = Text{Title: "Characteristics", Blocks: []string{"fixed length", "value type"}}
+	cat.Entry("比较").Translation = Text{Title: "Comparison", Blocks: []string{"too", "many"}}
+
+	en := Localize(l, cat)
+	s := en.Section("特性")
+	if s.Title != "Characteristics" || s.Blocks[0].Text != "fixed length" || s.Blocks[1].Example == nil || s.Blocks[2].Text != "value type" {
+		t.Errorf("localized 特性: %+v", s)
+	}
+	if s := en.Section("比较"); s.Title != "Comparison" || s.Blocks[0].Text != l.Section("比较").Blocks[0].Text {
+		t.Errorf("mismatched blocks should keep the source text: %+v", s)
+	}
+	if l.Section("特性").Title != "特性" {
+		t.Errorf("Localize modified the source lesson")
+	}
+
+	both := Bilingual(l, cat)
+	s = both.Section("特性")
+	if s.Title != "特性 / Characteristics" || len(s.Blocks) != 5 || s.Blocks[1].Text != "fixed length" {
+		t.Errorf("bilingual 特性: %q %+v", s.Title, s.Blocks)
+	}
+}
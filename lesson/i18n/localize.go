@@ -0,0 +1,112 @@
+package i18n
+
+import (
+	"github.com/SamHwang1990/go-tour/chapter"
+	"github.com/SamHwang1990/go-tour/lesson"
+)
+
+// LoadLesson 按语言加载章节课程：Source 为原文，Both 为中英文对照，其他语言使用对应的译文
+func LoadLesson(c chapter.Chapter, lang string) (*lesson.Lesson, error) {
+	l := lesson.Load(c)
+	switch lang {
+	case Source:
+		return l, nil
+	case Both:
+		cat, err := Load(c, "en")
+		if err != nil {
+			return nil, err
+		}
+		return Bilingual(l, cat), nil
+	default:
+		cat, err := Load(c, lang)
+		if err != nil {
+			return nil, err
+		}
+		return Localize(l, cat), nil
+	}
+}
+
+// Localize 返回使用译文替换标题以及普通文本之后的课程，不修改 l，
+// 没有译文的小节保留原文；译文的 blocks 数量与原文不一致时，只替换标题
+func Localize(l *lesson.Lesson, cat *Catalog) *lesson.Lesson {
+	return transform(l, cat, localize)
+}
+
+// Bilingual 返回中英文对照的课程，不修改 l：
+// 标题为 "原文 / 译文"，每段普通文本之后紧跟对应的译文，没有译文的小节只保留原文
+func Bilingual(l *lesson.Lesson, cat *Catalog) *lesson.Lesson {
+	return transform(l, cat, pair)
+}
+
+type transformFunc func(title string, blocks []lesson.Block, e *Entry) (string, []lesson.Block)
+
+// transform 复制课程，并使用 fn 转换课程简介以及每个有翻译的小节
+func transform(l *lesson.Lesson, cat *Catalog, fn transformFunc) *lesson.Lesson {
+	out := *l
+	if cat == nil || cat.Lang == Source {
+		return &out
+	}
+
+	if e := cat.Entry(IntroID); e != nil {
+		out.Title, out.Intro = fn(l.Title, l.Intro, e)
+	}
+
+	var sections func([]*lesson.Section) []*lesson.Section
+	sections = func(list []*lesson.Section) []*lesson.Section {
+		if list == nil {
+			return nil
+		}
+		copies := make([]*lesson.Section, len(list))
+		for i, s := range list {
+			copied := *s
+			if e := cat.Entry(s.ID); e != nil {
+				copied.Title, copied.Blocks = fn(s.Title, s.Blocks, e)
+			}
+			copied.Sections = sections(s.Sections)
+			copies[i] = &copied
+		}
+		return copies
+	}
+	out.Sections = sections(l.Sections)
+
+	return &out
+}
+
+func localize(title string, blocks []lesson.Block, e *Entry) (string, []lesson.Block) {
+	t := e.Translation
+	if t.Title != "" {
+		title = t.Title
+	}
+	if len(t.Blocks) == 0 || len(t.Blocks) != len(sourceText("", blocks).Blocks) {
+		return title, blocks
+	}
+
+	out := make([]lesson.Block, len(blocks))
+	next := 0
+	for i, b := range blocks {
+		if b.Spec == nil && b.Example == nil {
+			if text := t.Blocks[next]; text != "" {
+				b.Text = text
+			}
+			next++
+		}
+		out[i] = b
+	}
+	return title, out
+}
+
+func pair(title string, blocks []lesson.Block, e *Entry) (string, []lesson.Block) {
+	translatedTitle, translated := localize(title, blocks, e)
+	if translatedTitle != title {
+		title += " / " + translatedTitle
+	}
+
+	var out []lesson.Block
+	for i, b := range blocks {
+		out = append(out, b)
+		if t := translated[i]; b.Spec == nil && b.Example == nil && t.Text != b.Text {
+			out = append(out, t)
+		}
+	}
+	return title, out
+}
@@ -0,0 +1,101 @@
+package i18n
+
+import (
+	"github.com/SamHwang1990/go-tour/lesson"
+)
+
+// SyncResult 为 Sync 对翻译目录的修改，均为小节 ID
+type SyncResult struct {
+	Added    []string
+	Outdated []string // 原文发生变化，且已有译文的小节
+	Updated  []string // 原文发生变化，但还没有译文的小节
+	Removed  []string
+}
+
+// Changed 表示翻译目录被修改，需要保存
+func (r SyncResult) Changed() bool {
+	return len(r.Added)+len(r.Outdated)+len(r.Updated)+len(r.Removed) > 0
+}
+
+// Sync 根据课程的当前内容更新翻译目录，参考 package 文档
+func Sync(cat *Catalog, l *lesson.Lesson) SyncResult {
+	var r SyncResult
+	old := map[string]*Entry{}
+	for _, e := range cat.Sections {
+		old[e.ID] = e
+	}
+
+	var sections []*Entry
+	for _, u := range units(l) {
+		e, ok := old[u.id]
+		switch {
+		case !ok:
+			e = &Entry{ID: u.id, Source: u.text}
+			r.Added = append(r.Added, u.id)
+		case !e.Source.equal(u.text):
+			e.Source = u.text
+			if e.Translation.empty() {
+				r.Updated = append(r.Updated, u.id)
+			} else {
+				e.Outdated = true
+				r.Outdated = append(r.Outdated, u.id)
+			}
+		}
+		delete(old, u.id)
+		sections = append(sections, e)
+	}
+
+	for _, e := range cat.Sections {
+		if _, ok := old[e.ID]; ok {
+			r.Removed = append(r.Removed, e.ID)
+		}
+	}
+
+	// 文档顺序变化（比如调整了小节顺序）也需要保存
+	if !r.Changed() {
+		for i, e := range sections {
+			if cat.Sections[i] != e {
+				r.Updated = append(r.Updated, e.ID)
+			}
+		}
+	}
+
+	cat.Sections = sections
+	return r
+}
+
+// Coverage 为一个章节在某种语言下的翻译进度
+type Coverage struct {
+	Chapter      string
+	Lang         string
+	Total        int
+	Translated   int
+	Untranslated []*Entry // 没有译文，或者只翻译了部分内容的小节
+	Outdated     []*Entry // 译文已过期的小节
+	Unsynced     bool     // 翻译目录与课程内容不同步，需要运行 sync
+}
+
+// Check 统计翻译进度，不修改 cat
+func Check(cat *Catalog, l *lesson.Lesson) Coverage {
+	cov := Coverage{Chapter: cat.Chapter, Lang: cat.Lang}
+
+	synced := &Catalog{Chapter: cat.Chapter, Lang: cat.Lang}
+	for _, e := range cat.Sections {
+		copied := *e
+		synced.Sections = append(synced.Sections, &copied)
+	}
+	cov.Unsynced = Sync(synced, l).Changed()
+
+	for _, e := range synced.Sections {
+		cov.Total++
+		switch {
+		case e.Outdated:
+			cov.Outdated = append(cov.Outdated, e)
+		case !e.Translated():
+			cov.Untranslated = append(cov.Untranslated, e)
+		default:
+			cov.Translated++
+		}
+	}
+	return cov
+}
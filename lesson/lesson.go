@@ -0,0 +1,150 @@
+/*
+Package lesson 将章节开头的 doc comment 解析为结构化的课程数据
+
+	章节 doc comment 的结构约定：
+		* 第一行非空文本为课程标题，比如 `Slices:`
+		* 标题之后、第一个小节之前的内容为课程简介
+		* 缩进不超过一级，且下一行内容缩进更深的行为小节标题，比如 `zero value:`、`值类型，非引用类型：`
+		* 使用 ``` 包围的内容为代码块，开头的 ``` 后面可以声明语言以及属性，比如 "```go sketch"：
+			** 若代码块的每一行都是 EBNF 产生式，比如 `ForStmt = "for" [ Condition | ForClause | RangeClause ] Block .`，
+				则视为 SpecBlock
+			** 否则视为 Example
+		* 只包含一个 EBNF 产生式的行，比如 "spec: ` SwitchStmt = ExprSwitchStmt | TypeSwitchStmt . `"，也视为 SpecBlock
+		* `参考文章` 小节中的链接为参考文章列表
+
+	解析结果可以导出为 JSON（结构体自带 json tag）或 Markdown（参考 WriteMarkdown）
+*/
+package lesson
+
+import (
+	"github.com/SamHwang1990/go-tour/chapter"
+)
+
+// Lesson 为一个章节的课程数据
+type Lesson struct {
+	Chapter    string      `json:"chapter"` // 章节目录名，比如 "07-slices"
+	Title      string      `json:"title"`
+	File       string      `json:"file"` // doc comment 所在文件，相对于章节目录
+	Line       int         `json:"line"`
+	Intro      []Block     `json:"intro,omitempty"`
+	Sections   []*Section  `json:"sections,omitempty"`
+	References []Reference `json:"references,omitempty"`
+}
+
+// Section 为课程中的一个小节
+type Section struct {
+	ID       string     `json:"id"`    // 小节标识，由标题生成，在课程内唯一
+	Title    string     `json:"title"` // 去掉末尾冒号的标题
+	Level    int        `json:"level"` // 顶级小节为 1
+	Line     int        `json:"line"`
+	Blocks   []Block    `json:"blocks,omitempty"`
+	Sections []*Section `json:"sections,omitempty"`
+}
+
+// Block 为小节中的一段内容，Text、Spec、Example 三者只有一个不为空
+type Block struct {
+	Text    string     `json:"text,omitempty"` // 普通文本，保留相对缩进
+	Spec    *SpecBlock `json:"spec,omitempty"`
+	Example *Example   `json:"example,omitempty"`
+}
+
+// SpecBlock 为引用自 golang spec 的语法定义
+type SpecBlock struct {
+	Source string `json:"source"`
+	Line   int    `json:"line"`
+	Rules  []Rule `json:"rules"`
+}
+
+// Rule 为一个 EBNF 产生式：` Name = Expr . `
+type Rule struct {
+	Name string `json:"name"`
+	Expr string `json:"expr"`
+}
+
+// Example 为代码块
+type Example struct {
+	Lang  string   `json:"lang,omitempty"`  // 代码块声明的语言，比如 "go"
+	Attrs []string `json:"attrs,omitempty"` // 语言之后声明的属性，比如 "sketch" 表示包含占位符的示意代码
+	Code  string   `json:"code"`            // 去掉公共缩进后的代码
+	Line  int      `json:"line"`            // 代码第一行在文件中的行号
+}
+
+// Has 判断代码块是否声明了指定属性
+func (e *Example) Has(attr string) bool {
+	for _, a := range e.Attrs {
+		if a == attr {
+			return true
+		}
+	}
+	return false
+}
+
+// Reference 为参考文章
+type Reference struct {
+	Title string `json:"title,omitempty"`
+	URL   string `json:"url"`
+}
+
+// Load 解析章节的 doc comment
+func Load(c chapter.Chapter) *Lesson {
+	l := Parse(c.Doc, c.DocLine)
+	l.Chapter = c.Name()
+	l.File = c.DocFile
+	if l.Title == "" {
+		l.Title = c.Title
+	}
+	return l
+}
+
+// LoadAll 解析所有章节的 doc comment
+func LoadAll(chapters []chapter.Chapter) []*Lesson {
+	lessons := make([]*Lesson, 0, len(chapters))
+	for _, c := range chapters {
+		lessons = append(lessons, Load(c))
+	}
+	return lessons
+}
+
+// Walk 按文档顺序遍历所有小节，fn 返回 false 时不再遍历该小节的子小节
+func (l *Lesson) Walk(fn func(s *Section) bool) {
+	var walk func(sections []*Section)
+	walk = func(sections []*Section) {
+		for _, s := range sections {
+			if fn(s) {
+				walk(s.Sections)
+			}
+		}
+	}
+	walk(l.Sections)
+}
+
+// Section 按小节 ID 或标题查找小节，找不到时返回 nil
+func (l *Lesson) Section(key string) *Section {
+	var found *Section
+	l.Walk(func(s *Section) bool {
+		if found == nil && (s.ID == key || s.Title == key) {
+			found = s
+		}
+		return found == nil
+	})
+	return found
+}
+
+// Examples 按文档顺序返回课程中的所有代码块
+func (l *Lesson) Examples() []*Example {
+	var examples []*Example
+	collect := func(blocks []Block) {
+		for _, b := range blocks {
+			if b.Example != nil {
+				examples = append(examples, b.Example)
+			}
+		}
+	}
+
+	collect(l.Intro)
+	l.Walk(func(s *Section) bool {
+		collect(s.Blocks)
+		return true
+	})
+	return examples
+}
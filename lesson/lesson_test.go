@@ -0,0 +1,208 @@
+package lesson
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/SamHwang1990/go-tour/chapter"
+)
+
+// 运行 `go test ./lesson -update` 重新生成 testdata 中的 golden 文件
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// loadFixture 解析 testdata/01-fixture 中的示例章节
+func loadFixture(t *testing.T) (chapter.Chapter, *Lesson) {
+	t.Helper()
+	c, err := chapter.Load(filepath.Join("testdata", "01-fixture"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, Load(c)
+}
+
+func TestLoadHeadings(t *testing.T) {
+	_, l := loadFixture(t)
+	if l.Chapter != "01-fixture" || l.Title != "Fixture" || l.File != "fixture.go" || l.Line != 1 {
+		t.Errorf("lesson = %s %q %s:%d, want 01-fixture \"Fixture\" fixture.go:1", l.Chapter, l.Title, l.File, l.Line)
+	}
+	if len(l.Intro) != 1 || l.Intro[0].Text != "课程简介，说明 lesson 测试用到的结构" {
+		t.Errorf("intro = %+v", l.Intro)
+	}
+
+	type heading struct {
+		id, title   string
+		level, line int
+	}
+	var got []heading
+	l.Walk(func(s *Section) bool {
+		got = append(got, heading{s.ID, s.Title, s.Level, s.Line})
+		return true
+	})
+	want := []heading{
+		{"数组", "数组", 1, 7},
+		{"zero-value", "zero value", 2, 8},
+		{"值类型-非引用类型", "值类型，非引用类型", 2, 21},
+		{"zero-value-2", "zero value", 2, 40},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("sections = %+v, want %+v", got, want)
+	}
+	if s := l.Section("值类型，非引用类型"); s == nil || s.ID != "值类型-非引用类型" {
+		t.Errorf("Section(title) = %+v", s)
+	}
+
+	wantRefs := []Reference{
+		{Title: "Go Slices: usage and internals", URL: "https://go.dev/blog/slices-intro"},
+		{URL: "https://go.dev/ref/spec"},
+	}
+	if !slices.Equal(l.References, wantRefs) {
+		t.Errorf("references = %+v, want %+v", l.References, wantRefs)
+	}
+}
+
+// TestLoadText 检查普通文本去掉公共缩进后保留 tab 缩进的嵌套列表
+func TestLoadText(t *testing.T) {
+	_, l := loadFixture(t)
+	blocks := l.Section("zero-value").Blocks
+	want := strings.Join([]string{
+		"普通文本",
+		"\t缩进更深的文本保留相对缩进",
+		"",
+		"列表：",
+		"\t* 一级列表项",
+		"\t\t** 二级列表项",
+		"\t\t\t*** 三级列表项",
+		"\t\t列表项的后续内容",
+		"\t* 另一个一级列表项",
+		"- 短横线列表项",
+		"\t-- 嵌套的短横线列表项",
+	}, "\n")
+	if len(blocks) != 1 || blocks[0].Text != want {
+		t.Errorf("blocks = %+v, want one text block %q", blocks, want)
+	}
+}
+
+func TestLoadFencedBlocks(t *testing.T) {
+	_, l := loadFixture(t)
+	blocks := l.Section("值类型-非引用类型").Blocks
+	if len(blocks) != 4 {
+		t.Fatalf("got %d blocks, want 4: %+v", len(blocks), blocks)
+	}
+
+	for i, want := range []SpecBlock{
+		{Source: `ArrayType = "[" ArrayLength "]" ElementType .`, Line: 22,
+			Rules: []Rule{{"ArrayType", `"[" ArrayLength "]" ElementType`}}},
+		{Source: "ForStmt = \"for\" [ Condition | ForClause | RangeClause ] Block .\nCondition = Expression .", Line: 25,
+			Rules: []Rule{{"ForStmt", `"for" [ Condition | ForClause | RangeClause ] Block`}, {"Condition", "Expression"}}},
+	} {
+		got := blocks[i].Spec
+		if got == nil || got.Source != want.Source || got.Line != want.Line || !slices.Equal(got.Rules, want.Rules) {
+			t.Errorf("blocks[%d].Spec = %+v, want %+v", i, got, want)
+		}
+	}
+
+	examples := l.Examples()
+	want := []Example{
+		{Lang: "go", Attrs: []string{"sketch"}, Code: "for i := range … {\n\t...\n}", Line: 30},
+		{Lang: "go", Code: "arr := [3]int{1, 2, 3}\nfmt.Println(arr)", Line: 36},
+	}
+	if len(examples) != len(want) {
+		t.Fatalf("Examples() = %+v, want %+v", examples, want)
+	}
+	for i, e := range examples {
+		if e.Lang != want[i].Lang || !slices.Equal(e.Attrs, want[i].Attrs) || e.Code != want[i].Code || e.Line != want[i].Line {
+			t.Errorf("Examples()[%d] = %+v, want %+v", i, e, want[i])
+		}
+	}
+	if !examples[0].Has("sketch") || examples[1].Has("sketch") {
+		t.Errorf("Has(\"sketch\") = %v, %v, want true, false", examples[0].Has("sketch"), examples[1].Has("sketch"))
+	}
+}
+
+// TestLoadDemos 检查 demo 函数的 `//gotour:` 指令由 chapter 解析，不会出现在课程内容中
+func TestLoadDemos(t *testing.T) {
+	c, l := loadFixture(t)
+	var names []string
+	for _, d := range c.Demos {
+		names = append(names, d.Name)
+	}
+	if !slices.Equal(names, []string{"orderedDemo", "unorderedDemo"}) {
+		t.Errorf("demos = %q, want [orderedDemo unorderedDemo]", names)
+	}
+	if d, _ := c.Demo("unorderedDemo"); !d.Has("unordered") {
+		t.Errorf("unorderedDemo directives = %q, want [unordered]", d.Directives)
+	}
+	if d, _ := c.Demo("orderedDemo"); len(d.Directives) != 0 {
+		t.Errorf("orderedDemo directives = %q, want none", d.Directives)
+	}
+
+	var md bytes.Buffer
+	if err := l.WriteMarkdown(&md); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(md.String(), "gotour:") {
+		t.Errorf("markdown contains a //gotour: directive:\n%s", md.String())
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	_, l := loadFixture(t)
+	var got bytes.Buffer
+	if err := l.WriteMarkdown(&got); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join("testdata", "fixture.md")
+	if *update {
+		if err := os.WriteFile(path, got.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run `go test ./lesson -update` to create it)", err)
+	}
+	if got.String() != string(want) {
+		t.Errorf("WriteMarkdown() differs from %s:\n%s", path, got.String())
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	for _, tt := range []struct {
+		name, text, want string
+	}{
+		{
+			name: "nested",
+			text: "* a\n\t** b\n\t\t*** c\n\t** d",
+			want: "- a\n  - b\n    - c\n  - d\n",
+		},
+		{
+			name: "continuation",
+			text: "* a\n\t续行\n\t** b\n\t\t*** c\n\t续行属于 a",
+			want: "- a\n  续行  \n  - b\n    - c\n  续行属于 a  \n",
+		},
+		{
+			name: "text ends the list",
+			text: "* a\n正文\n-- b",
+			want: "- a\n正文  \n- b\n",
+		},
+		{
+			name: "quote is not a list",
+			text: "> 引用\n\t* a",
+			want: "> 引用  \n- a\n",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			writeText(&b, tt.text)
+			if b.String() != tt.want {
+				t.Errorf("writeText(%q) = %q, want %q", tt.text, b.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package lesson
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteMarkdown 将课程导出为 Markdown：
+//   - 课程标题为一级标题，小节标题按层级依次降级
+//   - doc comment 中的 `*`、`**`、`-`、`--` 等列表符号转换为 Markdown 的嵌套列表
+//   - SpecBlock 输出为 ebnf 代码块，Example 保留原有的语言声明
+func (l *Lesson) WriteMarkdown(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "# %s\n", l.Title)
+	writeBlocks(bw, l.Intro)
+
+	var writeSections func(sections []*Section)
+	writeSections = func(sections []*Section) {
+		for _, s := range sections {
+			fmt.Fprintf(bw, "\n%s %s\n", strings.Repeat("#", s.Level+1), s.Title)
+			writeBlocks(bw, s.Blocks)
+			writeSections(s.Sections)
+		}
+	}
+	writeSections(l.Sections)
+
+	if len(l.References) > 0 {
+		fmt.Fprintf(bw, "\n## %s\n\n", referencesTitle)
+		for _, r := range l.References {
+			if r.Title == "" {
+				fmt.Fprintf(bw, "- <%s>\n", r.URL)
+			} else {
+				fmt.Fprintf(bw, "- [%s](%s)\n", r.Title, r.URL)
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+func writeBlocks(w io.Writer, blocks []Block) {
+	for _, b := range blocks {
+		fmt.Fprintln(w)
+		switch {
+		case b.Spec != nil:
+			fmt.Fprintf(w, "```ebnf\n%s\n```\n", b.Spec.Source)
+		case b.Example != nil:
+			fmt.Fprintf(w, "```%s\n%s\n```\n", b.Example.Lang, b.Example.Code)
+		default:
+			writeText(w, b.Text)
+		}
+	}
+}
+
+// writeText 输出普通文本，列表项按缩进转换为嵌套列表，其他行使用硬换行，
+// 缩进在列表项之下的普通行作为该列表项的后续内容
+func writeText(w io.Writer, text string) {
+	depth := -1 // 上一个列表项的嵌套深度，-1 表示不在列表中
+	indentOf := map[int]int{}
+
+	for _, s := range strings.Split(text, "\n") {
+		content := strings.TrimSpace(s)
+		if content == "" {
+			fmt.Fprintln(w)
+			continue
+		}
+		indent := columns(s[:len(s)-len(strings.TrimLeft(s, " \t"))])
+
+		if m := listMarkerPattern.FindStringSubmatch(content); m != nil && m[1] != ">" {
+			d := 0
+			for prev := depth; prev >= 0; prev-- {
+				if indent > indentOf[prev] {
+					d = prev + 1
+					break
+				}
+			}
+			depth = d
+			indentOf[d] = indent
+			fmt.Fprintf(w, "%s- %s\n", strings.Repeat("  ", d), strings.TrimSpace(content[len(m[0]):]))
+			continue
+		}
+
+		// 后续内容属于缩进比它浅的最近一个列表项，更深的列表项到此结束
+		for depth >= 0 && indent <= indentOf[depth] {
+			depth--
+		}
+		if depth >= 0 {
+			fmt.Fprintf(w, "%s%s  \n", strings.Repeat("  ", depth+1), content)
+			continue
+		}
+		fmt.Fprintf(w, "%s  \n", content)
+	}
+}
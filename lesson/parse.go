@@ -0,0 +1,322 @@
+package lesson
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	// EBNF 产生式，比如：ForStmt = "for" [ Condition | ForClause | RangeClause ] Block .
+	productionPattern = regexp.MustCompile(`^([A-Z][A-Za-z_]*)\s*=\s*(.+?)\s*\.$`)
+
+	// 只包含一个 EBNF 产生式的行，比如：spec: ` SwitchStmt = ExprSwitchStmt | TypeSwitchStmt . `
+	inlineSpecPattern = regexp.MustCompile("^(?:spec\\s*[:：]\\s*)?`\\s*([A-Z][A-Za-z_]*\\s*=.+\\.)\\s*`$")
+
+	linkPattern = regexp.MustCompile(`\[([^\]]*)\]\((\S+?)\)`)
+	urlPattern  = regexp.MustCompile(`https?://\S+`)
+
+	listMarkerPattern = regexp.MustCompile(`^(\*+|-+|>)\s`)
+)
+
+const referencesTitle = "参考文章"
+
+type line struct {
+	no     int    // 在文件中的行号
+	indent int    // 缩进的列数，tab 按 4 列计算
+	prefix string // 行首空白
+	text   string // 去掉首尾空白后的内容
+}
+
+func (l line) blank() bool {
+	return l.text == ""
+}
+
+func (l line) fence() bool {
+	return strings.HasPrefix(l.text, "```")
+}
+
+// Parse 解析 doc comment 的原始文本，firstLine 为 doc 第一行在文件中的行号
+func Parse(doc string, firstLine int) *Lesson {
+	p := &parser{
+		lesson: &Lesson{Line: firstLine},
+		lines:  splitLines(doc, firstLine),
+		ids:    map[string]int{},
+	}
+	p.parse()
+	return p.lesson
+}
+
+type parser struct {
+	lesson *Lesson
+	lines  []line
+	base   int // 第一行非空文本的缩进，其他行的层级都相对于该缩进计算
+	ids    map[string]int
+
+	top     *Section // 当前顶级（缩进为 0）的小节，标题下的内容为 nil
+	current *Section // 当前小节，nil 表示课程简介
+	inRefs  bool     // 当前是否在参考文章小节中
+	text    []line   // 尚未输出的普通文本
+}
+
+func splitLines(doc string, firstLine int) []line {
+	raw := strings.Split(doc, "\n")
+	lines := make([]line, len(raw))
+	for i, s := range raw {
+		s = strings.TrimRightFunc(s, unicode.IsSpace)
+		text := strings.TrimLeftFunc(s, unicode.IsSpace)
+		prefix := s[:len(s)-len(text)]
+		lines[i] = line{no: firstLine + i, indent: columns(prefix), prefix: prefix, text: text}
+	}
+	return lines
+}
+
+func columns(prefix string) int {
+	n := 0
+	for _, r := range prefix {
+		if r == '\t' {
+			n += 4 - n%4
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// level 返回行的缩进层级，从 0 开始
+func (p *parser) level(l line) int {
+	return (l.indent - p.base) / 4
+}
+
+func (p *parser) parse() {
+	titled := false
+	for i := 0; i < len(p.lines); i++ {
+		l := p.lines[i]
+
+		switch {
+		case l.blank():
+			if len(p.text) > 0 {
+				p.text = append(p.text, l)
+			}
+
+		case !titled:
+			p.base = l.indent
+			p.lesson.Title = headingTitle(l.text)
+			titled = true
+
+		case l.fence():
+			p.flush()
+			i = p.fenceBlock(i)
+
+		case p.heading(i):
+			p.flush()
+			p.section(l)
+
+		case p.inRefs:
+			p.reference(l)
+
+		case inlineSpecPattern.MatchString(l.text):
+			p.flush()
+			m := inlineSpecPattern.FindStringSubmatch(l.text)
+			p.add(Block{Spec: &SpecBlock{
+				Source: m[1],
+				Line:   l.no,
+				Rules:  []Rule{rule(m[1])},
+			}})
+
+		default:
+			p.text = append(p.text, l)
+		}
+	}
+	p.flush()
+}
+
+// heading 判断第 i 行是否为小节标题：
+//   - 缩进层级不超过 1，且不是列表项
+//   - 下一个非空行的缩进更深，或者标题为参考文章
+func (p *parser) heading(i int) bool {
+	l := p.lines[i]
+	if p.level(l) > 1 || listMarkerPattern.MatchString(l.text) {
+		return false
+	}
+	if headingTitle(l.text) == referencesTitle {
+		return true
+	}
+	for _, next := range p.lines[i+1:] {
+		if !next.blank() {
+			return next.indent > l.indent
+		}
+	}
+	return false
+}
+
+func (p *parser) section(l line) {
+	title := headingTitle(l.text)
+	p.inRefs = title == referencesTitle
+	if p.inRefs {
+		p.current = nil
+		return
+	}
+
+	s := &Section{ID: p.id(title), Title: title, Line: l.no}
+	switch {
+	case p.level(l) == 0:
+		s.Level = 1
+		p.lesson.Sections = append(p.lesson.Sections, s)
+		p.top = s
+	case p.top != nil:
+		s.Level = 2
+		p.top.Sections = append(p.top.Sections, s)
+	default:
+		s.Level = 1
+		p.lesson.Sections = append(p.lesson.Sections, s)
+	}
+	p.current = s
+}
+
+// fenceBlock 解析从第 i 行开始的代码块，返回代码块最后一行的索引
+func (p *parser) fenceBlock(i int) int {
+	open := p.lines[i]
+	info := strings.Fields(strings.TrimPrefix(open.text, "```"))
+
+	end := len(p.lines)
+	for j := i + 1; j < len(p.lines); j++ {
+		if p.lines[j].fence() {
+			end = j
+			break
+		}
+	}
+
+	body := p.lines[i+1 : end]
+	code := dedent(body)
+	first := open.no + 1
+
+	if rules, ok := specRules(body); ok {
+		p.add(Block{Spec: &SpecBlock{Source: code, Line: first, Rules: rules}})
+	} else {
+		e := &Example{Code: code, Line: first}
+		if len(info) > 0 {
+			e.Lang, e.Attrs = info[0], info[1:]
+		}
+		p.add(Block{Example: e})
+	}
+
+	return end
+}
+
+func (p *parser) reference(l line) {
+	for _, m := range linkPattern.FindAllStringSubmatch(l.text, -1) {
+		p.lesson.References = append(p.lesson.References, Reference{Title: m[1], URL: m[2]})
+	}
+	if !linkPattern.MatchString(l.text) {
+		if url := urlPattern.FindString(l.text); url != "" {
+			p.lesson.References = append(p.lesson.References, Reference{URL: url})
+		}
+	}
+}
+
+// flush 将尚未输出的普通文本输出为 Text Block
+func (p *parser) flush() {
+	lines := p.text
+	p.text = nil
+	for len(lines) > 0 && lines[len(lines)-1].blank() {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return
+	}
+	p.add(Block{Text: dedent(lines)})
+}
+
+func (p *parser) add(b Block) {
+	switch {
+	case p.inRefs:
+	case p.current != nil:
+		p.current.Blocks = append(p.current.Blocks, b)
+	default:
+		p.lesson.Intro = append(p.lesson.Intro, b)
+	}
+}
+
+// id 由标题生成小节标识，比如 "zero value" => "zero-value"，"值类型，非引用类型" => "值类型-非引用类型"
+func (p *parser) id(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		default:
+			dash = true
+		}
+	}
+
+	id := b.String()
+	if id == "" {
+		id = "section"
+	}
+	p.ids[id]++
+	if n := p.ids[id]; n > 1 {
+		id += "-" + strconv.Itoa(n)
+	}
+	return id
+}
+
+func headingTitle(text string) string {
+	return strings.TrimSpace(strings.TrimRight(text, ":："))
+}
+
+// specRules 若所有非空行都是 EBNF 产生式，则返回产生式列表
+func specRules(lines []line) ([]Rule, bool) {
+	var rules []Rule
+	for _, l := range lines {
+		if l.blank() {
+			continue
+		}
+		if !productionPattern.MatchString(l.text) {
+			return nil, false
+		}
+		rules = append(rules, rule(l.text))
+	}
+	return rules, len(rules) > 0
+}
+
+func rule(source string) Rule {
+	m := productionPattern.FindStringSubmatch(strings.TrimSpace(source))
+	if m == nil {
+		return Rule{Expr: source}
+	}
+	return Rule{Name: m[1], Expr: m[2]}
+}
+
+// dedent 去掉所有非空行的公共行首空白，空行输出为空字符串
+func dedent(lines []line) string {
+	common := ""
+	first := true
+	for _, l := range lines {
+		if l.blank() {
+			continue
+		}
+		if first {
+			common = l.prefix
+			first = false
+			continue
+		}
+		for !strings.HasPrefix(l.prefix, common) {
+			common = common[:len(common)-1]
+		}
+	}
+
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		if !l.blank() {
+			out[i] = strings.TrimPrefix(l.prefix, common) + l.text
+		}
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,71 @@
+/*
+
+Fixture:
+
+	课程简介，说明 lesson 测试用到的结构
+
+数组：
+	zero value:
+		普通文本
+			缩进更深的文本保留相对缩进
+
+		列表：
+			* 一级列表项
+				** 二级列表项
+					*** 三级列表项
+				列表项的后续内容
+			* 另一个一级列表项
+		- 短横线列表项
+			-- 嵌套的短横线列表项
+
+	值类型，非引用类型：
+		spec: ` ArrayType = "[" ArrayLength "]" ElementType . `
+
+		```go
+			ForStmt = "for" [ Condition | ForClause | RangeClause ] Block .
+			Condition = Expression .
+		```
+
+		```go sketch
+			for i := range … {
+				...
+			}
+		```
+
+		```go
+			arr := [3]int{1, 2, 3}
+			fmt.Println(arr)
+		```
+
+	zero value:
+		重复的标题生成不同的 ID
+
+参考文章：
+	[Go Slices: usage and internals](https://go.dev/blog/slices-intro)
+	https://go.dev/ref/spec
+
+*/
+
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("fixture")
+}
+
+func orderedDemo() {
+	fmt.Println("ordered")
+}
+
+// unorderedDemo 的输出顺序不固定
+//
+//gotour:unordered
+func unorderedDemo() {
+	fmt.Println("unordered")
+}
+
+// helper 有参数，不是 demo 函数
+func helper(n int) int {
+	return n
+}
@@ -0,0 +1,500 @@
+package verify
+
+import (
+	"bytes"
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// 代码块中注释的约定，参考 package 文档
+	valueClaimPattern  = regexp.MustCompile(`^(true|false)\b`)
+	panicErrorPattern  = regexp.MustCompile(`(?i)^panic error`)
+	illegalPattern     = regexp.MustCompile(`^illegal\b`)
+	errorCodePattern   = regexp.MustCompile(`^Error:\s*(.+)$`)
+	returnClaimPattern = regexp.MustCompile(`^(\w+) returns (.+)$`)
+	printClaimPattern  = regexp.MustCompile(`^prints?:?\s+(.+)$`)
+	printProsePattern  = regexp.MustCompile(`\s+(?:before|after|when|while|if|because|then|and then)\b.*$`)
+	nameClaimPattern   = regexp.MustCompile(`\b([A-Za-z_]\w*)\s*==\s*(-?\d+(?:\.\d+)?|true|false|"[^"]*")`)
+	parenPattern       = regexp.MustCompile(`[(（][^)）]*[)）]`)
+	funcNamePattern    = regexp.MustCompile(`^func\s+(\w+)`)
+	importPathPattern  = regexp.MustCompile(`"([^"]+)"`)
+)
+
+// 代码块中未 import 就直接使用的标准库 package
+var stdPackages = map[string]string{
+	"errors":  "errors",
+	"fmt":     "fmt",
+	"math":    "math",
+	"os":      "os",
+	"reflect": "reflect",
+	"sort":    "sort",
+	"strconv": "strconv",
+	"strings": "strings",
+	"unicode": "unicode",
+	"utf8":    "unicode/utf8",
+}
+
+type claimKind int
+
+const (
+	claimValue   claimKind = iota // `// true` 注释之后的表达式
+	claimName                     // 行尾注释中的 `name == value`
+	claimReturns                  // `// f returns 42`
+	claimPrints                   // `// prints 3 2 1 0`
+)
+
+type claim struct {
+	id   int
+	kind claimKind
+	line int    // 所在行的索引，从 0 开始
+	expr string // claimName 为变量名，claimReturns、claimPrints 为函数名
+	want string
+}
+
+// errorUnit 为被标记为编译错误的代码
+type errorUnit struct {
+	start, end int    // 行索引区间，包含 end
+	code       string // 不为空时，表示代码写在第 start 行的注释中
+}
+
+// typeDecl 为代码块顶级作用域中的 type 声明或方法声明，同一课程中之后的代码块可以使用
+type typeDecl struct {
+	name   string // 声明的类型名，方法为 receiver 的类型名
+	method bool
+	first  int // 所在代码块第一行在章节文件中的行号，用于区分不同代码块中的同名类型
+	line   int // 声明第一行在章节文件中的行号
+	code   string
+	idents map[string]bool
+}
+
+// snippet 为代码块解析后的结果，所有行号均为相对于代码块第一行的索引
+type snippet struct {
+	file  string
+	first int
+	lines []string
+
+	full       bool // 代码块是完整的 go 文件，以 package 声明开头
+	hasCode    []bool
+	startDepth []int // 行首的括号深度
+	endDepth   []int // 行尾的括号深度
+	hoisted    []bool
+	imports    []string // 需要自动补充的 import
+	idents     map[string]bool
+	declared   map[string]bool // 顶级作用域中声明的类型以及函数
+	decls      []typeDecl      // 可以被之后的代码块使用的声明
+	context    []typeDecl      // 使用的之前代码块中的声明
+
+	claims []claim
+	errors []errorUnit
+}
+
+func parseSnippet(file string, first int, code string) *snippet {
+	s := &snippet{file: file, first: first, lines: strings.Split(code, "\n")}
+	n := len(s.lines)
+	s.hasCode = make([]bool, n)
+	s.startDepth = make([]int, n)
+	s.endDepth = make([]int, n)
+	s.hoisted = make([]bool, n)
+	s.idents = map[string]bool{}
+	s.declared = map[string]bool{}
+
+	var toks []lexeme
+	trailing := map[int]string{}
+
+	src := []byte(code)
+	fset := token.NewFileSet()
+	tf := fset.AddFile("", -1, len(src))
+	var sc scanner.Scanner
+	sc.Init(tf, src, func(token.Position, string) {}, scanner.ScanComments)
+
+	depth := 0
+	for {
+		pos, t, lit := sc.Scan()
+		if t == token.EOF {
+			break
+		}
+		line := tf.Line(pos) - 1
+		if t == token.SEMICOLON && lit == "\n" {
+			continue
+		}
+		if t == token.COMMENT {
+			if s.hasCode[line] {
+				trailing[line] = lit
+			}
+			continue
+		}
+		if !s.hasCode[line] {
+			s.hasCode[line] = true
+			s.startDepth[line] = depth
+		}
+		switch t {
+		case token.LPAREN, token.LBRACE, token.LBRACK:
+			depth++
+		case token.RPAREN, token.RBRACE, token.RBRACK:
+			depth--
+		}
+		s.endDepth[line] = depth
+		if t == token.IDENT {
+			s.idents[lit] = true
+		}
+		toks = append(toks, lexeme{t, lit, line})
+	}
+
+	// 没有代码的行沿用上一行行尾的括号深度
+	depth = 0
+	for i := range s.lines {
+		if s.hasCode[i] {
+			depth = s.endDepth[i]
+		} else {
+			s.startDepth[i], s.endDepth[i] = depth, depth
+		}
+	}
+
+	// 顶级作用域中的 import、type、func 声明需要放到 main 函数之外
+	imported := map[string]bool{}
+	for i, t := range toks {
+		if i > 0 && toks[i-1].line == t.line || s.startDepth[t.line] != 0 {
+			continue
+		}
+		decl := false
+		switch t.tok {
+		case token.PACKAGE:
+			s.full = true
+		case token.IMPORT, token.TYPE:
+			decl = true
+		case token.FUNC:
+			decl = isFuncDecl(toks[i+1:])
+		}
+		if !decl {
+			continue
+		}
+		end := t.line
+		for end < n-1 && s.endDepth[end] > 0 {
+			end++
+		}
+		for j := t.line; j <= end; j++ {
+			s.hoisted[j] = true
+			if t.tok == token.IMPORT {
+				for _, m := range importPathPattern.FindAllStringSubmatch(s.lines[j], -1) {
+					imported[m[1]] = true
+				}
+			}
+		}
+
+		name, method := declName(t.tok, toks[i+1:])
+		if name == "" {
+			continue
+		}
+		if !method {
+			s.declared[name] = true
+		}
+		if t.tok == token.TYPE || method {
+			s.addDecl(typeDecl{name: name, method: method}, toks, t.line, end, imported)
+		}
+	}
+
+	for i, t := range toks {
+		if t.tok != token.IDENT || i+1 >= len(toks) || toks[i+1].tok != token.PERIOD {
+			continue
+		}
+		if path, ok := stdPackages[t.lit]; ok && !imported[path] {
+			imported[path] = true
+			s.imports = append(s.imports, path)
+		}
+	}
+
+	s.parseComments(trailing)
+	return s
+}
+
+type lexeme struct {
+	tok  token.Token
+	lit  string
+	line int
+}
+
+// declName 返回 type、func 关键字开始的声明中声明的名字，方法返回 receiver 的类型名，rest 为关键字之后的 token
+func declName(tok token.Token, rest []lexeme) (name string, method bool) {
+	if len(rest) == 0 {
+		return "", false
+	}
+	if rest[0].tok == token.IDENT {
+		return rest[0].lit, false
+	}
+	if tok != token.FUNC || rest[0].tok != token.LPAREN {
+		return "", false
+	}
+	// receiver 为 `(f Foo)`、`(f *Foo)` 或 `(Foo)`，类型名为括号中的最后一个名字
+	for _, t := range rest[1:] {
+		switch t.tok {
+		case token.IDENT:
+			name = t.lit
+		case token.RPAREN, token.LBRACK:
+			return name, name != ""
+		}
+	}
+	return "", false
+}
+
+// addDecl 记录第 start 至 end 行的声明，使用了 package 的声明不能脱离代码块的 import 单独使用，因此不记录
+func (s *snippet) addDecl(d typeDecl, toks []lexeme, start, end int, imported map[string]bool) {
+	packages := map[string]bool{}
+	for name := range stdPackages {
+		packages[name] = true
+	}
+	for p := range imported {
+		packages[path.Base(p)] = true
+	}
+
+	d.idents = map[string]bool{}
+	for i, t := range toks {
+		if t.line < start || t.line > end || t.tok != token.IDENT {
+			continue
+		}
+		if packages[t.lit] && i+1 < len(toks) && toks[i+1].tok == token.PERIOD {
+			return
+		}
+		d.idents[t.lit] = true
+	}
+	d.first, d.line = s.first, s.first+start
+	d.code = strings.Join(s.lines[start:end+1], "\n")
+	s.decls = append(s.decls, d)
+}
+
+// use 从之前代码块的声明中选出代码块用到、但自身没有声明的类型及其方法，同名的类型使用最近的声明
+func (s *snippet) use(decls []typeDecl) {
+	latest := map[string]int{} // 类型名 => 最近一次声明所在代码块的第一行
+	for _, d := range decls {
+		if !d.method {
+			latest[d.name] = d.first
+		}
+	}
+
+	used := map[string]bool{}
+	var visit func(idents map[string]bool)
+	visit = func(idents map[string]bool) {
+		for name := range idents {
+			first, ok := latest[name]
+			if !ok || used[name] || s.declared[name] {
+				continue
+			}
+			used[name] = true
+			for _, d := range decls {
+				if d.name == name && d.first == first {
+					visit(d.idents)
+				}
+			}
+		}
+	}
+	visit(s.idents)
+
+	for _, d := range decls {
+		if used[d.name] && d.first == latest[d.name] {
+			s.context = append(s.context, d)
+		}
+	}
+}
+
+// isFuncDecl 判断 func 关键字开始的语句是函数（或方法）声明，还是函数字面量，rest 为 func 之后的 token
+func isFuncDecl(rest []lexeme) bool {
+	if len(rest) == 0 {
+		return false
+	}
+	switch rest[0].tok {
+	case token.IDENT:
+		return true
+	case token.LPAREN:
+		depth := 0
+		for j, t := range rest {
+			switch t.tok {
+			case token.LPAREN:
+				depth++
+			case token.RPAREN:
+				depth--
+				if depth == 0 {
+					return j+1 < len(rest) && rest[j+1].tok == token.IDENT
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (s *snippet) comment(i int) (string, bool) {
+	text := strings.TrimSpace(s.lines[i])
+	if !strings.HasPrefix(text, "//") {
+		return "", false
+	}
+	return strings.TrimSpace(strings.TrimPrefix(text, "//")), true
+}
+
+// nextCode 返回第 i 行之后第一个包含代码的行，跳过空行
+func (s *snippet) nextCode(i int) int {
+	for j := i + 1; j < len(s.lines); j++ {
+		if s.hasCode[j] {
+			return j
+		}
+		if strings.TrimSpace(s.lines[j]) != "" {
+			return -1
+		}
+	}
+	return -1
+}
+
+// statementEnd 返回从第 i 行开始的语句的最后一行
+func (s *snippet) statementEnd(i int) int {
+	end := i
+	for end < len(s.lines)-1 && s.endDepth[end] > 0 {
+		end++
+	}
+	return end
+}
+
+func (s *snippet) addClaim(c claim) {
+	c.id = len(s.claims) + 1
+	s.claims = append(s.claims, c)
+}
+
+// parseComments 从注释中提取结果声明以及错误标记
+func (s *snippet) parseComments(trailing map[int]string) {
+	for i := 0; i < len(s.lines); i++ {
+		head, ok := s.comment(i)
+		if !ok {
+			if c, ok := trailing[i]; ok {
+				c = parenPattern.ReplaceAllString(strings.TrimPrefix(c, "//"), "")
+				for _, m := range nameClaimPattern.FindAllStringSubmatch(c, -1) {
+					s.addClaim(claim{kind: claimName, line: i, expr: m[1], want: unquote(m[2])})
+				}
+			}
+			continue
+		}
+
+		// 连续的注释行组成一个注释块，注释块描述的是紧随其后的代码
+		end := i
+		for end+1 < len(s.lines) {
+			if _, ok := s.comment(end + 1); !ok {
+				break
+			}
+			end++
+		}
+		next := s.nextCode(end)
+
+		switch {
+		case valueClaimPattern.MatchString(head) && next >= 0:
+			s.addClaim(claim{kind: claimValue, line: next, want: valueClaimPattern.FindString(head)})
+
+		case panicErrorPattern.MatchString(head) && next >= 0:
+			s.errors = append(s.errors, errorUnit{start: next, end: s.statementEnd(next)})
+
+		case illegalPattern.MatchString(head):
+			for j := i + 1; j <= end; j++ {
+				code, _ := s.comment(j)
+				s.errors = append(s.errors, errorUnit{start: j, end: j, code: code})
+			}
+
+		case returnClaimPattern.MatchString(head):
+			m := returnClaimPattern.FindStringSubmatch(head)
+			s.addClaim(claim{kind: claimReturns, line: i, expr: m[1], want: m[2]})
+
+		case printClaimPattern.MatchString(head) && next >= 0:
+			m := printClaimPattern.FindStringSubmatch(head)
+			if fn := funcNamePattern.FindStringSubmatch(strings.TrimSpace(s.lines[next])); fn != nil {
+				s.addClaim(claim{kind: claimPrints, line: i, expr: fn[1], want: printProsePattern.ReplaceAllString(m[1], "")})
+			}
+		}
+
+		for j := i; j <= end; j++ {
+			text, _ := s.comment(j)
+			if m := errorCodePattern.FindStringSubmatch(text); m != nil {
+				s.errors = append(s.errors, errorUnit{start: j, end: j, code: m[1]})
+			}
+		}
+
+		i = end
+	}
+}
+
+// program 生成可编译的 main package 源码：
+//   - import、type、func 声明放在顶级作用域，其他语句放到 main 函数中
+//   - 之前代码块中的类型声明放在代码块自身的声明之前
+//   - 使用 `//line` 指令让编译错误的位置指向章节源码
+//   - variant 不为空时，生成包含该错误代码的版本，否则生成去掉所有错误代码的版本
+func (s *snippet) program(variant *errorUnit) []byte {
+	lines := append([]string(nil), s.lines...)
+	for _, u := range s.errors {
+		if variant != nil && u == *variant {
+			if u.code != "" {
+				lines[u.start] = u.code
+			}
+			continue
+		}
+		if u.code == "" {
+			for j := u.start; j <= u.end; j++ {
+				lines[j] = ""
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	next := -1
+	emit := func(i int) {
+		if i != next {
+			fmt.Fprintf(&b, "//line %s:%d\n", s.file, s.first+i)
+		}
+		b.WriteString(lines[i])
+		b.WriteByte('\n')
+		next = i + 1
+	}
+
+	if s.full {
+		for i := range lines {
+			emit(i)
+		}
+		return b.Bytes()
+	}
+
+	b.WriteString("package main\n\n")
+	for _, path := range s.imports {
+		fmt.Fprintf(&b, "import %q\n", path)
+	}
+	for _, d := range s.context {
+		fmt.Fprintf(&b, "//line %s:%d\n%s\n", s.file, d.line, d.code)
+	}
+	for i := range lines {
+		if s.hoisted[i] {
+			emit(i)
+		}
+	}
+
+	b.WriteString("\nfunc main() {\n")
+	next = -1
+	for i := range lines {
+		if !s.hoisted[i] {
+			emit(i)
+		}
+	}
+	for _, c := range s.claims {
+		switch c.kind {
+		case claimReturns:
+			fmt.Fprintf(&b, "//line %s:%d\ngotourBegin(%d); gotourPrint(%s()); gotourEnd(%d)\n", s.file, s.first+c.line, c.id, c.expr, c.id)
+		case claimPrints:
+			fmt.Fprintf(&b, "//line %s:%d\ngotourBegin(%d); %s(); gotourEnd(%d)\n", s.file, s.first+c.line, c.id, c.expr, c.id)
+		}
+	}
+	b.WriteString("}\n")
+
+	return b.Bytes()
+}
+
+func unquote(s string) string {
+	if u, err := strconv.Unquote(s); err == nil {
+		return u
+	}
+	return s
+}
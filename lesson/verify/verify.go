@@ -0,0 +1,402 @@
+/*
+Package verify 检查章节 doc comment 中的 go 代码块能否编译，以及代码注释中声明的结果是否成立
+
+	代码块的处理方式：
+		* 只检查声明为 ```go 的代码块，声明了 sketch 属性（```go sketch）的示意代码会被跳过
+		* 以 package 声明开头的代码块视为完整的 go 文件
+		* 否则，import、type、func 声明放在顶级作用域，其余语句放到 main 函数中，
+			并自动 import 代码中直接使用的标准库，比如 fmt
+		* 代码块可以使用同一课程中之前的代码块声明的类型及其方法，同名的类型使用最近的声明
+		* 单独一行的表达式，比如 ` arr1 == arr2 `，会被改写为 ` _ = arr1 == arr2 `
+		* 未使用的变量以及 import 不视为错误
+		* 使用 go/types 进行类型检查
+
+	错误标记，被标记的代码必须编译失败，去掉这些代码后，剩余的代码必须编译通过：
+		* ` // Error: defer 1+1 `，注释中的代码
+		* ` // illegal `，之后连续的注释行中的代码
+		* ` // Panic error `、` // panic error `，紧随其后的语句或声明
+
+	结果声明，存在结果声明时，会运行代码块并比较结果：
+		* ` // true `、` // false ` 开头的注释，声明紧随其后的表达式的值
+		* 行尾注释中的 ` name == value `，声明该行语句执行之后，变量的值，比如 ` // var3 == 20 `
+		* ` // f returns 42 `，声明函数 f 的返回值
+		* ` // prints 3 2 1 0 `、` // print: 1 PM `，声明紧随其后的函数的完整输出，
+			before、after、when 等词之后的说明文字不属于输出，比如 ` // prints 3 2 1 0 before surrounding function returns `
+*/
+package verify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SamHwang1990/go-tour/lesson"
+)
+
+// Result 为一个代码块的检查结果
+type Result struct {
+	File     string // 章节目录名 + 文件名，比如 "07-slices/slices.go"
+	Line     int
+	Skipped  string // 不为空时表示跳过检查的原因
+	Claims   int    // 检查过的结果声明数量
+	Errors   int    // 检查过的错误标记数量
+	Problems []string
+}
+
+// OK 表示代码块通过检查，或被跳过
+func (r Result) OK() bool {
+	return len(r.Problems) == 0
+}
+
+func (r *Result) problemf(format string, args ...interface{}) {
+	r.Problems = append(r.Problems, fmt.Sprintf(format, args...))
+}
+
+// Verifier 检查代码块，同一个 Verifier 会缓存标准库的类型信息
+type Verifier struct {
+	Timeout time.Duration // 运行单个代码块的超时时间
+
+	importer types.Importer
+}
+
+// New 创建 Verifier
+func New() *Verifier {
+	return &Verifier{
+		Timeout:  30 * time.Second,
+		importer: importer.ForCompiler(token.NewFileSet(), "source", nil),
+	}
+}
+
+// Lesson 检查课程中的所有代码块
+func (v *Verifier) Lesson(ctx context.Context, l *lesson.Lesson) []Result {
+	var results []Result
+	var decls []typeDecl // 之前通过检查的代码块中的类型声明
+	for _, e := range l.Examples() {
+		r, s := v.example(ctx, l.Chapter+"/"+l.File, e, decls)
+		results = append(results, r)
+		if s != nil && r.OK() {
+			decls = append(decls, s.decls...)
+		}
+	}
+	return results
+}
+
+// Example 检查一个代码块，file 用于错误信息中的位置
+func (v *Verifier) Example(ctx context.Context, file string, e *lesson.Example) Result {
+	r, _ := v.example(ctx, file, e, nil)
+	return r
+}
+
+// example 检查一个代码块，代码块可以使用 decls 中的类型声明，跳过的代码块返回的 snippet 为 nil
+func (v *Verifier) example(ctx context.Context, file string, e *lesson.Example, decls []typeDecl) (Result, *snippet) {
+	r := Result{File: file, Line: e.Line}
+	switch {
+	case e.Lang != "go":
+		r.Skipped = "not go code"
+		return r, nil
+	case e.Has("sketch"):
+		r.Skipped = "sketch"
+		return r, nil
+	}
+
+	s := parseSnippet(file, e.Line, e.Code)
+	s.use(decls)
+
+	for i := range s.errors {
+		u := s.errors[i]
+		r.Errors++
+		errs := v.check(s, &u)
+		if len(errs) == 0 {
+			r.problemf("%s:%d: marked as error, but compiles", file, s.first+u.start)
+			continue
+		}
+		if !errorsWithin(errs, s.first+u.start, s.first+u.end) {
+			r.problemf("%s:%d: marked as error, but fails elsewhere: %v", file, s.first+u.start, errs[0])
+		}
+	}
+
+	errs := v.check(s, nil)
+	for _, err := range errs {
+		r.problemf("%v", err)
+	}
+	if len(errs) > 0 || len(s.claims) == 0 {
+		return r, s
+	}
+
+	r.Claims = len(s.claims)
+	outputs, err := v.run(ctx, s)
+	if err != nil {
+		r.problemf("%s:%d: %v", file, e.Line, err)
+		return r, s
+	}
+	for _, c := range s.claims {
+		got, ok := outputs[c.id]
+		if !ok {
+			r.problemf("%s:%d: claim %q was not evaluated", file, s.first+c.line, c.want)
+			continue
+		}
+		if !c.matches(got) {
+			r.problemf("%s:%d: %s: want %q, got %q", file, s.first+c.line, c.describe(), c.want, strings.TrimSpace(got))
+		}
+	}
+	return r, s
+}
+
+func (c claim) describe() string {
+	switch c.kind {
+	case claimName:
+		return c.expr
+	case claimReturns:
+		return c.expr + "()"
+	case claimPrints:
+		return "output of " + c.expr + "()"
+	}
+	return "expression"
+}
+
+func (c claim) matches(got string) bool {
+	if c.kind == claimPrints {
+		// 说明文字在解析时已经去掉，要求完整的输出与声明相同；
+		// 忽略所有空白，spec 中的 "prints 3 2 1 0" 对应的输出为 fmt.Print 的 "3210"
+		return stripSpace(got) == stripSpace(c.want)
+	}
+	return strings.TrimSpace(got) == c.want
+}
+
+func stripSpace(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
+
+// check 生成并类型检查代码块，返回编译错误，忽略未使用的变量以及 import
+func (v *Verifier) check(s *snippet, variant *errorUnit) []error {
+	src, err := s.rewrite(s.program(variant))
+	if err != nil {
+		return scannerErrors(err)
+	}
+
+	fset := token.NewFileSet()
+	main, err := parser.ParseFile(fset, "main.go", src, 0)
+	if err != nil {
+		return scannerErrors(err)
+	}
+	helper, err := parser.ParseFile(fset, "gotour_claims.go", claimsHelper, 0)
+	if err != nil {
+		panic(err)
+	}
+
+	var errs []error
+	conf := types.Config{
+		Importer: v.importer,
+		Error: func(err error) {
+			if te, ok := err.(types.Error); ok && te.Soft && isUnused(te.Msg) {
+				return
+			}
+			errs = append(errs, err)
+		},
+	}
+	conf.Check("main", fset, []*ast.File{main, helper}, nil)
+	return errs
+}
+
+func isUnused(msg string) bool {
+	return strings.Contains(msg, "declared and not used") || strings.Contains(msg, "imported and not used")
+}
+
+func scannerErrors(err error) []error {
+	var list scanner.ErrorList
+	if errors.As(err, &list) {
+		errs := make([]error, len(list))
+		for i, e := range list {
+			errs[i] = e
+		}
+		return errs
+	}
+	return []error{err}
+}
+
+// errorsWithin 判断是否存在位于 [start, end] 行区间的错误
+func errorsWithin(errs []error, start, end int) bool {
+	for _, err := range errs {
+		var line int
+		switch e := err.(type) {
+		case types.Error:
+			line = e.Fset.Position(e.Pos).Line
+		case *scanner.Error:
+			line = e.Pos.Line
+		}
+		if line >= start && line <= end {
+			return true
+		}
+	}
+	return false
+}
+
+// rewrite 改写 main 函数中的语句：
+//   - 单独一行的表达式改写为赋值给 `_`，若存在结果声明，则改写为输出表达式的值
+//   - 存在 `name == value` 声明的语句，在语句之后输出变量的值
+func (s *snippet) rewrite(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "main.go", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var main *ast.FuncDecl
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "main" {
+			main = fn
+		}
+	}
+	if main == nil || main.Body == nil {
+		return src, nil
+	}
+
+	// 插入位置 => 插入的内容，同一位置的多个插入按顺序拼接
+	inserts := map[int]string{}
+	tf := fset.File(f.Pos())
+
+	for _, stmt := range main.Body.List {
+		start := fset.Position(stmt.Pos())
+		end := fset.Position(stmt.End())
+		if start.Filename != s.file {
+			continue
+		}
+		first, last := start.Line-s.first, end.Line-s.first
+
+		if es, ok := stmt.(*ast.ExprStmt); ok && !validStatement(es.X) {
+			before, after := "_ = ", ""
+			for _, c := range s.claims {
+				if c.kind == claimValue && c.line == first {
+					before = fmt.Sprintf("gotourBegin(%d); gotourPrint(", c.id)
+					after = fmt.Sprintf("); gotourEnd(%d)", c.id)
+				}
+			}
+			inserts[tf.Offset(stmt.Pos())] += before
+			inserts[tf.Offset(stmt.End())] += after
+		}
+
+		for _, c := range s.claims {
+			if c.kind == claimName && c.line >= first && c.line <= last {
+				inserts[tf.Offset(stmt.End())] += fmt.Sprintf("; gotourBegin(%d); gotourPrint(%s); gotourEnd(%d)", c.id, c.expr, c.id)
+			}
+		}
+	}
+
+	offsets := make([]int, 0, len(inserts))
+	for offset := range inserts {
+		offsets = append(offsets, offset)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(offsets)))
+
+	out := append([]byte(nil), src...)
+	for _, offset := range offsets {
+		out = append(out[:offset], append([]byte(inserts[offset]), out[offset:]...)...)
+	}
+	return out, nil
+}
+
+// 不能单独作为语句使用的内置函数
+var valueBuiltins = map[string]bool{
+	"append": true, "cap": true, "complex": true, "imag": true, "len": true,
+	"make": true, "max": true, "min": true, "new": true, "real": true,
+}
+
+// validStatement 判断表达式能否单独作为语句：函数调用（部分内置函数除外）以及 channel 接收操作
+func validStatement(x ast.Expr) bool {
+	switch e := ast.Unparen(x).(type) {
+	case *ast.CallExpr:
+		id, ok := ast.Unparen(e.Fun).(*ast.Ident)
+		return !ok || !valueBuiltins[id.Name]
+	case *ast.UnaryExpr:
+		return e.Op == token.ARROW
+	}
+	return false
+}
+
+// run 运行代码块，返回每个结果声明对应的输出
+func (v *Verifier) run(ctx context.Context, s *snippet) (map[int]string, error) {
+	src, err := s.rewrite(s.program(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "gotour-verify-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), src, 0644); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "gotour_claims.go"), []byte(claimsHelper), 0644); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, v.Timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "go", "run", "main.go", "gotour_claims.go")
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("run failed: %v\n%s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return parseOutputs(stdout.String()), nil
+}
+
+const (
+	beginMarker = "@@gotour-claim-begin"
+	endMarker   = "@@gotour-claim-end"
+)
+
+var claimsHelper = `package main
+
+import "fmt"
+
+func gotourBegin(id int) { fmt.Printf("\n` + beginMarker + ` %d\n", id) }
+
+func gotourEnd(id int) { fmt.Printf("\n` + endMarker + ` %d\n", id) }
+
+func gotourPrint(v ...interface{}) { fmt.Println(v...) }
+`
+
+func parseOutputs(out string) map[int]string {
+	outputs := map[int]string{}
+	for {
+		i := strings.Index(out, beginMarker+" ")
+		if i < 0 {
+			return outputs
+		}
+		out = out[i+len(beginMarker)+1:]
+		nl := strings.IndexByte(out, '\n')
+		if nl < 0 {
+			return outputs
+		}
+		id, _ := strconv.Atoi(out[:nl])
+		out = out[nl+1:]
+		j := strings.Index(out, "\n"+endMarker+" ")
+		if j < 0 {
+			return outputs
+		}
+		outputs[id] = out[:j]
+		out = out[j+1:]
+	}
+}
@@ -0,0 +1,231 @@
+package verify
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/SamHwang1990/go-tour/lesson"
+)
+
+func TestExample(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		example  lesson.Example
+		run      bool // 存在结果声明，需要 go run
+		skipped  string
+		claims   int
+		errors   int
+		problems []string // 每个问题中应该包含的内容，按顺序
+	}{
+		{
+			name: "passing claims",
+			example: lesson.Example{Lang: "go", Code: `x := 1 + 2 // x == 3
+// true
+x > 2
+s := strings.Repeat("ab", 2) // s == "abab"`},
+			run:    true,
+			claims: 3,
+		},
+		{
+			name:     "failing claim",
+			example:  lesson.Example{Lang: "go", Code: "x := 1\nx++ // x == 3"},
+			run:      true,
+			claims:   1,
+			problems: []string{`fixture/doc.go:11: x: want "3", got "2"`},
+		},
+		{
+			name: "function claims",
+			example: lesson.Example{Lang: "go", Code: `// f returns 42
+func f() int { return 6 * 7 }
+
+// prints 3 2 1 0 before g returns
+func g() {
+	for i := 3; i >= 0; i-- {
+		fmt.Print(i, " ")
+	}
+}`},
+			run:    true,
+			claims: 2,
+		},
+		{
+			name: "truncated output",
+			example: lesson.Example{Lang: "go", Code: `// prints 3 2 1 0 before g returns
+func g() {
+	fmt.Print(3)
+}`},
+			run:      true,
+			claims:   1,
+			problems: []string{`fixture/doc.go:10: output of g(): want "3 2 1 0", got "3"`},
+		},
+		{
+			name: "extra output",
+			example: lesson.Example{Lang: "go", Code: `// print: 1 PM
+func g() {
+	fmt.Println(1, "PM", "!")
+}`},
+			run:      true,
+			claims:   1,
+			problems: []string{`fixture/doc.go:10: output of g(): want "1 PM", got "1 PM !"`},
+		},
+		{
+			name:    "sketch",
+			example: lesson.Example{Lang: "go", Attrs: []string{"sketch"}, Code: "for i := range … {\n}"},
+			skipped: "sketch",
+		},
+		{
+			name:    "not go",
+			example: lesson.Example{Lang: "ebnf", Code: "Block = \"{\" StatementList \"}\" ."},
+			skipped: "not go code",
+		},
+		{
+			name:     "does not parse",
+			example:  lesson.Example{Lang: "go", Code: "x := [3]int{1, 2, 3\nfmt.Println(x)"},
+			problems: []string{"fixture/doc.go:10:"},
+		},
+		{
+			name:     "type error",
+			example:  lesson.Example{Lang: "go", Code: "var a [3]int\nvar b [4]int\n_ = a == b"},
+			problems: []string{"fixture/doc.go:12:", "mismatched types"},
+		},
+		{
+			name: "error markers",
+			example: lesson.Example{Lang: "go", Code: `a := [3]int{}
+// Error: a = [4]int{}
+_ = a
+// illegal
+//	var b [2]int = a
+_ = a
+// Panic error
+_ = a[5]`},
+			errors: 3,
+		},
+		{
+			name:     "error marker compiles",
+			example:  lesson.Example{Lang: "go", Code: "a := [3]int{}\n// Error: a = [3]int{1}"},
+			errors:   1,
+			problems: []string{"fixture/doc.go:11: marked as error, but compiles"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.run && testing.Short() {
+				t.Skip("runs go run")
+			}
+			tt.example.Line = 10
+			r := New().Example(context.Background(), "fixture/doc.go", &tt.example)
+			if r.File != "fixture/doc.go" || r.Line != 10 {
+				t.Errorf("position = %s:%d, want fixture/doc.go:10", r.File, r.Line)
+			}
+			if r.Skipped != tt.skipped || r.Claims != tt.claims || r.Errors != tt.errors {
+				t.Errorf("skipped %q, %d claims, %d errors, want %q, %d, %d",
+					r.Skipped, r.Claims, r.Errors, tt.skipped, tt.claims, tt.errors)
+			}
+			if r.OK() != (len(tt.problems) == 0) {
+				t.Fatalf("problems = %q, want %q", r.Problems, tt.problems)
+			}
+			got := strings.Join(r.Problems, "\n")
+			for _, want := range tt.problems {
+				i := strings.Index(got, want)
+				if i < 0 {
+					t.Fatalf("problems = %q, want %q", r.Problems, tt.problems)
+				}
+				got = got[i+len(want):]
+			}
+		})
+	}
+}
+
+func TestParseSnippet(t *testing.T) {
+	const code = `import "math"
+
+type point struct{ x, y int }
+
+func (p point) len() float64 {
+	return math.Hypot(float64(p.x), float64(p.y))
+}
+
+f := func() int { return 1 }
+p := point{3, 4} // n == 2 (p.x == 3 在括号中，不是结果声明)
+// false
+p.x > p.y
+fmt.Println(p)
+// Error: p = 1`
+	s := parseSnippet("fixture/doc.go", 1, code)
+
+	var hoisted []int
+	for i, h := range s.hoisted {
+		if h {
+			hoisted = append(hoisted, i)
+		}
+	}
+	if want := []int{0, 2, 4, 5, 6}; !slices.Equal(hoisted, want) {
+		t.Errorf("hoisted lines = %v, want %v", hoisted, want)
+	}
+	if len(s.imports) != 1 || s.imports[0] != "fmt" {
+		t.Errorf("imports = %q, want [fmt]", s.imports)
+	}
+
+	want := []claim{
+		{id: 1, kind: claimName, line: 9, expr: "n", want: "2"},
+		{id: 2, kind: claimValue, line: 11, want: "false"},
+	}
+	if len(s.claims) != len(want) {
+		t.Fatalf("claims = %+v, want %+v", s.claims, want)
+	}
+	for i, c := range s.claims {
+		if c != want[i] {
+			t.Errorf("claims[%d] = %+v, want %+v", i, c, want[i])
+		}
+	}
+	if want := []errorUnit{{start: 13, end: 13, code: "p = 1"}}; len(s.errors) != 1 || s.errors[0] != want[0] {
+		t.Errorf("errors = %+v, want %+v", s.errors, want)
+	}
+
+	// 去掉错误代码的版本中，声明在 main 之外，其余语句在 main 之中
+	prog := string(s.program(nil))
+	if i, j := strings.Index(prog, "type point"), strings.Index(prog, "func main()"); i < 0 || j < i {
+		t.Errorf("type point is not hoisted before main:\n%s", prog)
+	}
+	if i, j := strings.Index(prog, "func main()"), strings.Index(prog, "f := func()"); j < i {
+		t.Errorf("function literal is hoisted out of main:\n%s", prog)
+	}
+	// f 位于代码块第 9 行，//line 指令从它之前的空行开始
+	if !strings.Contains(prog, "//line fixture/doc.go:8\n\nf := func()") {
+		t.Errorf("statements in main do not keep their lines in the chapter:\n%s", prog)
+	}
+}
+
+// TestDeclarations 检查代码块可以使用之前代码块中声明的类型及其方法，同名的类型使用最近的声明
+func TestDeclarations(t *testing.T) {
+	var decls []typeDecl
+	for i, code := range []string{
+		"type Person struct{ name string }",
+		"type Lock interface{ Acquire() }\n\ntype Foo struct{}\n\nfunc (f Foo) Acquire() {}",
+		"type Person struct {\n\tname string\n\tage  int\n}",
+		// String 方法使用了 fmt，不能脱离代码块单独使用
+		"type Bar struct{}\n\nfunc (b Bar) String() string { return fmt.Sprint(1) }",
+	} {
+		decls = append(decls, parseSnippet("fixture/doc.go", 10*i+1, code).decls...)
+	}
+	if len(decls) != 6 {
+		t.Fatalf("decls = %+v, want 6 declarations", decls)
+	}
+
+	v := New()
+	for _, tt := range []struct {
+		code string
+		ok   bool
+	}{
+		{code: "var lo Lock = Foo{}\nlo.Acquire()", ok: true},
+		{code: `sam := Person{name: "foo", age: 18}`, ok: true},
+		{code: "type Person struct{ string }\n_ = Person{string: \"foo\"}", ok: true},
+		{code: "var b fmt.Stringer = Bar{}"},
+	} {
+		s := parseSnippet("fixture/doc.go", 100, tt.code)
+		s.use(decls)
+		if errs := v.check(s, nil); (len(errs) == 0) != tt.ok {
+			t.Errorf("%q: errors = %v, want ok %v", tt.code, errs, tt.ok)
+		}
+	}
+}
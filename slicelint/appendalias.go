@@ -0,0 +1,175 @@
+package slicelint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var AppendAlias = &analysis.Analyzer{
+	Name:     "appendalias",
+	Doc:      "report append calls on a re-sliced value that write in place into a backing array still reachable through another name",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runAppendAlias,
+}
+
+func runAppendAlias(pass *analysis.Pass) (interface{}, error) {
+	info := pass.TypesInfo
+	l := newLocals(pass)
+	in := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	in.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		call := n.(*ast.CallExpr)
+		if !push || !isBuiltin(info, call, "append") || len(call.Args) < 2 {
+			return true
+		}
+
+		// append(a[low:high], ...)，或者 s := a[low:high] 之后的 append(s, ...)
+		dst := ast.Unparen(call.Args[0])
+		se, ok := dst.(*ast.SliceExpr)
+		var via types.Object
+		if id, isIdent := dst.(*ast.Ident); isIdent {
+			if via = info.Uses[id]; via != nil && !l.escapes(pass, via) {
+				if a, found := l.lastAssign(via, call); found {
+					se, ok = ast.Unparen(a.rhs).(*ast.SliceExpr)
+				}
+			}
+		}
+		if !ok || se.Slice3 || se.High == nil {
+			return true
+		}
+
+		// s = s[:2] 之后 s[2:] 只能通过其他 slice 访问，这里不再追踪
+		root, always := rootOf(pass, l, se.X)
+		if root == nil || root == via || assignsTo(pass, l, call, stack, root) {
+			return true
+		}
+		if !always && !l.usedAfter(root, call, stack) && !l.sharedBefore(root, call) {
+			return true
+		}
+
+		x := types.ExprString(se.X)
+		overwritten := fmt.Sprintf("%s[%s:]", x, types.ExprString(se.High))
+		verb := "may overwrite"
+		if _, high, ok := bounds(info, se); ok {
+			n, nok := appended(info, call)
+			length, lok := arrayLen(info.TypeOf(se.X))
+			if nok && lok {
+				// 容量不够时 append 会创建新数组，不会改写 a
+				if high+n > length {
+					return true
+				}
+				overwritten = fmt.Sprintf("%s[%d:%d]", x, high, high+n)
+				verb = "overwrites"
+			}
+		}
+
+		full := fullSlice(se)
+		d := analysis.Diagnostic{
+			Pos: call.Pos(),
+			End: call.End(),
+			Message: fmt.Sprintf("append to %s %s %s in place, which is still reachable through %s; use the full slice expression %s or copy before appending",
+				types.ExprString(dst), verb, overwritten, x, full),
+		}
+		if pure(info, se.High) {
+			d.SuggestedFixes = []analysis.SuggestedFix{{
+				Message: "Use a full slice expression",
+				TextEdits: []analysis.TextEdit{{
+					Pos:     se.Rbrack,
+					End:     se.Rbrack,
+					NewText: []byte(":" + types.ExprString(se.High)),
+				}},
+			}}
+		}
+		pass.Report(d)
+		return true
+	})
+	return nil, nil
+}
+
+// rootOf 返回 slice expression 操作数所引用的变量；always 表示变量在当前函数之外也可以访问，比如字段、参数
+func rootOf(pass *analysis.Pass, l *locals, e ast.Expr) (obj types.Object, always bool) {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		if v, ok := pass.TypesInfo.Uses[e].(*types.Var); ok {
+			return v, l.escapes(pass, v)
+		}
+	case *ast.StarExpr:
+		return rootOf(pass, l, e.X)
+	case *ast.SelectorExpr:
+		if v, ok := pass.TypesInfo.Uses[e.Sel].(*types.Var); ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// assignsTo 表示 append 的结果赋值给 obj 本身，比如 a = append(a[:i], a[i+1:]...)，此时 a 原来的内容不再被使用
+func assignsTo(pass *analysis.Pass, l *locals, call *ast.CallExpr, stack []ast.Node, obj types.Object) bool {
+	as, ok := stack[len(stack)-2].(*ast.AssignStmt)
+	if !ok || len(as.Lhs) != len(as.Rhs) {
+		return false
+	}
+	for i, rhs := range as.Rhs {
+		if ast.Unparen(rhs) != call {
+			continue
+		}
+		lhs, _ := rootOf(pass, l, as.Lhs[i])
+		return lhs == obj
+	}
+	return false
+}
+
+// appended 返回 append 追加的元素数量
+func appended(info *types.Info, call *ast.CallExpr) (int64, bool) {
+	if !call.Ellipsis.IsValid() {
+		return int64(len(call.Args) - 1), true
+	}
+	switch e := ast.Unparen(call.Args[1]).(type) {
+	case *ast.SliceExpr:
+		if e.Slice3 {
+			return 0, false
+		}
+		if low, high, ok := bounds(info, e); ok {
+			return high - low, true
+		}
+	case *ast.CompositeLit:
+		for _, elt := range e.Elts {
+			if _, ok := elt.(*ast.KeyValueExpr); ok {
+				return 0, false
+			}
+		}
+		return int64(len(e.Elts)), true
+	}
+	return 0, false
+}
+
+// fullSlice 返回 a[low:high] 对应的 full slice expression a[low:high:high]
+func fullSlice(se *ast.SliceExpr) string {
+	low := ""
+	if se.Low != nil {
+		low = types.ExprString(se.Low)
+	}
+	high := types.ExprString(se.High)
+	return fmt.Sprintf("%s[%s:%s:%s]", types.ExprString(se.X), low, high, high)
+}
+
+// pure 表示 e 由常量、变量组成，复制一份作为 max 不会改变程序的行为
+func pure(info *types.Info, e ast.Expr) bool {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.BasicLit:
+		return true
+	case *ast.Ident:
+		return true
+	case *ast.SelectorExpr:
+		return pure(info, e.X)
+	case *ast.BinaryExpr:
+		return pure(info, e.X) && pure(info, e.Y)
+	case *ast.CallExpr:
+		return info.Types[e].Value != nil
+	}
+	return false
+}
@@ -0,0 +1,262 @@
+/*
+Package slicelint 是检查 slice 与底层数组共享问题的 go/analysis analyzer，参考 07-slices 中的 Slice Operation
+
+	slice 只是数组的引用，章节中的例子说明了 append 的隐患：
+		* operationAppend：slice1 := arr[2:4] 的 cap 为 8，append(slice1, -4, -5, -6) 不会创建新数组，
+			而是直接覆盖 arr[4]、arr[5]、arr[6]
+		* operationDelete：append(arr[:3], arr[4:]...) 把 arr[4:] 的元素前移，删除元素的同时也改写了 arr
+
+	analyzer：
+		* appendalias：append 的第一个参数是 `a[low:high]` 形式的 slice（直接写在 append 中，或者最近一次赋值为该形式的变量），
+			而 a 在 append 之后仍然被使用、或者是参数、包级变量、字段等函数外可以访问的值，
+			或者 append 之前 ` a[:] `、` &a ` 已经传给了函数、保存到了字段等位置（比如 operationAppend 中的 sliceview.Track），
+			此时 append 会原地覆盖 a[high:] 中的元素；
+			建议使用 full slice expression ` a[low:high:high] ` 让 append 创建新数组，或者先复制一份再 append
+		* subsliceretain：返回、或者保存到包级变量、字段、元素、channel 中的值，是局部构建的 slice、数组中不超过一半的片段，
+			比如章节 Memory Optimization 中的 ` return countries[:3] `，
+			sub-slice 会让整个底层数组一直无法被 GC 回收，建议复制需要的元素到新的 slice；
+			局部构建指数组变量、slice 字面量、常量长度的 make，或者调用当前包中返回这样的 slice 的函数（只跟随一层调用），
+			底层数组按 types.Sizes 计算不足 1 KiB 时不报告
+
+	通过 cmd/tourvet 与 go vet 一起运行：
+		```
+			go build -o /tmp/tourvet ./cmd/tourvet
+			go vet -vettool=/tmp/tourvet ./07-slices
+		```
+*/
+package slicelint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzers 为 slicelint 中的所有 analyzer
+var Analyzers = []*analysis.Analyzer{AppendAlias, SubsliceRetain}
+
+// isBuiltin 表示 call 调用的是名为 name 的内置函数
+func isBuiltin(info *types.Info, call *ast.CallExpr, name string) bool {
+	id, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	b, ok := info.Uses[id].(*types.Builtin)
+	return ok && b.Name() == name
+}
+
+// constInt 返回 e 的整数常量值
+func constInt(info *types.Info, e ast.Expr) (int64, bool) {
+	v := info.Types[e].Value
+	if v == nil {
+		return 0, false
+	}
+	return constant.Int64Val(constant.ToInt(v))
+}
+
+// arrayLen 返回数组或数组指针类型的长度，t 不是数组时返回 false
+func arrayLen(t types.Type) (int64, bool) {
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	a, ok := t.Underlying().(*types.Array)
+	if !ok {
+		return 0, false
+	}
+	return a.Len(), true
+}
+
+// bounds 返回 slice expression 的常量 low、high，省略的 high 取数组长度
+func bounds(info *types.Info, se *ast.SliceExpr) (low, high int64, ok bool) {
+	if se.Low != nil {
+		if low, ok = constInt(info, se.Low); !ok {
+			return 0, 0, false
+		}
+	}
+	if se.High == nil {
+		high, ok = arrayLen(info.TypeOf(se.X))
+		return low, high, ok
+	}
+	high, ok = constInt(info, se.High)
+	return low, high, ok
+}
+
+// locals 记录 pass 中变量的使用位置、赋值、共享底层数组的位置，以及哪些变量是函数参数
+type locals struct {
+	uses    map[types.Object][]ast.Node
+	assigns map[types.Object][]assignment
+	shared  map[types.Object][]ast.Node
+	params  map[types.Object]bool
+}
+
+// assignment 为一次对变量的赋值，at 为赋值语句；
+// ` x, y := f() ` 中 rhs 为调用 f()，result 为变量对应的返回值下标
+type assignment struct {
+	at     ast.Node
+	rhs    ast.Expr
+	result int
+}
+
+func newLocals(pass *analysis.Pass) *locals {
+	l := &locals{
+		uses:    map[types.Object][]ast.Node{},
+		assigns: map[types.Object][]assignment{},
+		shared:  map[types.Object][]ast.Node{},
+		params:  map[types.Object]bool{},
+	}
+	for id, obj := range pass.TypesInfo.Uses {
+		if _, ok := obj.(*types.Var); ok {
+			l.uses[obj] = append(l.uses[obj], id)
+		}
+	}
+
+	in := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	filter := []ast.Node{
+		(*ast.FuncDecl)(nil), (*ast.FuncLit)(nil), (*ast.AssignStmt)(nil), (*ast.ValueSpec)(nil),
+		(*ast.CallExpr)(nil), (*ast.CompositeLit)(nil), (*ast.SendStmt)(nil),
+	}
+	in.Preorder(filter, func(n ast.Node) {
+		var lists []*ast.FieldList
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) == len(n.Rhs) {
+				for i, lhs := range n.Lhs {
+					l.assign(pass, n, lhs, n.Rhs[i], 0)
+					if stores(pass, lhs) {
+						l.share(pass, n.Rhs[i])
+					}
+				}
+			} else if len(n.Rhs) == 1 {
+				for i, lhs := range n.Lhs {
+					l.assign(pass, n, lhs, n.Rhs[0], i)
+				}
+			}
+		case *ast.CallExpr:
+			// 内置函数（append、copy 等）与类型转换不会保留参数
+			if tv := pass.TypesInfo.Types[n.Fun]; tv.IsBuiltin() || tv.IsType() {
+				return
+			}
+			for _, arg := range n.Args {
+				l.share(pass, arg)
+			}
+		case *ast.CompositeLit:
+			for _, elt := range n.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					elt = kv.Value
+				}
+				l.share(pass, elt)
+			}
+		case *ast.SendStmt:
+			l.share(pass, n.Value)
+		case *ast.ValueSpec:
+			if len(n.Names) == len(n.Values) {
+				for i, name := range n.Names {
+					l.assign(pass, n, name, n.Values[i], 0)
+				}
+			} else if len(n.Values) == 1 {
+				for i, name := range n.Names {
+					l.assign(pass, n, name, n.Values[0], i)
+				}
+			}
+		case *ast.FuncDecl:
+			lists = []*ast.FieldList{n.Recv, n.Type.Params, n.Type.Results}
+		case *ast.FuncLit:
+			lists = []*ast.FieldList{n.Type.Params, n.Type.Results}
+		}
+		for _, list := range lists {
+			if list == nil {
+				continue
+			}
+			for _, f := range list.List {
+				for _, name := range f.Names {
+					l.params[pass.TypesInfo.Defs[name]] = true
+				}
+			}
+		}
+	})
+	return l
+}
+
+func (l *locals) assign(pass *analysis.Pass, at ast.Node, lhs, rhs ast.Expr, result int) {
+	id, ok := ast.Unparen(lhs).(*ast.Ident)
+	if !ok {
+		return
+	}
+	if obj := pass.TypesInfo.ObjectOf(id); obj != nil {
+		l.assigns[obj] = append(l.assigns[obj], assignment{at, rhs, result})
+	}
+}
+
+// share 记录 e 共享了变量的底层数组：` a[:] `、` &a `，以及 slice、指针类型的变量本身
+func (l *locals) share(pass *analysis.Pass, e ast.Expr) {
+	var id *ast.Ident
+	switch e := ast.Unparen(e).(type) {
+	case *ast.SliceExpr:
+		id, _ = ast.Unparen(e.X).(*ast.Ident)
+	case *ast.UnaryExpr:
+		if e.Op == token.AND {
+			id, _ = ast.Unparen(e.X).(*ast.Ident)
+		}
+	case *ast.Ident:
+		switch pass.TypesInfo.TypeOf(e).Underlying().(type) {
+		case *types.Slice, *types.Pointer:
+			id = e
+		}
+	}
+	if id == nil {
+		return
+	}
+	if obj, ok := pass.TypesInfo.Uses[id].(*types.Var); ok {
+		l.shared[obj] = append(l.shared[obj], e)
+	}
+}
+
+// sharedBefore 表示 obj 的底层数组在 n 之前已经传给了函数，或者保存到了字段、元素、channel 等位置，
+// 之后即使不再直接使用 obj，也可能通过其他名字读取数组
+func (l *locals) sharedBefore(obj types.Object, n ast.Node) bool {
+	for _, e := range l.shared[obj] {
+		if e.End() <= n.Pos() {
+			return true
+		}
+	}
+	return false
+}
+
+// lastAssign 返回 n 之前最近一次对 obj 的赋值
+func (l *locals) lastAssign(obj types.Object, n ast.Node) (assignment, bool) {
+	var last assignment
+	for _, a := range l.assigns[obj] {
+		if a.at.End() <= n.Pos() && (last.at == nil || a.at.Pos() > last.at.Pos()) {
+			last = a
+		}
+	}
+	return last, last.at != nil
+}
+
+// usedAfter 表示 obj 在 n 之后仍被使用：使用位置在 n 之后，或者 n 位于循环中、且循环体使用了 obj
+func (l *locals) usedAfter(obj types.Object, n ast.Node, stack []ast.Node) bool {
+	for _, id := range l.uses[obj] {
+		if id.Pos() >= n.End() {
+			return true
+		}
+		for _, s := range stack {
+			switch s.(type) {
+			case *ast.ForStmt, *ast.RangeStmt:
+				if obj.Pos() < s.Pos() && s.Pos() <= id.Pos() && id.End() <= s.End() {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// escapes 表示 obj 在当前函数之外仍然可以访问：包级变量、其他包的变量、函数参数与返回值
+func (l *locals) escapes(pass *analysis.Pass, obj types.Object) bool {
+	return obj.Pkg() != pass.Pkg || obj.Parent() == pass.Pkg.Scope() || l.params[obj]
+}
@@ -0,0 +1,217 @@
+package slicelint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var SubsliceRetain = &analysis.Analyzer{
+	Name:     "subsliceretain",
+	Doc:      "report returning or storing a small sub-slice of a locally built slice or array, which keeps the whole backing array alive",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runSubsliceRetain,
+}
+
+func runSubsliceRetain(pass *analysis.Pass) (interface{}, error) {
+	l := newLocals(pass)
+	in := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	filter := []ast.Node{(*ast.ReturnStmt)(nil), (*ast.AssignStmt)(nil), (*ast.SendStmt)(nil)}
+	in.Preorder(filter, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.ReturnStmt:
+			for _, e := range n.Results {
+				checkRetain(pass, l, n, e, "returning %s")
+			}
+		case *ast.AssignStmt:
+			if len(n.Lhs) != len(n.Rhs) {
+				return
+			}
+			for i, lhs := range n.Lhs {
+				if stores(pass, lhs) {
+					checkRetain(pass, l, n, n.Rhs[i], "storing %s in "+types.ExprString(lhs))
+				}
+			}
+		case *ast.SendStmt:
+			checkRetain(pass, l, n, n.Value, "sending %s on "+types.ExprString(n.Chan))
+		}
+	})
+	return nil, nil
+}
+
+// stores 表示赋值给 lhs 之后，值在当前函数之外仍然可以访问：包级变量、字段、slice 或 map 元素、指针
+func stores(pass *analysis.Pass, lhs ast.Expr) bool {
+	switch lhs := ast.Unparen(lhs).(type) {
+	case *ast.Ident:
+		obj := pass.TypesInfo.ObjectOf(lhs)
+		return obj != nil && obj.Pkg() == pass.Pkg && obj.Parent() == pass.Pkg.Scope()
+	case *ast.SelectorExpr, *ast.IndexExpr, *ast.StarExpr:
+		return true
+	}
+	return false
+}
+
+// checkRetain 检查 at 语句中返回或保存的 e 是否为局部 slice、数组的一小段
+func checkRetain(pass *analysis.Pass, l *locals, at ast.Node, e ast.Expr, format string) {
+	info := pass.TypesInfo
+	e = ast.Unparen(e)
+	se, ok := e.(*ast.SliceExpr)
+	name := types.ExprString(e)
+	// top := countries[:3] 之后的 return top
+	if id, isIdent := e.(*ast.Ident); isIdent {
+		if obj := info.Uses[id]; obj != nil && !l.escapes(pass, obj) {
+			if a, found := l.lastAssign(obj, at); found {
+				se, ok = ast.Unparen(a.rhs).(*ast.SliceExpr)
+				if ok {
+					name = fmt.Sprintf("%s (%s)", id.Name, types.ExprString(se))
+				}
+			}
+		}
+	}
+	if !ok {
+		return
+	}
+
+	id, ok := ast.Unparen(se.X).(*ast.Ident)
+	if !ok {
+		return
+	}
+	root, ok := info.Uses[id].(*types.Var)
+	if !ok || l.escapes(pass, root) {
+		return
+	}
+	length, ok := builtLen(pass, l, root, at, true)
+	if !ok || pass.TypesSizes.Sizeof(elemType(root.Type()))*length < minRetainBytes {
+		return
+	}
+
+	low, high := int64(0), length
+	if se.Low != nil {
+		if low, ok = constInt(info, se.Low); !ok {
+			return
+		}
+	}
+	if se.High != nil {
+		if high, ok = constInt(info, se.High); !ok {
+			return
+		}
+	}
+	// 只报告不超过底层数组一半的 sub-slice
+	if n := high - low; n*2 <= length {
+		pass.Reportf(e.Pos(), "%s keeps all %d elements of %s alive; copy the %d elements into a new slice instead",
+			fmt.Sprintf(format, name), length, id.Name, n)
+	}
+}
+
+// minRetainBytes 为报告的底层数组的最小字节数，更小的数组即使只用到一小段，多保留的内存也可以忽略
+const minRetainBytes = 1 << 10
+
+// elemType 返回 slice 或数组类型的元素类型
+func elemType(t types.Type) types.Type {
+	switch t := t.Underlying().(type) {
+	case *types.Slice:
+		return t.Elem()
+	case *types.Array:
+		return t.Elem()
+	}
+	return nil
+}
+
+// builtLen 返回局部变量 obj 在 at 之前构建的数组长度：数组变量、slice 字面量、常量长度的 make；
+// follow 为 true 时还会跟随一层同一个包中的函数调用，比如章节中的 ` countries, collected := newCountries() `
+func builtLen(pass *analysis.Pass, l *locals, obj *types.Var, at ast.Node, follow bool) (int64, bool) {
+	if a, ok := obj.Type().Underlying().(*types.Array); ok {
+		return a.Len(), true
+	}
+	if _, ok := obj.Type().Underlying().(*types.Slice); !ok {
+		return 0, false
+	}
+	a, ok := l.lastAssign(obj, at)
+	if !ok {
+		return 0, false
+	}
+	switch rhs := ast.Unparen(a.rhs).(type) {
+	case *ast.CompositeLit:
+		for _, elt := range rhs.Elts {
+			if _, ok := elt.(*ast.KeyValueExpr); ok {
+				return 0, false
+			}
+		}
+		return int64(len(rhs.Elts)), true
+	case *ast.CallExpr:
+		if isBuiltin(pass.TypesInfo, rhs, "make") && len(rhs.Args) >= 2 {
+			return constInt(pass.TypesInfo, rhs.Args[len(rhs.Args)-1])
+		}
+		if follow {
+			return resultLen(pass, l, rhs, a.result)
+		}
+	}
+	return 0, false
+}
+
+// resultLen 返回 call 调用的函数第 result 个返回值的数组长度：
+// 函数需要在当前包中声明，并且每个 return 语句返回的都是局部构建的 slice、数组，取其中最短的长度
+func resultLen(pass *analysis.Pass, l *locals, call *ast.CallExpr, result int) (int64, bool) {
+	id, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return 0, false
+	}
+	fn, ok := pass.TypesInfo.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() != pass.Pkg {
+		return 0, false
+	}
+	decl := funcDecl(pass, fn)
+	if decl == nil || decl.Body == nil {
+		return 0, false
+	}
+
+	length, found := int64(0), false
+	ok = true
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if !ok || result >= len(n.Results) {
+				ok = false
+				return false
+			}
+			ret, isIdent := ast.Unparen(n.Results[result]).(*ast.Ident)
+			if !isIdent {
+				ok = false
+				return false
+			}
+			obj, isVar := pass.TypesInfo.Uses[ret].(*types.Var)
+			if !isVar || l.escapes(pass, obj) {
+				ok = false
+				return false
+			}
+			built, isBuilt := builtLen(pass, l, obj, n, false)
+			if !isBuilt {
+				ok = false
+				return false
+			}
+			if !found || built < length {
+				length, found = built, true
+			}
+		}
+		return true
+	})
+	return length, ok && found
+}
+
+// funcDecl 返回当前包中 fn 的声明
+func funcDecl(pass *analysis.Pass, fn *types.Func) *ast.FuncDecl {
+	for _, f := range pass.Files {
+		for _, d := range f.Decls {
+			if decl, ok := d.(*ast.FuncDecl); ok && pass.TypesInfo.Defs[decl.Name] == fn {
+				return decl
+			}
+		}
+	}
+	return nil
+}
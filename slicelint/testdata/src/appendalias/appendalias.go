@@ -0,0 +1,124 @@
+package appendalias
+
+import "fmt"
+
+// operationAppend 与 operationDelete 来自 07-slices
+func operationAppend() {
+	arr := [...]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	slice1 := arr[2:4]
+	slice2 := append(slice1, -4, -5, -6) // want `append to slice1 overwrites arr\[4:7\] in place, which is still reachable through arr; use the full slice expression arr\[2:4:4\] or copy before appending`
+	fmt.Println(arr, slice1, slice2)
+
+	// cap 不足，append 创建新数组
+	arr2 := [...]int{1, 2, 3}
+	slice3 := arr2[:]
+	slice4 := append(slice3, 4, 5, 6)
+	fmt.Println(arr2, slice3, slice4)
+}
+
+// view 与 track 模拟 07-slices 中的 sliceview：track 保存 slice，之后通过 view 读取数组
+type view struct{ slices [][]int }
+
+func (v *view) track(s []int) { v.slices = append(v.slices, s) }
+
+func (v *view) String() string { return fmt.Sprint(v.slices) }
+
+// operationAppendView 为使用 sliceview 之后的 operationAppend：arr 在 append 之后不再直接出现
+func operationAppendView() {
+	arr := [...]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	slice1 := arr[2:4]
+
+	var v view
+	v.track(arr[:])
+	v.track(slice1)
+	fmt.Print(v.String())
+
+	slice2 := append(slice1, -4, -5, -6) // want `append to slice1 overwrites arr\[4:7\] in place, which is still reachable through arr`
+	v.track(slice2)
+	fmt.Print(v.String())
+}
+
+// shared 中 arr 的底层数组通过 &arr、保存到字段共享出去
+func shared(p *view) []int {
+	arr := [4]int{}
+	ptr := &arr
+	_ = ptr
+	keep(&arr)
+	s := append(arr[:1], 1) // want `append to arr\[:1\] overwrites arr\[1:2\] in place`
+
+	b := [4]int{}
+	p.slices = [][]int{b[:]}
+	t := append(b[:1], 1) // want `append to b\[:1\] overwrites b\[1:2\] in place`
+
+	// 数组按值传递，不共享底层数组
+	c := [4]int{}
+	byValue(c)
+	u := append(c[:1], 1)
+	return append(append(s, t...), u...)
+}
+
+func keep(p *[4]int) {}
+
+func byValue(a [4]int) {}
+
+func operationDelete() {
+	arr := [...]int{0, 1, 2, 3, 4, 5, 6}
+	slice1 := append(arr[:3], arr[4:]...) // want `append to arr\[:3\] overwrites arr\[3:6\] in place, which is still reachable through arr; use the full slice expression arr\[:3:3\]`
+	fmt.Println(arr, slice1)
+}
+
+type buffer struct{ b []byte }
+
+func (p *buffer) head(n int) []byte {
+	return append(p.b[:n], '.') // want `append to p.b\[:n\] may overwrite p.b\[n:\] in place, which is still reachable through p.b`
+}
+
+func (p *buffer) truncate(n int) {
+	p.b = append(p.b[:n], '.')
+}
+
+func param(xs []int, i int) []int {
+	return append(xs[:i], xs[i+1:]...) // want `append to xs\[:i\] may overwrite xs\[i:\] in place, which is still reachable through xs`
+}
+
+func deref(p *[4]int) []int {
+	return append(p[1:2], 0) // want `append to p\[1:2\] overwrites p\[2:3\] in place`
+}
+
+func local(i int) {
+	xs := []int{0, 1, 2, 3}
+	xs = append(xs[:i], xs[i+1:]...)
+
+	ys := []int{0, 1, 2, 3}
+	zs := append(ys[:len(ys)-1], 9) // want `append to ys\[:len\(ys\) - 1\] may overwrite ys\[len\(ys\) - 1:\]`
+	fmt.Println(xs, ys, zs)
+
+	// append 之后不再使用 a
+	a := [...]int{1, 2, 3}
+	s := append(a[:1], 5)
+
+	// full slice expression
+	b := [...]int{1, 2, 3}
+	s = append(b[:1:1], 5)
+	fmt.Println(b, s)
+
+	// 容量不足，append 创建新数组
+	c := [3]int{}
+	s = append(c[:2], 1, 2)
+	fmt.Println(c, s)
+
+	// s = s[:1] 之后 s[1:] 不再通过 s 访问
+	s = s[:1]
+	s = append(s, 6)
+	fmt.Println(s)
+}
+
+func loop(n int) {
+	arr := [...]int{1, 2, 3}
+	var s []int
+	for i := 0; i < n; i++ {
+		fmt.Println(arr)
+		s = append(arr[:1], i) // want `append to arr\[:1\] overwrites arr\[1:2\] in place`
+	}
+	fmt.Println(s)
+}
@@ -0,0 +1,106 @@
+package subsliceretain
+
+import "runtime"
+
+// newCountries、badCountries 与 goodCountries 来自 07-slices 的 Memory Optimization
+var countryNames = []string{
+	"United states", "United kingdom", "Austrilia",
+	"India", "China", "Russia",
+	"France", "Germany", "Spain",
+}
+
+const countryCount = 1 << 20
+
+func newCountries() ([]string, <-chan struct{}) {
+	countries := make([]string, countryCount)
+	for i := range countries {
+		countries[i] = countryNames[i%len(countryNames)]
+	}
+
+	collected := make(chan struct{})
+	runtime.SetFinalizer(&countries[0], func(*string) { close(collected) })
+	return countries, collected
+}
+
+func badCountries() ([]string, <-chan struct{}) {
+	countries, collected := newCountries()
+	return countries[:3], collected // want `returning countries\[:3\] keeps all 1048576 elements of countries alive; copy the 3 elements into a new slice instead`
+}
+
+func goodCountries() ([]string, <-chan struct{}) {
+	countries, collected := newCountries()
+
+	c := make([]string, 3)
+	copy(c, countries[:3])
+	return c, collected
+}
+
+// literalCountries 中的数组只有 9 个 string，共 144 字节，不值得复制
+func literalCountries() []string {
+	countries := []string{
+		"United states", "United kingdom", "Austrilia",
+		"India", "China", "Russia",
+		"France", "Germany", "Spain",
+	}
+	return countries[:3]
+}
+
+var cache []byte
+
+type index struct {
+	head []int
+	all  map[string][]int
+}
+
+func store(idx *index, ch chan<- []int) {
+	buf := make([]byte, 1<<20)
+	cache = buf[:16] // want `storing buf\[:16\] in cache keeps all 1048576 elements of buf alive`
+
+	var arr [1000]int
+	idx.head = arr[990:]         // want `storing arr\[990:\] in idx.head keeps all 1000 elements of arr alive; copy the 10 elements`
+	idx.all["top"] = arr[:10:10] // want `storing arr\[:10:10\] in idx.all\["top"\] keeps all 1000 elements`
+	ch <- arr[:1]                // want `sending arr\[:1\] on ch keeps all 1000 elements`
+
+	local := arr[:1]
+	_ = local
+}
+
+func named() []int {
+	nums := make([]int, 10, 1000)
+	top := nums[:5]
+	return top // want `returning top \(nums\[:5\]\) keeps all 1000 elements of nums alive`
+}
+
+// 底层数组小于 1 KiB 时不报告
+func small() ([]int, []byte, []struct{}) {
+	xs := []int{1, 2}
+	var b [4]byte
+	empty := make([]struct{}, 1<<20)
+	return xs[:1], b[:1], empty[:1]
+}
+
+// 只跟随一层调用，并且要求被调用的函数返回局部构建的 slice
+func indirect(xs []int) ([]int, []int) {
+	nested := wrapped()
+	passed := identity(make([]int, 1000))
+	return nested[:1], passed[:1]
+}
+
+func wrapped() []int {
+	return built()
+}
+
+func built() []int {
+	nums := make([]int, 1000)
+	return nums
+}
+
+func identity(xs []int) []int {
+	return xs
+}
+
+func large(xs []int, n int) ([]int, []int, []int) {
+	nums := []int{1, 2, 3, 4}
+	dyn := make([]int, n)
+	return nums[1:], dyn[:1], xs[:1]
+}
@@ -0,0 +1,480 @@
+package switchconv
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// exprSwitch 将 expression switch 改写为 if-else，包含 fallthrough 时先计算匹配的 clause 编号
+func (w *rewriter) exprSwitch(fn *ast.FuncDecl, label *ast.LabeledStmt, s *ast.SwitchStmt) edit {
+	ns := w.names(fn)
+	ranges := bodies(s.Body)
+
+	var clauses, cases []*ast.CaseClause
+	var def *ast.CaseClause
+	fallsThrough := false
+	pure := true
+	for _, stmt := range s.Body.List {
+		c := stmt.(*ast.CaseClause)
+		clauses = append(clauses, c)
+		if c.List == nil {
+			def = c
+		} else {
+			cases = append(cases, c)
+		}
+		if n := len(c.Body); n > 0 && isFallthrough(c.Body[n-1]) {
+			fallsThrough = true
+		}
+		for _, e := range c.List {
+			pure = pure && w.pure(e)
+		}
+	}
+
+	// switch 表达式只取值一次：表达式为变量并且 case 表达式不会修改它时直接比较，否则先保存到临时变量
+	subj, temp := "", ""
+	if s.Tag != nil {
+		if id, ok := s.Tag.(*ast.Ident); ok && pure {
+			subj = id.Name
+		} else {
+			temp = ns.fresh("v")
+			subj = temp
+		}
+	}
+	cond := func(c *ast.CaseClause) string {
+		var parts []string
+		for _, e := range c.List {
+			text := w.text(e)
+			if s.Tag != nil {
+				if be, ok := e.(*ast.BinaryExpr); ok && be.Op.Precedence() <= token.EQL.Precedence() {
+					text = "(" + text + ")"
+				}
+				text = subj + " == " + text
+			}
+			parts = append(parts, text)
+		}
+		return strings.Join(parts, " || ")
+	}
+
+	end := ""
+	var edits []edit
+	if _, found := switchBreaks(s.Body, label, "", w); found {
+		end = endName(ns, fn, label)
+		edits, _ = switchBreaks(s.Body, label, end, w)
+	}
+	body := func(i int, extra ...edit) string {
+		return "{" + w.slice(ranges[i][0], ranges[i][1], append(extra, edits...)) + "}"
+	}
+	index := map[*ast.CaseClause]int{}
+	for i, c := range clauses {
+		index[c] = i
+	}
+
+	var b strings.Builder
+	start := s.Pos()
+	if keepLabel(fn, label) {
+		fmt.Fprintf(&b, "%s:\n", label.Label.Name)
+	} else if label != nil {
+		start = label.Pos()
+	}
+	block := s.Init != nil || temp != "" || end != "" || fallsThrough || len(cases) == 0
+	if block {
+		b.WriteString("{\n")
+	}
+	b.WriteString(w.preamble(s.Body))
+	if s.Init != nil {
+		fmt.Fprintf(&b, "%s\n", w.text(s.Init))
+	}
+	if temp != "" {
+		fmt.Fprintf(&b, "%s := %s\n", temp, w.text(s.Tag))
+		if len(cases) == 0 {
+			fmt.Fprintf(&b, "_ = %s\n", temp)
+		}
+	}
+
+	if !fallsThrough {
+		for i, c := range cases {
+			if i > 0 {
+				b.WriteString(" else ")
+			}
+			fmt.Fprintf(&b, "if %s %s", cond(c), body(index[c]))
+		}
+		if def != nil {
+			if len(cases) > 0 {
+				b.WriteString(" else ")
+			}
+			b.WriteString(body(index[def]))
+		}
+		b.WriteString("\n")
+	} else {
+		// 先按 case 的顺序计算匹配的 clause 编号（从 1 开始，没有匹配并且没有 default 时为 0），再按源码顺序执行 clause
+		clause := ns.fresh("clause")
+		fmt.Fprintf(&b, "var %s int\n", clause)
+		for i, c := range cases {
+			if i > 0 {
+				b.WriteString(" else ")
+			}
+			fmt.Fprintf(&b, "if %s {\n%s = %d\n}", cond(c), clause, index[c]+1)
+		}
+		if def != nil {
+			fmt.Fprintf(&b, " else {\n%s = %d\n}", clause, index[def]+1)
+		}
+		b.WriteString("\n")
+
+		// 包含 default 并且每个 clause 都以 return 等语句或者 fallthrough 结束时，switch 语句为 terminating statement，
+		// 执行到最后一个 clause 时编号一定与之相同，最后一个 clause 不需要条件，改写后仍然为 terminating statement
+		terminating := def != nil
+		for _, c := range clauses {
+			terminating = terminating && len(c.Body) > 0 && (isFallthrough(c.Body[len(c.Body)-1]) || w.terminates(c.Body[len(c.Body)-1]))
+		}
+		for i, c := range clauses {
+			var extra []edit
+			if n := len(c.Body); n > 0 && isFallthrough(c.Body[n-1]) {
+				extra = append(extra, w.replace(c.Body[n-1], fmt.Sprintf("%s = %d", clause, i+2)))
+			}
+			if terminating && i == len(clauses)-1 {
+				fmt.Fprintf(&b, "%s\n", body(i, extra...))
+				continue
+			}
+			fmt.Fprintf(&b, "if %s == %d %s\n", clause, i+1, body(i, extra...))
+		}
+	}
+
+	if end != "" {
+		fmt.Fprintf(&b, "\n%s:\n", end)
+	}
+	if block {
+		b.WriteString("}")
+	}
+	return edit{w.offset(start), w.offset(s.End()), b.String()}
+}
+
+// exprCase 为 if-else 中与 expression switch 的 clause 对应的分支
+type exprCase struct {
+	cond ast.Expr // 为 nil 时为 default clause
+	body *ast.BlockStmt
+	num  int // clause 编号，只用于包含 fallthrough 的形式
+}
+
+// exprIf 识别 exprSwitch 生成的 if-else（可以包含在 block 中），将其改写为 expression switch
+func (w *rewriter) exprIf(fn *ast.FuncDecl, s ast.Stmt) (edit, bool) {
+	var prefix, rest []ast.Stmt
+	var chain *ast.IfStmt
+	end := ""
+
+	switch s := s.(type) {
+	case *ast.IfStmt:
+		chain = s
+	case *ast.BlockStmt:
+		var stmts []ast.Stmt
+		stmts, end = endLabel(s.List)
+		for i, stmt := range stmts {
+			if ifStmt, ok := stmt.(*ast.IfStmt); ok {
+				prefix, chain, rest = stmts[:i], ifStmt, stmts[i+1:]
+				break
+			}
+		}
+	}
+	if chain == nil {
+		return edit{}, false
+	}
+
+	// 包含 fallthrough 的形式：`var clause int`、计算编号的 if-else，以及按编号执行 clause 的 if 语句
+	var clause *ast.Ident
+	if n := len(prefix); n > 0 {
+		clause = clauseDecl(prefix[n-1])
+		if clause != nil {
+			prefix = prefix[:n-1]
+		}
+	}
+	if (clause == nil) != (len(rest) == 0) {
+		return edit{}, false
+	}
+
+	var cases []exprCase
+	for s := chain; ; {
+		if s != chain && s.Init != nil {
+			return edit{}, false
+		}
+		cases = append(cases, exprCase{cond: s.Cond, body: s.Body})
+		if e, ok := s.Else.(*ast.IfStmt); ok {
+			s = e
+			continue
+		}
+		if e, ok := s.Else.(*ast.BlockStmt); ok {
+			cases = append(cases, exprCase{body: e})
+		} else if s.Else != nil {
+			return edit{}, false
+		}
+		break
+	}
+
+	init := prefix
+	if chain.Init != nil {
+		init = append(append([]ast.Stmt{}, prefix...), chain.Init)
+	}
+
+	// 所有条件都是同一个变量的 == 比较时，改写为带 switch 表达式的形式
+	var subj []*ast.Ident
+	var lists [][]ast.Expr
+	tagged := true
+	pure := true
+	for _, c := range cases {
+		if c.cond == nil {
+			continue
+		}
+		var list []ast.Expr
+		for _, part := range splitOr(c.cond) {
+			be, ok := part.(*ast.BinaryExpr)
+			var x *ast.Ident
+			if ok && be.Op == token.EQL {
+				x, ok = be.X.(*ast.Ident)
+			}
+			if !ok || len(subj) > 0 && w.info.Uses[x] != w.info.Uses[subj[0]] || w.info.Uses[x] == nil {
+				tagged = false
+				list = append(list, part)
+				continue
+			}
+			subj = append(subj, x)
+			list = append(list, ast.Unparen(be.Y))
+			pure = pure && w.pure(be.Y)
+		}
+		lists = append(lists, list)
+	}
+	tag, temp := "", false // temp 表示 switch 表达式来自临时变量
+	if tagged && len(subj) > 0 {
+		obj := w.info.Uses[subj[0]]
+		if n := len(init); n > 0 {
+			if id, x, ok := define(init[n-1]); ok && w.info.Defs[id] == obj && w.only(obj, subj) {
+				tag, temp = w.text(x), true
+				init = init[:n-1]
+			}
+		}
+		if _, isVar := obj.(*types.Var); tag == "" && pure && (isVar || isConst(obj)) {
+			tag = subj[0].Name
+		}
+	}
+	if tag == "" {
+		// 没有 switch 表达式时，case 表达式为 || 连接的各个条件
+		lists = nil
+		for _, c := range cases {
+			if c.cond != nil {
+				lists = append(lists, splitOr(c.cond))
+			}
+		}
+	}
+	if len(init) > 1 || len(init) == 1 && !isSimple(init[0]) {
+		return edit{}, false
+	}
+
+	var blocks []*ast.BlockStmt
+	var order []int           // clause 的源码顺序，值为 cases 中的下标
+	extra := map[int][]edit{} // fallthrough
+	if clause == nil {
+		if len(lists) < 2 && !temp && end == "" {
+			return edit{}, false // 只有一个条件的 if 语句保持不变
+		}
+		for i, c := range cases {
+			blocks = append(blocks, c.body)
+			order = append(order, i)
+		}
+	} else {
+		var ok bool
+		if blocks, order, extra, ok = w.dispatch(clause, cases, rest); !ok {
+			return edit{}, false
+		}
+	}
+
+	gotos, labeled, ok := w.endGotos(blocks, end)
+	if !ok {
+		return edit{}, false
+	}
+
+	var b strings.Builder
+	if labeled {
+		fmt.Fprintf(&b, "%s:\n", end)
+	}
+	b.WriteString("switch ")
+	if len(init) == 1 {
+		b.WriteString(w.text(init[0]))
+		if tag == "" {
+			b.WriteString(";")
+		} else {
+			b.WriteString("; ")
+		}
+	}
+	if tag != "" {
+		b.WriteString(tag + " ")
+	}
+	b.WriteString("{\n")
+	for k, i := range order {
+		c := cases[i]
+		if c.cond == nil {
+			b.WriteString("default:")
+		} else {
+			var texts []string
+			for _, e := range lists[caseIndex(cases, i)] {
+				texts = append(texts, w.text(e))
+			}
+			fmt.Fprintf(&b, "case %s:", strings.Join(texts, ", "))
+		}
+		edits := append(append([]edit{}, gotos...), extra[i]...)
+		b.WriteString(strings.TrimRight(w.slice(blocks[k].Lbrace+1, blocks[k].Rbrace, edits), " \t\n"))
+		b.WriteString("\n")
+	}
+	b.WriteString("}")
+	return w.replace(s, b.String()), true
+}
+
+// dispatch 识别按 clause 编号执行的 if 语句，返回 clause 的语句、源码顺序，以及改写为 fallthrough 的赋值
+func (w *rewriter) dispatch(clause *ast.Ident, cases []exprCase, rest []ast.Stmt) (blocks []*ast.BlockStmt, order []int, extra map[int][]edit, ok bool) {
+	obj := w.info.Defs[clause]
+	allowed := []*ast.Ident{}
+
+	// 计算编号的 if-else 中每个分支只包含 `clause = N`，case 的编号递增
+	byNum := map[int]int{}
+	last := 0
+	for i := range cases {
+		c := &cases[i]
+		if len(c.body.List) != 1 {
+			return nil, nil, nil, false
+		}
+		id, n, isAssign := w.assignNum(c.body.List[0], obj)
+		if !isAssign || n < 1 || byNum[n] != 0 {
+			return nil, nil, nil, false
+		}
+		if c.cond != nil {
+			if n <= last {
+				return nil, nil, nil, false
+			}
+			last = n
+		}
+		c.num = n
+		byNum[n] = i + 1
+		allowed = append(allowed, id)
+	}
+	if len(byNum) != len(rest) {
+		return nil, nil, nil, false
+	}
+
+	extra = map[int][]edit{}
+	terminating := true
+	for k, stmt := range rest {
+		// 最后一个 clause 可以没有条件，前提是包含 default 并且前面的 clause 都以 return 等语句或者 fallthrough 结束
+		if blk, isBlock := stmt.(*ast.BlockStmt); isBlock && k == len(rest)-1 && terminating && cases[len(cases)-1].cond == nil {
+			blocks = append(blocks, blk)
+			order = append(order, byNum[k+1]-1)
+			break
+		}
+		s, isIf := stmt.(*ast.IfStmt)
+		if !isIf || s.Init != nil || s.Else != nil {
+			return nil, nil, nil, false
+		}
+		be, isBinary := s.Cond.(*ast.BinaryExpr)
+		if !isBinary || be.Op != token.EQL {
+			return nil, nil, nil, false
+		}
+		x, isIdent := be.X.(*ast.Ident)
+		y, isLit := be.Y.(*ast.BasicLit)
+		if !isIdent || !isLit || w.info.Uses[x] != obj || y.Value != strconv.Itoa(k+1) || byNum[k+1] == 0 {
+			return nil, nil, nil, false
+		}
+		allowed = append(allowed, x)
+		i := byNum[k+1] - 1
+		blocks = append(blocks, s.Body)
+		order = append(order, i)
+
+		// clause 最后一条语句为 `clause = k+1` 时为 fallthrough
+		if n := len(s.Body.List); n > 0 && k+1 < len(rest) {
+			if id, num, isAssign := w.assignNum(s.Body.List[n-1], obj); isAssign && num == k+2 {
+				allowed = append(allowed, id)
+				extra[i] = append(extra[i], w.replace(s.Body.List[n-1], "fallthrough"))
+			}
+		}
+		terminating = terminating && (len(extra[i]) > 0 || len(s.Body.List) > 0 && w.terminates(s.Body.List[len(s.Body.List)-1]))
+	}
+	if !w.only(obj, allowed) {
+		return nil, nil, nil, false
+	}
+	return blocks, order, extra, true
+}
+
+// assignNum 识别 `clause = N`
+func (w *rewriter) assignNum(s ast.Stmt, obj types.Object) (*ast.Ident, int, bool) {
+	a, ok := s.(*ast.AssignStmt)
+	if !ok || a.Tok != token.ASSIGN || len(a.Lhs) != 1 || len(a.Rhs) != 1 {
+		return nil, 0, false
+	}
+	id, ok1 := a.Lhs[0].(*ast.Ident)
+	lit, ok2 := a.Rhs[0].(*ast.BasicLit)
+	if !ok1 || !ok2 || lit.Kind != token.INT || w.info.Uses[id] != obj {
+		return nil, 0, false
+	}
+	n, err := strconv.Atoi(lit.Value)
+	return id, n, err == nil
+}
+
+// clauseDecl 识别 `var clause int`
+func clauseDecl(s ast.Stmt) *ast.Ident {
+	d, ok := s.(*ast.DeclStmt)
+	if !ok {
+		return nil
+	}
+	g, ok := d.Decl.(*ast.GenDecl)
+	if !ok || g.Tok != token.VAR || len(g.Specs) != 1 {
+		return nil
+	}
+	spec := g.Specs[0].(*ast.ValueSpec)
+	if t, ok := spec.Type.(*ast.Ident); !ok || t.Name != "int" || len(spec.Names) != 1 || len(spec.Values) != 0 {
+		return nil
+	}
+	return spec.Names[0]
+}
+
+// caseIndex 返回 cases[i] 在不包含 default 的 case 中的下标
+func caseIndex(cases []exprCase, i int) int {
+	n := 0
+	for _, c := range cases[:i] {
+		if c.cond != nil {
+			n++
+		}
+	}
+	return n
+}
+
+func isFallthrough(s ast.Stmt) bool {
+	b, ok := s.(*ast.BranchStmt)
+	return ok && b.Tok == token.FALLTHROUGH
+}
+
+// terminates 表示语句为 terminating statement，只识别常见的形式：return、goto、panic，以及由它们结束的 block、if-else
+func (w *rewriter) terminates(s ast.Stmt) bool {
+	switch s := s.(type) {
+	case *ast.ReturnStmt:
+		return true
+	case *ast.BranchStmt:
+		return s.Tok == token.GOTO
+	case *ast.ExprStmt:
+		if call, ok := s.X.(*ast.CallExpr); ok {
+			if id, ok := ast.Unparen(call.Fun).(*ast.Ident); ok {
+				b, ok := w.info.Uses[id].(*types.Builtin)
+				return ok && b.Name() == "panic"
+			}
+		}
+	case *ast.BlockStmt:
+		return len(s.List) > 0 && w.terminates(s.List[len(s.List)-1])
+	case *ast.IfStmt:
+		return s.Else != nil && w.terminates(s.Body) && w.terminates(s.Else)
+	case *ast.LabeledStmt:
+		return w.terminates(s.Stmt)
+	}
+	return false
+}
+
+func isConst(obj types.Object) bool {
+	_, ok := obj.(*types.Const)
+	return ok
+}
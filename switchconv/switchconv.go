@@ -0,0 +1,556 @@
+/*
+Package switchconv 将 switch 语句改写为等价的 if-else，或者反过来将 if-else 改写为 switch 语句，参考 05-flow-control-statements 中的 Switch-case
+
+	type switch 改写为 if-else 时，与章节中手写的改写方式相同：
+		```go sketch
+			switch i := x.(type) {
+			case nil:
+				printString("x is nil")
+			case int:
+				printInt(i)
+			case bool, string:
+				printString("type is bool or string")
+			default:
+				printString("don't know the type")
+			}
+		```
+	改写为：
+		```go sketch
+			if x == nil {
+				printString("x is nil")
+			} else if i, isInt := x.(int); isInt {
+				printInt(i)                       // type of i is int
+			} else {
+				_, isBool := x.(bool)
+				_, isString := x.(string)
+				if isBool || isString {
+					printString("type is bool or string")
+				} else {
+					printString("don't know the type")
+				}
+			}
+		```
+		* 每个 clause 中 i 的类型保持不变：只包含一个类型时通过 comma-ok 断言声明 i，nil、多个类型以及 default 中使用 `i := x`
+		* clause 中没有使用 i 时不声明 i，否则会出现 "declared and not used" 的编译错误
+		* x 不是变量时（比如函数调用），先赋值给临时变量，保证 x 只取值一次
+
+	expression switch 改写为 if-else 时，case 表达式列表改写为 `||` 连接的条件，default clause 移到最后的 else；
+	包含 fallthrough 时，先通过 if-else 计算匹配的 clause 编号，再按源码顺序依次执行 clause，fallthrough 改写为对编号的赋值：
+		```go sketch
+			var clause int
+			if v == 16 || v == int(32.0) {
+				clause = 1
+			} else {
+				clause = 2
+			}
+			if clause == 1 {
+				fmt.Println("Expression Switch case 32 or 16")
+				clause = 2
+			}
+			if clause == 2 {
+				fmt.Println("Expression Switch default")
+			}
+		```
+
+	两个方向都保持语义不变：
+		* 跳出 switch 的 break 语句改写为 goto 到语句末尾的 label（switch 语句有 label 时沿用它的名字），反向改写时还原为 break
+		* 引入的变量名、label 不与函数中已有的名字冲突
+		* 每改写一条语句都重新进行类型检查，保证改写后的源码可以编译
+	反向改写只识别与上面形式相同的 if-else，不能保证语义不变的 if-else（比如 clause 中的 break 会改变跳出的语句）保持不变；
+	只有 default clause 的 switch 语句改写后只剩下 block，不会被改写回 switch 语句
+*/
+package switchconv
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Mode 选择改写的 switch 语句
+type Mode int
+
+const (
+	TypeSwitch Mode = iota // type switch 与 if-else 互相改写
+	ExprSwitch             // expression switch（包括 fallthrough）与 if-else 互相改写
+)
+
+// Options 为改写选项
+type Options struct {
+	Mode    Mode
+	Reverse bool     // 将 if-else 改写为 switch 语句
+	Funcs   []string // 只改写这些函数中的语句，方法写作 T.Method；为空时改写所有函数
+}
+
+// Rewrite 改写文件 filename 的源码 src，返回格式化后的源码以及改写的语句数量
+//
+// 文件所在目录中同一个 package 的其他文件会一起进行类型检查；改写后的源码不能通过类型检查时返回 error
+func Rewrite(filename string, src []byte, opts Options) ([]byte, int, error) {
+	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "source", nil)
+	for n := 0; ; n++ {
+		w, err := load(fset, imp, filename, src, opts)
+		if err != nil {
+			if n > 0 {
+				return nil, n, fmt.Errorf("rewritten source does not compile: %v", err)
+			}
+			return nil, 0, err
+		}
+		if n == 0 {
+			if err := w.checkFuncs(); err != nil {
+				return nil, 0, err
+			}
+		}
+
+		e, ok := w.next()
+		if !ok {
+			return src, n, nil
+		}
+		out := append(append(append([]byte{}, src[:e.start]...), e.text...), src[e.end:]...)
+		if src, err = format.Source(out); err != nil {
+			return nil, n, fmt.Errorf("rewritten source does not parse: %v", err)
+		}
+	}
+}
+
+// rewriter 在一个文件中查找并改写下一条语句
+type rewriter struct {
+	fset *token.FileSet
+	file *ast.File
+	src  []byte
+	info *types.Info
+	opts Options
+	uses map[types.Object][]*ast.Ident
+}
+
+type edit struct {
+	start, end int
+	text       string
+}
+
+// load 解析并类型检查 src；每次改写后都要重新加载，imp 在多次加载之间共享，标准库只需要导入一次
+func load(fset *token.FileSet, imp types.Importer, filename string, src []byte, opts Options) (*rewriter, error) {
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	// 文件在磁盘上时，与同一个 package 的其他文件一起类型检查
+	files := []*ast.File{file}
+	if _, err := os.Stat(filename); err == nil {
+		if bp, err := build.ImportDir(filepath.Dir(filename), 0); err == nil && bp.Name == file.Name.Name {
+			for _, name := range bp.GoFiles {
+				if name == filepath.Base(filename) {
+					continue
+				}
+				f, err := parser.ParseFile(fset, filepath.Join(bp.Dir, name), nil, 0)
+				if err != nil {
+					return nil, err
+				}
+				files = append(files, f)
+			}
+		}
+	}
+
+	info := &types.Info{
+		Types:     map[ast.Expr]types.TypeAndValue{},
+		Defs:      map[*ast.Ident]types.Object{},
+		Uses:      map[*ast.Ident]types.Object{},
+		Implicits: map[ast.Node]types.Object{},
+	}
+	conf := types.Config{Importer: imp}
+	if _, err := conf.Check(file.Name.Name, fset, files, info); err != nil {
+		return nil, err
+	}
+
+	w := &rewriter{fset: fset, file: file, src: src, info: info, opts: opts, uses: map[types.Object][]*ast.Ident{}}
+	for id, obj := range info.Uses {
+		w.uses[obj] = append(w.uses[obj], id)
+	}
+	return w, nil
+}
+
+// checkFuncs 检查 Options.Funcs 中的函数都存在
+func (w *rewriter) checkFuncs() error {
+	missing := map[string]bool{}
+	for _, name := range w.opts.Funcs {
+		missing[name] = true
+	}
+	for _, decl := range w.file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			delete(missing, funcName(fn))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	var names []string
+	for name := range missing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("%s: function %s not found", w.fset.File(w.file.Pos()).Name(), strings.Join(names, ", "))
+}
+
+// next 按源码顺序返回第一条可以改写的语句的改写结果
+func (w *rewriter) next() (edit, bool) {
+	want := map[string]bool{}
+	for _, name := range w.opts.Funcs {
+		want[name] = true
+	}
+	for _, decl := range w.file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil || len(want) > 0 && !want[funcName(fn)] {
+			continue
+		}
+
+		// 反向改写时，只有语句列表中的 block、if 语句可以替换为 switch 语句，
+		// 函数体、else 分支等位置的 block 以及 else if 不行
+		inList := map[ast.Stmt]bool{}
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.BlockStmt:
+				for _, s := range n.List {
+					inList[s] = true
+				}
+			case *ast.CaseClause:
+				for _, s := range n.Body {
+					inList[s] = true
+				}
+			case *ast.CommClause:
+				for _, s := range n.Body {
+					inList[s] = true
+				}
+			case *ast.LabeledStmt:
+				inList[n.Stmt] = true
+			}
+			return true
+		})
+
+		var found *edit
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			if found != nil {
+				return false
+			}
+			s, ok := n.(ast.Stmt)
+			if !ok {
+				return true
+			}
+			if e, ok := w.rewrite(fn, s, inList[s]); ok {
+				found = &e
+				return false
+			}
+			return true
+		})
+		if found != nil {
+			return *found, true
+		}
+	}
+	return edit{}, false
+}
+
+func (w *rewriter) rewrite(fn *ast.FuncDecl, s ast.Stmt, inList bool) (edit, bool) {
+	var label *ast.LabeledStmt
+	if l, ok := s.(*ast.LabeledStmt); ok {
+		label, s = l, l.Stmt
+	}
+
+	switch opts := w.opts; {
+	case !opts.Reverse && opts.Mode == TypeSwitch:
+		if ts, ok := s.(*ast.TypeSwitchStmt); ok {
+			return w.typeSwitch(fn, label, ts), true
+		}
+	case !opts.Reverse && opts.Mode == ExprSwitch:
+		if es, ok := s.(*ast.SwitchStmt); ok {
+			return w.exprSwitch(fn, label, es), true
+		}
+	case label == nil && inList && opts.Mode == TypeSwitch:
+		return w.typeIf(fn, s)
+	case label == nil && inList && opts.Mode == ExprSwitch:
+		return w.exprIf(fn, s)
+	}
+	return edit{}, false
+}
+
+func (w *rewriter) offset(pos token.Pos) int {
+	return w.fset.Position(pos).Offset
+}
+
+// text 返回节点的源码
+func (w *rewriter) text(n ast.Node) string {
+	return string(w.src[w.offset(n.Pos()):w.offset(n.End())])
+}
+
+// slice 返回 [start, end) 之间的源码，并应用其中的 edits
+func (w *rewriter) slice(start, end token.Pos, edits []edit) string {
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+	var b strings.Builder
+	offset := w.offset(start)
+	for _, e := range edits {
+		if e.start < offset || e.end > w.offset(end) {
+			continue
+		}
+		b.Write(w.src[offset:e.start])
+		b.WriteString(e.text)
+		offset = e.end
+	}
+	b.Write(w.src[offset:w.offset(end)])
+	return b.String()
+}
+
+// replace 返回将节点替换为 text 的 edit
+func (w *rewriter) replace(n ast.Node, text string) edit {
+	return edit{w.offset(n.Pos()), w.offset(n.End()), text}
+}
+
+// preamble 返回 switch 语句的 `{` 与第一个 clause 之间的注释
+func (w *rewriter) preamble(body *ast.BlockStmt) string {
+	end := body.Rbrace
+	if len(body.List) > 0 {
+		end = body.List[0].Pos()
+	}
+	text := w.slice(body.Lbrace+1, end, nil)
+	if strings.TrimSpace(text) == "" {
+		return ""
+	}
+	return text
+}
+
+// bodies 返回每个 clause 的语句源码（冒号与下一个 clause 之间，包括注释）的范围
+func bodies(s *ast.BlockStmt) [][2]token.Pos {
+	var ranges [][2]token.Pos
+	for i, c := range s.List {
+		end := s.Rbrace
+		if i+1 < len(s.List) {
+			end = s.List[i+1].Pos()
+		}
+		ranges = append(ranges, [2]token.Pos{c.(*ast.CaseClause).Colon + 1, end})
+	}
+	return ranges
+}
+
+// names 收集函数中出现的所有名字，新引入的变量名、label 不能与它们重复
+type names map[string]bool
+
+func (w *rewriter) names(fn *ast.FuncDecl) names {
+	ns := names{}
+	ast.Inspect(fn, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			ns[id.Name] = true
+		}
+		return true
+	})
+	return ns
+}
+
+// fresh 返回不与已有名字重复的名字：base、base2、base3 ...
+func (ns names) fresh(base string) string {
+	name := base
+	for i := 2; ns[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	ns[name] = true
+	return name
+}
+
+// branches 对 stmts 中的 break、continue、goto、fallthrough 语句调用 f，
+// nested 表示语句位于嵌套的 for、switch、select 语句中；不进入函数字面量
+func branches(stmts []ast.Stmt, f func(b *ast.BranchStmt, nested bool)) {
+	var visit func(root ast.Node, nested bool)
+	visit = func(root ast.Node, nested bool) {
+		ast.Inspect(root, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.BranchStmt:
+				f(n, nested)
+			case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+				if !nested {
+					visit(n, true)
+					return false
+				}
+			}
+			return true
+		})
+	}
+	for _, s := range stmts {
+		visit(s, false)
+	}
+}
+
+// switchBreaks 将跳出 switch 语句的 break 改写为 goto end，end 为空时只检查是否存在这样的 break
+func switchBreaks(body *ast.BlockStmt, label *ast.LabeledStmt, end string, w *rewriter) (edits []edit, found bool) {
+	for _, c := range body.List {
+		branches(c.(*ast.CaseClause).Body, func(b *ast.BranchStmt, nested bool) {
+			if b.Tok != token.BREAK {
+				return
+			}
+			if b.Label == nil && !nested || b.Label != nil && label != nil && b.Label.Name == label.Label.Name {
+				found = true
+				edits = append(edits, w.replace(b, "goto "+end))
+			}
+		})
+	}
+	return edits, found
+}
+
+// endName 返回 switch 语句结束位置的 label：switch 语句的 label 在改写后被移除时沿用它的名字，否则为新的名字
+func endName(ns names, fn *ast.FuncDecl, label *ast.LabeledStmt) string {
+	if label != nil && !keepLabel(fn, label) {
+		return label.Label.Name
+	}
+	return ns.fresh("end")
+}
+
+// keepLabel 表示 switch 语句的 label 被 goto 语句引用，改写后需要保留
+func keepLabel(fn *ast.FuncDecl, label *ast.LabeledStmt) bool {
+	if label == nil {
+		return false
+	}
+	found := false
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if b, ok := n.(*ast.BranchStmt); ok && b.Tok == token.GOTO && b.Label.Name == label.Label.Name {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// endGotos 将 goto end 还原为 break；clause 中存在跳出外层语句的 break 时返回 false，
+// goto end 位于嵌套的 for、switch、select 中时，需要为 switch 语句加上 label，labeled 为 true
+func (w *rewriter) endGotos(blocks []*ast.BlockStmt, end string) (edits []edit, labeled, ok bool) {
+	var gotos []*ast.BranchStmt
+	ok = true
+	for _, blk := range blocks {
+		branches(blk.List, func(b *ast.BranchStmt, nested bool) {
+			switch {
+			case b.Tok == token.BREAK && b.Label == nil && !nested:
+				ok = false
+			case b.Tok == token.GOTO && end != "" && b.Label.Name == end:
+				gotos = append(gotos, b)
+				labeled = labeled || nested
+			}
+		})
+	}
+	for _, b := range gotos {
+		text := "break"
+		if labeled {
+			text += " " + end
+		}
+		edits = append(edits, w.replace(b, text))
+	}
+	return edits, labeled, ok
+}
+
+// endLabel 拆分 block 末尾的 `end:` label
+func endLabel(stmts []ast.Stmt) ([]ast.Stmt, string) {
+	if n := len(stmts); n > 0 {
+		if l, ok := stmts[n-1].(*ast.LabeledStmt); ok {
+			if _, ok := l.Stmt.(*ast.EmptyStmt); ok {
+				return stmts[:n-1], l.Label.Name
+			}
+		}
+	}
+	return stmts, ""
+}
+
+// isSimple 表示语句可以作为 switch 语句的初始化语句
+func isSimple(s ast.Stmt) bool {
+	switch s.(type) {
+	case *ast.AssignStmt, *ast.ExprStmt, *ast.IncDecStmt, *ast.SendStmt:
+		return true
+	}
+	return false
+}
+
+// define 返回 `name := expr` 形式的语句中的 name 与 expr
+func define(s ast.Stmt) (*ast.Ident, ast.Expr, bool) {
+	a, ok := s.(*ast.AssignStmt)
+	if !ok || a.Tok != token.DEFINE || len(a.Lhs) != 1 || len(a.Rhs) != 1 {
+		return nil, nil, false
+	}
+	id, ok := a.Lhs[0].(*ast.Ident)
+	return id, a.Rhs[0], ok && id.Name != "_"
+}
+
+// only 表示 obj 的所有引用都在 allowed 中
+func (w *rewriter) only(obj types.Object, allowed []*ast.Ident) bool {
+	set := map[*ast.Ident]bool{}
+	for _, id := range allowed {
+		set[id] = true
+	}
+	for _, id := range w.uses[obj] {
+		if !set[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// pure 表示表达式取值没有副作用：不包含函数调用（类型转换以及 len、cap 等 builtin 除外）以及 channel 接收
+func (w *rewriter) pure(e ast.Expr) bool {
+	ok := true
+	ast.Inspect(e, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			fun := ast.Unparen(n.Fun)
+			if w.info.Types[fun].IsType() {
+				return ok
+			}
+			if id, isIdent := fun.(*ast.Ident); isIdent && w.info.Types[fun].IsBuiltin() {
+				switch id.Name {
+				case "len", "cap", "min", "max", "real", "imag", "complex":
+					return ok
+				}
+			}
+			ok = false
+		case *ast.UnaryExpr:
+			ok = ok && n.Op != token.ARROW
+		}
+		return ok
+	})
+	return ok
+}
+
+// funcName 返回函数名，方法写作 `T.Method`
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	t := fn.Recv.List[0].Type
+	for {
+		switch x := t.(type) {
+		case *ast.StarExpr:
+			t = x.X
+			continue
+		case *ast.IndexExpr:
+			t = x.X
+			continue
+		case *ast.IndexListExpr:
+			t = x.X
+			continue
+		case *ast.Ident:
+			return x.Name + "." + fn.Name.Name
+		}
+		return fn.Name.Name
+	}
+}
+
+// upper 将名字的首字母改为大写
+func upper(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,225 @@
+package switchconv
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 运行 `go test ./switchconv -update` 重新生成 golden 文件
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+const switches = "testdata/switches/main.go"
+
+var modes = []struct {
+	name string
+	mode Mode
+}{
+	{"type", TypeSwitch},
+	{"expr", ExprSwitch},
+}
+
+func rewrite(t *testing.T, filename string, src []byte, opts Options) ([]byte, int) {
+	t.Helper()
+	out, n, err := Rewrite(filename, src, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out, n
+}
+
+// TestGolden 检查改写后的源码与 testdata/<mode>.golden 一致
+func TestGolden(t *testing.T) {
+	src, err := os.ReadFile(switches)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range modes {
+		t.Run(m.name, func(t *testing.T) {
+			out, n := rewrite(t, switches, src, Options{Mode: m.mode})
+			if n == 0 {
+				t.Fatal("no statement rewritten")
+			}
+
+			golden := filepath.Join("testdata", m.name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, out, 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v (run `go test ./switchconv -update` to create it)", err)
+			}
+			if !bytes.Equal(out, want) {
+				t.Errorf("output differs from %s\n--- got\n%s", golden, out)
+			}
+		})
+	}
+}
+
+// TestRoundTrip 检查 if-else 改写回 switch 后，再次改写得到相同的 if-else
+func TestRoundTrip(t *testing.T) {
+	src, err := os.ReadFile(switches)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range modes {
+		t.Run(m.name, func(t *testing.T) {
+			forward, n := rewrite(t, switches, src, Options{Mode: m.mode})
+			reversed, rn := rewrite(t, switches, forward, Options{Mode: m.mode, Reverse: true})
+			// 只有 default clause 的 switch 语句（empty）不会被改写回来
+			if rn != n && rn != n-1 {
+				t.Errorf("reversed %d statements, want %d", rn, n)
+			}
+			again, _ := rewrite(t, switches, reversed, Options{Mode: m.mode})
+			if !bytes.Equal(again, forward) {
+				t.Errorf("rewriting the reversed source gives different output\n--- reversed\n%s\n--- got\n%s", reversed, again)
+			}
+		})
+	}
+}
+
+// TestKeep 检查不能等价改写的 if-else 保持不变
+func TestKeep(t *testing.T) {
+	tests := []struct {
+		name string
+		mode Mode
+		body string
+	}{
+		{"not an interface", TypeSwitch, `
+	x := 1
+	if x == 1 {
+		println(1)
+	}`},
+		{"different subjects", TypeSwitch, `
+	var x, y interface{} = 1, "y"
+	if v, ok := x.(int); ok {
+		println(v)
+	} else if v, ok := y.(string); ok {
+		println(v)
+	}`},
+		{"flag used twice", TypeSwitch, `
+	var x interface{} = 1
+	if v, ok := x.(int); ok {
+		println(v, ok)
+	} else if _, ok := x.(string); ok {
+		println("string")
+	}`},
+		{"single condition", ExprSwitch, `
+	x := 1
+	if x == 1 {
+		println(1)
+	} else {
+		println(2)
+	}`},
+		{"not a comparison", ExprSwitch, `
+	x := 1
+	if x > 1 {
+		println(1)
+	}`},
+		{"outer break", ExprSwitch, `
+	for x := 0; x < 3; x++ {
+		if x == 1 {
+			break
+		} else if x == 2 {
+			println(2)
+		}
+	}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := []byte("package p\n\nfunc f() {" + tt.body + "\n}\n")
+			out, n := rewrite(t, "p.go", src, Options{Mode: tt.mode, Reverse: true})
+			if n != 0 {
+				t.Errorf("rewrote %d statements, want 0\n%s", n, out)
+			}
+		})
+	}
+}
+
+func TestFuncs(t *testing.T) {
+	src, err := os.ReadFile(switches)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, n := rewrite(t, switches, src, Options{Mode: ExprSwitch, Funcs: []string{"chapter", "grade"}}); n != 2 {
+		t.Errorf("rewrote %d statements in chapter and grade, want 2", n)
+	}
+	if _, _, err := Rewrite(switches, src, Options{Funcs: []string{"describe", "missing"}}); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("rewriting a missing function: err = %v", err)
+	}
+}
+
+// TestChapter 检查 05-flow-control-statements 中带 fallthrough 的 switch 改写为按 clause 编号执行的形式
+func TestChapter(t *testing.T) {
+	filename := "../05-flow-control-statements/flow_control_statements.go"
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, n := rewrite(t, filename, src, Options{Mode: ExprSwitch})
+	if n != 1 {
+		t.Fatalf("rewrote %d statements, want 1", n)
+	}
+	for _, want := range []string{"var clause int", "clause = 2\n", "if clause == 2 {"} {
+		if !bytes.Contains(out, []byte(want)) {
+			t.Errorf("rewritten source does not contain %q", want)
+		}
+	}
+	// 注释中的 fallthrough 保持不变
+	if got, want := bytes.Count(out, []byte("fallthrough")), bytes.Count(src, []byte("fallthrough"))-1; got != want {
+		t.Errorf("rewritten source contains fallthrough %d times, want %d", got, want)
+	}
+}
+
+// TestRun 检查改写前后以及改写回 switch 之后，程序的输出相同
+func TestRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping run tests in short mode: the rewritten programs are compiled with go run")
+	}
+
+	src, err := os.ReadFile(switches)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := run(t, src)
+	for _, m := range modes {
+		t.Run(m.name, func(t *testing.T) {
+			forward, _ := rewrite(t, switches, src, Options{Mode: m.mode})
+			if got := run(t, forward); got != want {
+				t.Errorf("rewritten output differs\n--- got\n%s\n--- want\n%s", got, want)
+			}
+			reversed, _ := rewrite(t, switches, forward, Options{Mode: m.mode, Reverse: true})
+			if got := run(t, reversed); got != want {
+				t.Errorf("reversed output differs\n--- got\n%s\n--- want\n%s", got, want)
+			}
+		})
+	}
+}
+
+func run(t *testing.T, src []byte) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), src, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "go", "run", "main.go")
+	cmd.Dir = dir
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("%v\n%s", err, stderr.String())
+	}
+	return stdout.String()
+}
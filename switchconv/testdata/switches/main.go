@@ -0,0 +1,229 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// describe 为章节中的 type switch
+func describe(x interface{}) string {
+	switch i := x.(type) {
+	case nil:
+		return "x is nil" // type of i is type of x (interface{})
+	case int:
+		return "int " + strconv.Itoa(i)
+	case float64:
+		return fmt.Sprintf("float64 %g", i)
+	case func(int) float64:
+		return fmt.Sprintf("func %g", i(2))
+	case bool, string:
+		return fmt.Sprintf("bool or string %v", i)
+	default:
+		return fmt.Sprintf("don't know the type %T", i)
+	}
+}
+
+var calls int
+
+func get(xs []any, k int) any {
+	calls++
+	return xs[k]
+}
+
+// shadow 中 clause 声明的变量与断言的对象同名，断言的对象为函数调用
+func shadow(xs []any) string {
+	var out []string
+	for k := range xs {
+		switch x := get(xs, k).(type) {
+		case error:
+			out = append(out, "error "+x.Error())
+		case fmt.Stringer, nil:
+			out = append(out, fmt.Sprint("stringer or nil ", x))
+		case []byte:
+			x = append(x, '!')
+			out = append(out, string(x))
+		}
+	}
+	for _, x := range xs {
+		switch x := x.(type) {
+		case int:
+			out = append(out, strconv.Itoa(x+1))
+		}
+	}
+	return fmt.Sprint(out, calls)
+}
+
+// firstString 中的 break 跳出 switch 语句，continue 继续外层的 for 语句
+func firstString(xs []any) string {
+	for _, x := range xs {
+		switch s := x.(type) {
+		case string:
+			if s == "" {
+				break
+			}
+			return s
+		case nil:
+			continue
+		}
+	}
+	return "none"
+}
+
+// sumInts 中的 break Outer 位于嵌套的 for 语句中
+func sumInts(x any) int {
+	total := 0
+Outer:
+	switch v := x.(type) {
+	case []int:
+		for _, n := range v {
+			if n < 0 {
+				break Outer
+			}
+			total += n
+		}
+	case int:
+		total = v
+	}
+	return total
+}
+
+// kind 的第一个 clause 包含多个类型，函数中已经有名为 isInt 的变量
+func kind(x any) string {
+	isInt := "integer"
+	switch n := len(isInt); x.(type) {
+	case int, int64:
+		return isInt + strconv.Itoa(n)
+	case nil, error:
+		return "nil or error"
+	}
+	return "other"
+}
+
+type celsius float64
+
+func (c celsius) String() string { return fmt.Sprintf("%.1f°C", float64(c)) }
+
+// chapter 为章节中的 expression switch
+func chapter() []string {
+	var out []string
+	switch 16 {
+	case 16, int(32.0):
+		out = append(out, "Expression Switch case 32 or 16")
+		fallthrough
+	default:
+		out = append(out, "Expression Switch default")
+	}
+	return out
+}
+
+// grade 中的 default clause 位于中间，fallthrough 进入以及离开 default clause
+func grade(n int) []string {
+	var out []string
+	switch {
+	case n >= 90:
+		out = append(out, "A")
+		fallthrough
+	default:
+		out = append(out, "default")
+		fallthrough
+	case n >= 80:
+		out = append(out, "B")
+	case n < 0:
+		out = append(out, "negative")
+	}
+	return out
+}
+
+// classify 的每个 clause 都以 return 或者 fallthrough 结束，switch 语句为 terminating statement
+func classify(n int) string {
+	switch {
+	case n < 0:
+		n = -n
+		fallthrough
+	case n < 10:
+		return "small " + strconv.Itoa(n)
+	default:
+		return "large"
+	}
+}
+
+var ticks int
+
+func tick() int {
+	ticks++
+	return ticks
+}
+
+// weekday 的 switch 表达式为函数调用，只取值一次
+func weekday(d int) string {
+	switch tick(); tick() % 7 {
+	case 0, 6:
+		return "weekend " + strconv.Itoa(d)
+	case 1 | 2:
+		return "odd day"
+	default:
+		return "weekday"
+	}
+}
+
+// countUntil 中的 break 跳出 switch 语句，case 表达式为变量
+func countUntil(xs []int, stop int) int {
+	n := 0
+	for _, x := range xs {
+		switch x {
+		case stop:
+			break
+		case 0:
+			continue
+		default:
+			if x < 0 {
+				break
+			}
+			n++
+		}
+	}
+	return n
+}
+
+// sign 为没有 switch 表达式、包含初始化语句的 switch
+func sign(x int) string {
+	switch y := x * 2; {
+	case y < 0:
+		return "negative"
+	case y == 0, y > 1000:
+		return "zero or large"
+	}
+	return "positive"
+}
+
+// empty 的 switch 语句只有 default clause
+func empty() int {
+	switch tick() {
+	default:
+		return ticks
+	}
+}
+
+func main() {
+	xs := []any{nil, 42, 3.5, func(x int) float64 { return float64(x) / 4 }, true, "go", []int{1}}
+	for _, x := range xs {
+		fmt.Println(describe(x))
+	}
+	fmt.Println(shadow([]any{errors.New("boom"), celsius(21.5), nil, []byte("hi"), 7}))
+	fmt.Println(firstString([]any{nil, "", 1, "first", "second"}), firstString(nil))
+	fmt.Println(sumInts([]int{1, 2, -1, 3}), sumInts(5), sumInts("x"))
+	for _, x := range []any{1, int64(2), nil, errors.New("e"), 1.5} {
+		fmt.Println(kind(x))
+	}
+	fmt.Println(chapter())
+	for _, n := range []int{95, 85, 50, -5} {
+		fmt.Println(n, grade(n), classify(n%20-10))
+	}
+	for d := 0; d < 4; d++ {
+		fmt.Println(weekday(d), ticks)
+	}
+	fmt.Println(countUntil([]int{1, 0, 2, 3, -1, 4}, 3))
+	fmt.Println(sign(-1), sign(0), sign(600), sign(3))
+	fmt.Println(empty(), ticks)
+}
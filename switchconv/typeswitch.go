@@ -0,0 +1,570 @@
+package switchconv
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// typeGen 生成 type switch 对应的 if-else
+type typeGen struct {
+	w      *rewriter
+	names  names
+	b      strings.Builder
+	subj   string // 断言的对象：x 本身，或者保存 x 的临时变量
+	bind   string // switch 语句中声明的变量，没有时为空
+	bodies map[*ast.CaseClause][2]token.Pos
+	edits  []edit
+}
+
+// typeSwitch 将 type switch 改写为 if-else
+func (w *rewriter) typeSwitch(fn *ast.FuncDecl, label *ast.LabeledStmt, s *ast.TypeSwitchStmt) edit {
+	g := &typeGen{w: w, names: w.names(fn), bodies: map[*ast.CaseClause][2]token.Pos{}}
+
+	var x ast.Expr
+	switch a := s.Assign.(type) {
+	case *ast.AssignStmt:
+		g.bind = a.Lhs[0].(*ast.Ident).Name
+		x = a.Rhs[0].(*ast.TypeAssertExpr).X
+	case *ast.ExprStmt:
+		x = a.X.(*ast.TypeAssertExpr).X
+	}
+
+	var cases []*ast.CaseClause
+	var def *ast.CaseClause
+	for i, r := range bodies(s.Body) {
+		c := s.Body.List[i].(*ast.CaseClause)
+		g.bodies[c] = r
+		if c.List == nil {
+			def = c
+		} else {
+			cases = append(cases, c)
+		}
+	}
+
+	// x 为变量且不会被 clause 中声明的同名变量遮蔽时，直接断言 x，否则先保存到临时变量
+	temp := ""
+	if id, ok := x.(*ast.Ident); ok && id.Name != g.bind {
+		g.subj = id.Name
+	} else {
+		temp = g.names.fresh("v")
+		g.subj = temp
+	}
+
+	end := ""
+	if _, found := switchBreaks(s.Body, label, "", w); found {
+		end = endName(g.names, fn, label)
+		g.edits, _ = switchBreaks(s.Body, label, end, w)
+	}
+
+	b := &g.b
+	start := s.Pos()
+	if keepLabel(fn, label) {
+		fmt.Fprintf(b, "%s:\n", label.Label.Name)
+	} else if label != nil {
+		start = label.Pos()
+	}
+	block := s.Init != nil || temp != "" || end != "" || len(cases) == 0 || len(cases[0].List) > 1
+	if block {
+		b.WriteString("{\n")
+	}
+	b.WriteString(w.preamble(s.Body))
+	if s.Init != nil {
+		fmt.Fprintf(b, "%s\n", w.text(s.Init))
+	}
+	if temp != "" {
+		fmt.Fprintf(b, "%s := %s\n", temp, w.text(x))
+	}
+	if len(cases) == 0 {
+		fmt.Fprintf(b, "_ = %s\n", g.subj)
+	}
+	g.chain(cases, def, false)
+	if end != "" {
+		fmt.Fprintf(b, "\n%s:\n", end)
+	}
+	if block {
+		b.WriteString("}")
+	}
+	return edit{w.offset(start), w.offset(s.End()), b.String()}
+}
+
+// chain 生成 if-else，continued 表示前面已经有 if 语句，需要以 else 开始
+func (g *typeGen) chain(cases []*ast.CaseClause, def *ast.CaseClause, continued bool) {
+	b := &g.b
+	for i, c := range cases {
+		elseBranch := i > 0 || continued
+
+		// 多个类型的 clause 不能写成一个断言，先在 else 分支中分别断言，再以 || 连接
+		if len(c.List) > 1 {
+			if elseBranch {
+				b.WriteString(" else {\n")
+			}
+			var conds []string
+			for _, t := range c.List {
+				if g.isNil(t) {
+					conds = append(conds, g.subj+" == nil")
+					continue
+				}
+				flag := g.names.fresh("is" + typeName(t))
+				fmt.Fprintf(b, "_, %s := %s.(%s)\n", flag, g.subj, g.w.text(t))
+				conds = append(conds, flag)
+			}
+			fmt.Fprintf(b, "if %s {", strings.Join(conds, " || "))
+			g.clause(c, true)
+			g.chain(cases[i+1:], def, true)
+			if elseBranch {
+				b.WriteString("\n}")
+			}
+			return
+		}
+
+		if elseBranch {
+			b.WriteString(" else ")
+		}
+		if t := c.List[0]; g.isNil(t) {
+			fmt.Fprintf(b, "if %s == nil {", g.subj)
+			g.clause(c, true)
+		} else {
+			flag := g.names.fresh("is" + typeName(t))
+			v := "_"
+			if g.used(c) {
+				v = g.bind
+			}
+			fmt.Fprintf(b, "if %s, %s := %s.(%s); %s {", v, flag, g.subj, g.w.text(t), flag)
+			g.clause(c, false)
+		}
+	}
+
+	if def != nil {
+		if len(cases) > 0 || continued {
+			b.WriteString(" else ")
+		}
+		b.WriteString("{")
+		g.clause(def, true)
+	}
+}
+
+// clause 输出 clause 的语句以及 `}`，assign 表示在 clause 开头以 `i := x` 声明变量
+func (g *typeGen) clause(c *ast.CaseClause, assign bool) {
+	if assign && g.used(c) {
+		fmt.Fprintf(&g.b, "\n%s := %s", g.bind, g.subj)
+	}
+	r := g.bodies[c]
+	g.b.WriteString(g.w.slice(r[0], r[1], g.edits))
+	g.b.WriteString("}")
+}
+
+// used 表示 clause 中使用了 switch 语句声明的变量
+func (g *typeGen) used(c *ast.CaseClause) bool {
+	obj := g.w.info.Implicits[c]
+	return obj != nil && len(g.w.uses[obj]) > 0
+}
+
+func (g *typeGen) isNil(t ast.Expr) bool {
+	return g.w.info.Types[t].IsNil()
+}
+
+// typeName 返回类型的名字，用于生成断言结果的变量名，比如 int 为 Int，*T 为 TPtr，[]byte 为 ByteSlice
+func typeName(t ast.Expr) string {
+	switch t := t.(type) {
+	case *ast.Ident:
+		return upper(t.Name)
+	case *ast.SelectorExpr:
+		return upper(t.Sel.Name)
+	case *ast.ParenExpr:
+		return typeName(t.X)
+	case *ast.StarExpr:
+		return typeName(t.X) + "Ptr"
+	case *ast.IndexExpr:
+		return typeName(t.X)
+	case *ast.IndexListExpr:
+		return typeName(t.X)
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return typeName(t.Elt) + "Slice"
+		}
+		return typeName(t.Elt) + "Array"
+	case *ast.MapType:
+		return "Map"
+	case *ast.ChanType:
+		return "Chan"
+	case *ast.FuncType:
+		return "Func"
+	case *ast.InterfaceType:
+		return "Interface"
+	case *ast.StructType:
+		return "Struct"
+	}
+	return "Type"
+}
+
+// typeCase 为 if-else 中与 type switch 的 clause 对应的分支
+type typeCase struct {
+	list     []ast.Expr // 为空时为 default clause
+	body     *ast.BlockStmt
+	bindStmt ast.Stmt   // 分支开头的 `i := x`
+	bind     *ast.Ident // 分支中声明 i 的位置，没有声明时为 nil
+	multi    bool       // 通过 || 连接的断言
+}
+
+// flagDef 为多个类型的 clause 中的断言 `_, isT := x.(T)`
+type flagDef struct {
+	flag *ast.Ident
+	typ  ast.Expr
+}
+
+// typeChain 为识别出的 if-else
+type typeChain struct {
+	subj   []*ast.Ident // if-else 中所有引用断言对象的位置
+	bind   string
+	flags  []*ast.Ident // 断言结果的变量
+	cases  []typeCase
+	blocks []*ast.BlockStmt
+}
+
+func (c *typeChain) subject(id *ast.Ident) bool {
+	if len(c.subj) > 0 && c.subj[0].Name != id.Name {
+		return false
+	}
+	c.subj = append(c.subj, id)
+	return true
+}
+
+func (c *typeChain) binding(id *ast.Ident) bool {
+	if c.bind != "" && c.bind != id.Name {
+		return false
+	}
+	c.bind = id.Name
+	return true
+}
+
+// typeIf 识别 typeSwitch 生成的 if-else（可以包含在 block 中），将其改写为 type switch
+func (w *rewriter) typeIf(fn *ast.FuncDecl, s ast.Stmt) (edit, bool) {
+	c := &typeChain{}
+	var prefix []ast.Stmt
+	end := ""
+
+	switch s := s.(type) {
+	case *ast.IfStmt:
+		if !w.typeChain(s, c, nil) {
+			return edit{}, false
+		}
+	case *ast.BlockStmt:
+		var stmts []ast.Stmt
+		stmts, end = endLabel(s.List)
+		n := len(stmts)
+		if n == 0 {
+			return edit{}, false
+		}
+		first, ok := stmts[n-1].(*ast.IfStmt)
+		if !ok {
+			return edit{}, false
+		}
+		i := n - 1
+		var pending []flagDef
+		for i > 0 {
+			f, ok := c.flagDef(stmts[i-1])
+			if !ok {
+				break
+			}
+			pending = append([]flagDef{f}, pending...)
+			i--
+		}
+		if !w.typeChain(first, c, pending) {
+			return edit{}, false
+		}
+		prefix = stmts[:i]
+	default:
+		return edit{}, false
+	}
+
+	// 至少包含一个类型断言，否则只是普通的 nil 判断
+	asserted := false
+	for _, tc := range c.cases {
+		for _, t := range tc.list {
+			asserted = asserted || !w.info.Types[t].IsNil()
+		}
+	}
+	subj := w.info.Uses[c.subj[0]]
+	if !asserted || subj == nil {
+		return edit{}, false
+	}
+	for _, id := range c.subj {
+		if w.info.Uses[id] != subj {
+			return edit{}, false
+		}
+	}
+	if _, ok := subj.Type().(*types.TypeParam); ok || !types.IsInterface(subj.Type()) {
+		return edit{}, false
+	}
+
+	// 断言结果的变量只在条件中使用
+	for _, flag := range c.flags {
+		if len(w.uses[w.info.Defs[flag]]) != 1 {
+			return edit{}, false
+		}
+	}
+
+	// 没有声明 i 的分支不能引用外层的 i，改写后这些引用会变成 switch 语句声明的 i
+	if c.bind != "" {
+		for _, tc := range c.cases {
+			if tc.bind == nil && w.refers(tc.body, c.bind) {
+				return edit{}, false
+			}
+			if tc.bind != nil && tc.multi && len(tc.list) == 1 {
+				return edit{}, false // `i := x` 的类型与 case T 中 i 的类型不同
+			}
+		}
+	}
+
+	// block 中最后一条语句为 `v := x` 并且 v 只用于断言时，v 为临时变量，x 为 switch 的断言对象
+	guard := c.subj[0].Name
+	if n := len(prefix); n > 0 {
+		if id, x, ok := define(prefix[n-1]); ok && w.info.Defs[id] == subj && w.only(subj, c.subj) {
+			guard = w.text(x)
+			if !isPrimary(x) {
+				guard = "(" + guard + ")"
+			}
+			prefix = prefix[:n-1]
+		}
+	}
+	if len(prefix) > 1 || len(prefix) == 1 && !isSimple(prefix[0]) {
+		return edit{}, false
+	}
+
+	gotos, labeled, ok := w.endGotos(c.blocks, end)
+	if !ok {
+		return edit{}, false
+	}
+
+	var b strings.Builder
+	if labeled {
+		fmt.Fprintf(&b, "%s:\n", end)
+	}
+	b.WriteString("switch ")
+	if len(prefix) == 1 {
+		fmt.Fprintf(&b, "%s; ", w.text(prefix[0]))
+	}
+	if c.bind != "" {
+		fmt.Fprintf(&b, "%s := ", c.bind)
+	}
+	fmt.Fprintf(&b, "%s.(type) {\n", guard)
+	for _, tc := range c.cases {
+		if tc.list == nil {
+			b.WriteString("default:")
+		} else {
+			var types []string
+			for _, t := range tc.list {
+				types = append(types, w.text(t))
+			}
+			fmt.Fprintf(&b, "case %s:", strings.Join(types, ", "))
+		}
+		edits := gotos
+		start := tc.body.Lbrace + 1
+		if tc.bindStmt != nil {
+			start = tc.bindStmt.End()
+		}
+		b.WriteString(strings.TrimRight(w.slice(start, tc.body.Rbrace, append([]edit{}, edits...)), " \t\n"))
+		b.WriteString("\n")
+	}
+	b.WriteString("}")
+	return w.replace(s, b.String()), true
+}
+
+// typeChain 识别 if-else 中的每个分支，pending 为前面的多个类型的断言
+func (w *rewriter) typeChain(s *ast.IfStmt, c *typeChain, pending []flagDef) bool {
+	for {
+		tc, ok := w.typeCond(s, c, pending)
+		if !ok {
+			return false
+		}
+		tc.body = s.Body
+		if tc.bind == nil && (tc.multi || len(tc.list) == 1 && w.info.Types[tc.list[0]].IsNil()) && !c.bindStmt(&tc) {
+			return false
+		}
+		c.cases = append(c.cases, tc)
+		c.blocks = append(c.blocks, s.Body)
+
+		pending = nil
+		switch e := s.Else.(type) {
+		case nil:
+			return true
+		case *ast.IfStmt:
+			s = e
+		case *ast.BlockStmt:
+			if n := len(e.List); n >= 2 {
+				if next, ok := e.List[n-1].(*ast.IfStmt); ok {
+					var flags []flagDef
+					nsubj, nflags := len(c.subj), len(c.flags)
+					for _, stmt := range e.List[:n-1] {
+						f, ok := c.flagDef(stmt)
+						if !ok {
+							flags = nil
+							c.subj, c.flags = c.subj[:nsubj], c.flags[:nflags]
+							break
+						}
+						flags = append(flags, f)
+					}
+					if flags != nil {
+						s, pending = next, flags
+						continue
+					}
+				}
+			}
+			def := typeCase{body: e}
+			if !c.bindStmt(&def) {
+				return false
+			}
+			c.cases = append(c.cases, def)
+			c.blocks = append(c.blocks, e)
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// typeCond 识别分支的条件：`x == nil`、`i, isT := x.(T); isT`，或者以 || 连接的多个类型的断言结果
+func (w *rewriter) typeCond(s *ast.IfStmt, c *typeChain, pending []flagDef) (typeCase, bool) {
+	if len(pending) > 0 {
+		if s.Init != nil {
+			return typeCase{}, false
+		}
+		tc := typeCase{multi: true}
+		unused := map[string]flagDef{}
+		for _, f := range pending {
+			unused[f.flag.Name] = f
+		}
+		for _, part := range splitOr(s.Cond) {
+			if id, ok := part.(*ast.Ident); ok {
+				f, ok := unused[id.Name]
+				if !ok {
+					return typeCase{}, false
+				}
+				delete(unused, id.Name)
+				tc.list = append(tc.list, f.typ)
+				continue
+			}
+			x, ok := c.isNil(w, part)
+			if !ok || !c.subject(x) {
+				return typeCase{}, false
+			}
+			tc.list = append(tc.list, part.(*ast.BinaryExpr).Y)
+		}
+		return tc, len(unused) == 0
+	}
+
+	if s.Init == nil {
+		x, ok := c.isNil(w, s.Cond)
+		if !ok || !c.subject(x) {
+			return typeCase{}, false
+		}
+		return typeCase{list: []ast.Expr{s.Cond.(*ast.BinaryExpr).Y}}, true
+	}
+
+	a, ok := s.Init.(*ast.AssignStmt)
+	if !ok || a.Tok != token.DEFINE || len(a.Lhs) != 2 || len(a.Rhs) != 1 {
+		return typeCase{}, false
+	}
+	ta, ok := a.Rhs[0].(*ast.TypeAssertExpr)
+	if !ok || ta.Type == nil {
+		return typeCase{}, false
+	}
+	x, ok1 := ta.X.(*ast.Ident)
+	bind, ok2 := a.Lhs[0].(*ast.Ident)
+	flag, ok3 := a.Lhs[1].(*ast.Ident)
+	cond, ok4 := s.Cond.(*ast.Ident)
+	if !ok1 || !ok2 || !ok3 || !ok4 || flag.Name == "_" || cond.Name != flag.Name || !c.subject(x) {
+		return typeCase{}, false
+	}
+	c.flags = append(c.flags, flag)
+	tc := typeCase{list: []ast.Expr{ta.Type}}
+	if bind.Name != "_" {
+		if !c.binding(bind) {
+			return typeCase{}, false
+		}
+		tc.bind = bind
+	}
+	return tc, true
+}
+
+// flagDef 识别 `_, isT := x.(T)`
+func (c *typeChain) flagDef(s ast.Stmt) (flagDef, bool) {
+	a, ok := s.(*ast.AssignStmt)
+	if !ok || a.Tok != token.DEFINE || len(a.Lhs) != 2 || len(a.Rhs) != 1 {
+		return flagDef{}, false
+	}
+	blank, ok1 := a.Lhs[0].(*ast.Ident)
+	flag, ok2 := a.Lhs[1].(*ast.Ident)
+	ta, ok3 := a.Rhs[0].(*ast.TypeAssertExpr)
+	if !ok1 || !ok2 || !ok3 || blank.Name != "_" || flag.Name == "_" || ta.Type == nil {
+		return flagDef{}, false
+	}
+	x, ok := ta.X.(*ast.Ident)
+	if !ok || !c.subject(x) {
+		return flagDef{}, false
+	}
+	c.flags = append(c.flags, flag)
+	return flagDef{flag: flag, typ: ta.Type}, true
+}
+
+// bindStmt 识别分支开头的 `i := x`
+func (c *typeChain) bindStmt(tc *typeCase) bool {
+	if len(tc.body.List) == 0 {
+		return true
+	}
+	id, x, ok := define(tc.body.List[0])
+	xid, isIdent := x.(*ast.Ident)
+	if !ok || !isIdent || len(c.subj) == 0 || xid.Name != c.subj[0].Name {
+		return true
+	}
+	if !c.binding(id) {
+		return false
+	}
+	c.subj = append(c.subj, xid)
+	tc.bindStmt, tc.bind = tc.body.List[0], id
+	return true
+}
+
+// isNil 识别 `x == nil`
+func (c *typeChain) isNil(w *rewriter, e ast.Expr) (*ast.Ident, bool) {
+	be, ok := e.(*ast.BinaryExpr)
+	if !ok || be.Op != token.EQL || !w.info.Types[be.Y].IsNil() {
+		return nil, false
+	}
+	x, ok := be.X.(*ast.Ident)
+	return x, ok
+}
+
+// refers 表示 block 中引用了名为 name、在 block 之外声明的对象
+func (w *rewriter) refers(blk *ast.BlockStmt, name string) bool {
+	found := false
+	ast.Inspect(blk, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && id.Name == name {
+			if obj := w.info.Uses[id]; obj != nil && (obj.Pos() < blk.Pos() || obj.Pos() >= blk.End()) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// splitOr 拆分以 || 连接的表达式
+func splitOr(e ast.Expr) []ast.Expr {
+	if be, ok := e.(*ast.BinaryExpr); ok && be.Op == token.LOR {
+		return append(splitOr(be.X), splitOr(be.Y)...)
+	}
+	return []ast.Expr{e}
+}
+
+// isPrimary 表示表达式可以直接作为 x.(type) 中的 x
+func isPrimary(e ast.Expr) bool {
+	switch e.(type) {
+	case *ast.Ident, *ast.CallExpr, *ast.SelectorExpr, *ast.IndexExpr, *ast.IndexListExpr, *ast.ParenExpr, *ast.TypeAssertExpr:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,269 @@
+/*
+Package switchlint 是检查 switch 语句中隐藏的控制流的 go/analysis analyzer，参考 05-flow-control-statements 中的 Switch-case
+
+	章节中 fallthrough 的规则（只能用于 expression switch、不能位于最后一个 clause、必须是 clause 的最后一个语句）
+	由编译器检查，analyzer 只会看到通过类型检查的代码，所以这里检查的是能够编译、但容易看错的写法：
+		* fallthroughdefault：default clause 可以出现在 switch 中的任意位置，
+			fallthrough 进入位于中间的 default clause，或者从中间的 default clause fallthrough 到下一个 case，
+			都会让 clause 在没有匹配的情况下执行
+		* dupcase：编译器只检查带 switch 表达式时重复的常量 case，
+			没有 switch 表达式时，`case x == 1` 与后面的 `case x == one` 重复，后者永远不会执行
+		* caseconv：case 的值只有经过类型转换才与 switch 表达式的类型相同，
+			比如章节 main 函数中的 `case 16, int(32.0)`，以及 switch 表达式为 int 时的 `case 2.0`
+
+	通过 cmd/tourvet 与 go vet 一起运行：
+		```
+			go build -o /tmp/tourvet ./cmd/tourvet
+			go vet -vettool=/tmp/tourvet ./05-flow-control-statements
+		```
+*/
+package switchlint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzers 为 switchlint 中的所有 analyzer
+var Analyzers = []*analysis.Analyzer{FallthroughDefault, DuplicateCase, CaseConversion}
+
+var FallthroughDefault = &analysis.Analyzer{
+	Name:     "fallthroughdefault",
+	Doc:      "report fallthrough into or out of a default clause that is not the last clause of a switch",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runFallthroughDefault,
+}
+
+var DuplicateCase = &analysis.Analyzer{
+	Name:     "dupcase",
+	Doc:      "report repeated constant conditions in switches without a tag, which the compiler does not reject",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runDuplicateCase,
+}
+
+var CaseConversion = &analysis.Analyzer{
+	Name:     "caseconv",
+	Doc:      "report case values that only match the switch tag after converting a float or complex constant to an integer or float type",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runCaseConversion,
+}
+
+// switches 对 pass 中的每个 expression switch 调用 f
+func switches(pass *analysis.Pass, f func(s *ast.SwitchStmt)) {
+	in := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	in.Preorder([]ast.Node{(*ast.SwitchStmt)(nil)}, func(n ast.Node) {
+		f(n.(*ast.SwitchStmt))
+	})
+}
+
+func runFallthroughDefault(pass *analysis.Pass) (interface{}, error) {
+	switches(pass, func(s *ast.SwitchStmt) {
+		clauses := s.Body.List
+		for i, c := range clauses[:max(len(clauses)-1, 0)] {
+			c := c.(*ast.CaseClause)
+			ft := lastFallthrough(c)
+			if ft == nil {
+				continue
+			}
+			next := clauses[i+1].(*ast.CaseClause)
+			switch {
+			case next.List == nil && i+1 < len(clauses)-1:
+				pass.Reportf(ft.Pos(), "fallthrough into a default clause in the middle of the switch: the default clause also runs after this case matches")
+			case c.List == nil:
+				pass.Reportf(ft.Pos(), "fallthrough out of a default clause: the next case runs when no case matches, without checking %s",
+					exprList(next.List))
+			}
+		}
+	})
+	return nil, nil
+}
+
+func lastFallthrough(c *ast.CaseClause) *ast.BranchStmt {
+	if n := len(c.Body); n > 0 {
+		if b, ok := c.Body[n-1].(*ast.BranchStmt); ok && b.Tok == token.FALLTHROUGH {
+			return b
+		}
+	}
+	return nil
+}
+
+func runDuplicateCase(pass *analysis.Pass) (interface{}, error) {
+	switches(pass, func(s *ast.SwitchStmt) {
+		// switch true 与没有 switch 表达式的 switch 相同
+		if s.Tag != nil {
+			if tv := pass.TypesInfo.Types[s.Tag]; tv.Value == nil || tv.Value.Kind() != constant.Bool || !constant.BoolVal(tv.Value) {
+				return
+			}
+		}
+
+		seen := map[string]ast.Expr{}
+		for _, c := range s.Body.List {
+			for _, e := range c.(*ast.CaseClause).List {
+				for _, cond := range splitOr(e) {
+					key, ok := conditionKey(pass.TypesInfo, cond)
+					if !ok {
+						continue
+					}
+					if prev, ok := seen[key]; ok {
+						pass.Reportf(cond.Pos(), "duplicate case %s: same condition as %s at line %d, so it is never the first to match",
+							types.ExprString(cond), types.ExprString(prev), pass.Fset.Position(prev.Pos()).Line)
+						continue
+					}
+					seen[key] = cond
+				}
+			}
+		}
+	})
+	return nil, nil
+}
+
+// conditionKey 返回常量条件，或者 `x == 常量` 条件的唯一表示；x 只能是变量、字段等没有副作用的表达式
+func conditionKey(info *types.Info, e ast.Expr) (string, bool) {
+	e = ast.Unparen(e)
+	if tv := info.Types[e]; tv.Value != nil {
+		return "const " + tv.Value.ExactString(), true
+	}
+	be, ok := e.(*ast.BinaryExpr)
+	if !ok || be.Op != token.EQL {
+		return "", false
+	}
+	x, y := be.X, be.Y
+	if info.Types[x].Value != nil {
+		x, y = y, x
+	}
+	yv := info.Types[y].Value
+	if yv == nil || info.Types[x].Value != nil || !isVariable(info, x) {
+		return "", false
+	}
+	return types.ExprString(x) + " == " + yv.ExactString() + " " + info.Types[y].Type.String(), true
+}
+
+// isVariable 表示 e 由变量、字段以及常量下标组成
+func isVariable(info *types.Info, e ast.Expr) bool {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		_, ok := info.Uses[e].(*types.Var)
+		return ok
+	case *ast.SelectorExpr:
+		if sel := info.Selections[e]; sel == nil || sel.Kind() != types.FieldVal {
+			return false
+		}
+		return isVariable(info, e.X)
+	case *ast.IndexExpr:
+		return info.Types[e.Index].Value != nil && isVariable(info, e.X)
+	}
+	return false
+}
+
+func splitOr(e ast.Expr) []ast.Expr {
+	if be, ok := ast.Unparen(e).(*ast.BinaryExpr); ok && be.Op == token.LOR {
+		return append(splitOr(be.X), splitOr(be.Y)...)
+	}
+	return []ast.Expr{e}
+}
+
+func runCaseConversion(pass *analysis.Pass) (interface{}, error) {
+	info := pass.TypesInfo
+	switches(pass, func(s *ast.SwitchStmt) {
+		if s.Tag == nil {
+			return
+		}
+		tag, ok := info.Types[s.Tag].Type.Underlying().(*types.Basic)
+		if !ok {
+			return
+		}
+		for _, c := range s.Body.List {
+			for _, e := range c.(*ast.CaseClause).List {
+				if info.Types[e].Value == nil {
+					continue
+				}
+				// 显式转换：case int(32.0)
+				if call, ok := ast.Unparen(e).(*ast.CallExpr); ok && len(call.Args) == 1 && info.Types[call.Fun].IsType() {
+					to, ok := info.Types[call].Type.Underlying().(*types.Basic)
+					if from := untypedKind(info, call.Args[0]); ok && narrows(from, to) {
+						pass.Reportf(e.Pos(), "case %s matches only after converting the %s constant %s to %s",
+							types.ExprString(e), kindName(from), types.ExprString(call.Args[0]), info.Types[call].Type)
+					}
+					continue
+				}
+				// 隐式转换：switch 表达式为 int 时的 case 2.0
+				if from := untypedKind(info, e); narrows(from, tag) {
+					pass.Reportf(e.Pos(), "case %s matches only after implicitly converting the %s constant to %s",
+						types.ExprString(e), kindName(from), info.Types[s.Tag].Type)
+				}
+			}
+		}
+	})
+	return nil, nil
+}
+
+// untypedKind 返回无类型常量表达式在转换之前的种类（types.UntypedFloat 等），不是无类型常量时返回 types.Invalid
+func untypedKind(info *types.Info, e ast.Expr) types.BasicKind {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.INT:
+			return types.UntypedInt
+		case token.CHAR:
+			return types.UntypedRune
+		case token.FLOAT:
+			return types.UntypedFloat
+		case token.IMAG:
+			return types.UntypedComplex
+		}
+	case *ast.Ident:
+		if c, ok := info.Uses[e].(*types.Const); ok {
+			if b, ok := c.Type().(*types.Basic); ok && b.Info()&types.IsUntyped != 0 {
+				return b.Kind()
+			}
+		}
+	case *ast.UnaryExpr:
+		return untypedKind(info, e.X)
+	case *ast.BinaryExpr:
+		if e.Op == token.SHL || e.Op == token.SHR {
+			return untypedKind(info, e.X)
+		}
+		x, y := untypedKind(info, e.X), untypedKind(info, e.Y)
+		if x == types.Invalid || y == types.Invalid {
+			return types.Invalid
+		}
+		// 无类型常量的运算结果取两者中靠后的种类：integer < rune < floating-point < complex
+		return max(x, y)
+	}
+	return types.Invalid
+}
+
+// narrows 表示种类为 from 的常量转换为 to 时，浮点数变为整数，或者复数变为浮点数
+func narrows(from types.BasicKind, to *types.Basic) bool {
+	switch from {
+	case types.UntypedFloat:
+		return to.Info()&types.IsInteger != 0
+	case types.UntypedComplex:
+		return to.Info()&(types.IsInteger|types.IsFloat) != 0
+	}
+	return false
+}
+
+func kindName(k types.BasicKind) string {
+	if k == types.UntypedComplex {
+		return "complex"
+	}
+	return "float"
+}
+
+func exprList(list []ast.Expr) string {
+	s := ""
+	for i, e := range list {
+		if i > 0 {
+			s += ", "
+		}
+		s += types.ExprString(e)
+	}
+	return s
+}
@@ -0,0 +1,28 @@
+package caseconv
+
+const one = 1
+
+const half = 0.5
+
+func conversions(x int, b byte, f float64, c complex128) {
+	switch x {
+	case 16, int(32.0): // want `case int\(32.0\) matches only after converting the float constant 32.0 to int`
+	case 2.0: // want `case 2.0 matches only after implicitly converting the float constant to int`
+	case half * 6: // want `case half \* 6 matches only after implicitly converting the float constant to int`
+	case 'a', 1 << 6, int(one):
+	}
+
+	switch b {
+	case 'a', byte(98), 0x63:
+	case 1e2: // want `case 1e2 matches only after implicitly converting the float constant to byte`
+	}
+
+	switch f {
+	case 1, 2.5, float64(3):
+	case 2i * 2i: // want `case 2i \* 2i matches only after implicitly converting the complex constant to float64`
+	}
+
+	switch c {
+	case 1, 2.5, 3i:
+	}
+}
@@ -0,0 +1,36 @@
+package dupcase
+
+const one = 1
+
+type point struct{ x, y int }
+
+func duplicates(x int, p point, xs [2]int, f func() int) {
+	switch {
+	case x == 1:
+	case x == 2, one == x: // want `duplicate case one == x: same condition as x == 1 at line 9`
+	case p.x == 1 || x == 3:
+	case p.x == 1: // want `duplicate case p.x == 1`
+	case xs[0] == 1, xs[1] == 1:
+	case x == 3: // want `duplicate case x == 3: same condition as x == 3 at line 11`
+	case f() == 1, f() == 1:
+	case x > 1, x > 1:
+	}
+
+	switch true {
+	case x == 1:
+	case x == 1.0: // want `duplicate case x == 1.0`
+	}
+
+	var v any = x
+	switch {
+	case v == 1, v == int64(1), v == 1.0:
+	case v == 1: // want `duplicate case v == 1`
+	}
+
+	const debug = false
+	switch {
+	case debug:
+	case x == 4:
+	case false: // want `duplicate case false: same condition as debug`
+	}
+}
@@ -0,0 +1,26 @@
+package fallthroughdefault
+
+func fallthroughs(n int) {
+	switch {
+	case n > 0:
+		fallthrough // want `fallthrough into a default clause in the middle of the switch`
+	default:
+		fallthrough // want `fallthrough out of a default clause: the next case runs when no case matches, without checking n < 0, n == 0`
+	case n < 0, n == 0:
+	case n > 10:
+	}
+
+	// default 位于最后时，fallthrough 进入 default 与章节中的写法相同
+	switch 16 {
+	case 16:
+		fallthrough
+	default:
+	}
+
+	switch {
+	default:
+	case n > 0:
+		fallthrough
+	case n > 1:
+	}
+}
@@ -0,0 +1,96 @@
+package web
+
+import (
+	"go/scanner"
+	"go/token"
+	"html"
+	"html/template"
+	"strings"
+)
+
+// 预声明的类型以及内置函数，高亮为 builtin
+var predeclared = map[string]bool{
+	"bool": true, "byte": true, "complex64": true, "complex128": true, "error": true,
+	"float32": true, "float64": true, "int": true, "int8": true, "int16": true,
+	"int32": true, "int64": true, "rune": true, "string": true, "uint": true,
+	"uint8": true, "uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	"any": true, "comparable": true,
+
+	"true": true, "false": true, "iota": true, "nil": true,
+
+	"append": true, "cap": true, "clear": true, "close": true, "complex": true,
+	"copy": true, "delete": true, "imag": true, "len": true, "make": true,
+	"max": true, "min": true, "new": true, "panic": true, "print": true,
+	"println": true, "real": true, "recover": true,
+}
+
+// Highlight 使用 go/scanner 将 go 代码转换为带语法高亮的 HTML，
+// 代码不需要是完整的 go 文件，无法识别的部分原样输出
+func Highlight(code string) template.HTML {
+	src := []byte(code)
+	fset := token.NewFileSet()
+	file := fset.AddFile("", -1, len(src))
+
+	var s scanner.Scanner
+	s.Init(file, src, func(token.Position, string) {}, scanner.ScanComments)
+
+	var b strings.Builder
+	last := 0
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		// 自动插入的分号不对应源码中的任何文本
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue
+		}
+
+		start := file.Offset(pos)
+		end := start + len(tokenText(tok, lit))
+		if tok == token.STRING && strings.HasPrefix(lit, "`") {
+			// raw string 的 lit 去掉了 \r，长度可能与源码不一致
+			if i := strings.IndexByte(code[start+1:], '`'); i >= 0 {
+				end = start + i + 2
+			}
+		}
+		if start < last || end > len(code) {
+			continue
+		}
+
+		b.WriteString(html.EscapeString(code[last:start]))
+		text := html.EscapeString(code[start:end])
+		if class := tokenClass(tok, lit); class != "" {
+			b.WriteString(`<span class="` + class + `">` + text + `</span>`)
+		} else {
+			b.WriteString(text)
+		}
+		last = end
+	}
+	b.WriteString(html.EscapeString(code[last:]))
+
+	return template.HTML(b.String())
+}
+
+func tokenText(tok token.Token, lit string) string {
+	if lit != "" {
+		return lit
+	}
+	return tok.String()
+}
+
+func tokenClass(tok token.Token, lit string) string {
+	switch {
+	case tok == token.COMMENT:
+		return "comment"
+	case tok == token.STRING || tok == token.CHAR:
+		return "string"
+	case tok == token.INT || tok == token.FLOAT || tok == token.IMAG:
+		return "number"
+	case tok.IsKeyword():
+		return "keyword"
+	case tok == token.IDENT && predeclared[lit]:
+		return "builtin"
+	}
+	return ""
+}
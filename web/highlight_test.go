@@ -0,0 +1,32 @@
+package web
+
+import "testing"
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		code, want string
+	}{
+		{
+			`x := len("a<b") // 注释`,
+			`x := <span class="builtin">len</span>(<span class="string">&#34;a&lt;b&#34;</span>) <span class="comment">// 注释</span>`,
+		},
+		{
+			"func f() {\n\treturn 42\n}",
+			"<span class=\"keyword\">func</span> f() {\n\t<span class=\"keyword\">return</span> <span class=\"number\">42</span>\n}",
+		},
+		{
+			// 示意代码中的 `...` 以及非法字符原样输出
+			"for a < b {\n\t...\n}",
+			"<span class=\"keyword\">for</span> a &lt; b {\n\t...\n}",
+		},
+		{
+			"s := `raw\n` + \"中国\"",
+			"s := <span class=\"string\">`raw\n`</span> + <span class=\"string\">&#34;中国&#34;</span>",
+		},
+	}
+	for _, tt := range tests {
+		if got := string(Highlight(tt.code)); got != tt.want {
+			t.Errorf("Highlight(%q)\n got: %s\nwant: %s", tt.code, got, tt.want)
+		}
+	}
+}
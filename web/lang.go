@@ -0,0 +1,129 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/SamHwang1990/go-tour/chapter"
+	"github.com/SamHwang1990/go-tour/lesson"
+	"github.com/SamHwang1990/go-tour/lesson/i18n"
+)
+
+// langView 为页面顶部的语言切换链接
+type langView struct {
+	Lang    string
+	Label   string
+	Current bool
+}
+
+var langLabels = []langView{
+	{Lang: i18n.Source, Label: "中文"},
+	{Lang: "en", Label: "English"},
+	{Lang: i18n.Both, Label: "中英对照"},
+}
+
+// lang 返回请求所使用的语言：?lang= 参数，未指定或者不支持时为 Server.Lang
+func (s *Server) lang(r *http.Request) string {
+	if lang := r.URL.Query().Get("lang"); i18n.Valid(lang) {
+		return lang
+	}
+	return s.Lang
+}
+
+// query 返回页面链接需要附带的查询参数，使用默认语言时为空
+func (s *Server) query(lang string) string {
+	if lang == s.Lang {
+		return ""
+	}
+	return "?lang=" + lang
+}
+
+func langs(current string) []langView {
+	list := make([]langView, len(langLabels))
+	for i, l := range langLabels {
+		l.Current = l.Lang == current
+		list[i] = l
+	}
+	return list
+}
+
+// sectionView 为页面中的一个小节，中英对照时 Translation 为译文标题
+type sectionView struct {
+	ID          string
+	Title       string
+	Translation string
+	Level       int
+	Blocks      []blockView
+	Sections    []*sectionView
+}
+
+// blockView 为页面中的一段内容，中英对照时 Translation 为与原文并排显示的译文
+type blockView struct {
+	lesson.Block
+	Translation *lesson.Block
+}
+
+// lessonView 为按语言组织的课程内容
+type lessonView struct {
+	Title       string
+	Translation string
+	Intro       []blockView
+	Sections    []*sectionView
+}
+
+// loadLesson 按语言加载课程：zh、en 只显示一种语言，both 使用两栏并排显示原文以及译文
+func loadLesson(c chapter.Chapter, lang string) (*lesson.Lesson, lessonView, error) {
+	source := lesson.Load(c)
+	translated := source
+	if lang != i18n.Source {
+		catLang := lang
+		if lang == i18n.Both {
+			catLang = "en"
+		}
+		cat, err := i18n.Load(c, catLang)
+		if err != nil {
+			return nil, lessonView{}, err
+		}
+		translated = i18n.Localize(source, cat)
+	}
+
+	if lang != i18n.Both {
+		source = translated
+	}
+	v := lessonView{Title: source.Title, Intro: pairBlocks(source.Intro, translated.Intro)}
+	if translated.Title != source.Title {
+		v.Translation = translated.Title
+	}
+	v.Sections = pairSections(source.Sections, translated.Sections)
+	return source, v, nil
+}
+
+// pairSections 合并原文以及译文的小节，Localize 不改变小节结构，所以两者一一对应
+func pairSections(source, translated []*lesson.Section) []*sectionView {
+	var list []*sectionView
+	for i, s := range source {
+		t := translated[i]
+		v := &sectionView{
+			ID:       s.ID,
+			Title:    s.Title,
+			Level:    s.Level,
+			Blocks:   pairBlocks(s.Blocks, t.Blocks),
+			Sections: pairSections(s.Sections, t.Sections),
+		}
+		if t.Title != s.Title {
+			v.Translation = t.Title
+		}
+		list = append(list, v)
+	}
+	return list
+}
+
+func pairBlocks(source, translated []lesson.Block) []blockView {
+	list := make([]blockView, len(source))
+	for i, b := range source {
+		list[i].Block = b
+		if t := translated[i]; t.Text != b.Text {
+			list[i].Translation = &translated[i]
+		}
+	}
+	return list
+}
@@ -0,0 +1,102 @@
+package web
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"html"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/SamHwang1990/go-tour/chapter"
+	"github.com/SamHwang1990/go-tour/lesson"
+)
+
+var (
+	inlineCodePattern = regexp.MustCompile("`([^`\n]+)`")
+	linkPattern       = regexp.MustCompile(`\[([^\]\n]+)\]\((https?://[^)\s]+)\)`)
+	urlPattern        = regexp.MustCompile(`(^|[\s：:])(https?://[^\s<)]+)`)
+)
+
+// renderText 将 doc comment 中的普通文本转换为 HTML，保留缩进以及换行（由 CSS 的 white-space 负责），
+// 并转换行内代码 ` code `、Markdown 链接以及 URL
+func renderText(text string) template.HTML {
+	s := html.EscapeString(text)
+	s = inlineCodePattern.ReplaceAllStringFunc(s, func(m string) string {
+		code := strings.TrimSpace(m[1 : len(m)-1])
+		return "<code>" + code + "</code>"
+	})
+	s = linkPattern.ReplaceAllString(s, `<a href="$2">$1</a>`)
+	s = urlPattern.ReplaceAllString(s, `$1<a href="$2">$2</a>`)
+	return template.HTML(s)
+}
+
+// renderBlock 将课程中的一段内容转换为 HTML
+func renderBlock(b lesson.Block) template.HTML {
+	switch {
+	case b.Spec != nil:
+		return template.HTML(`<pre class="spec">` + html.EscapeString(b.Spec.Source) + `</pre>`)
+	case b.Example != nil:
+		code := template.HTML(html.EscapeString(b.Example.Code))
+		if b.Example.Lang == "go" {
+			code = Highlight(b.Example.Code)
+		}
+		return template.HTML(`<pre class="code">`) + code + template.HTML(`</pre>`)
+	default:
+		return template.HTML(`<div class="text">`) + renderText(dedent(b.Text)) + template.HTML(`</div>`)
+	}
+}
+
+// dedent 去掉所有行的公共缩进，制表符按 4 个空格显示由 CSS tab-size 负责
+func dedent(text string) string {
+	lines := strings.Split(strings.Trim(text, "\n"), "\n")
+	prefix := ""
+	first := true
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if first {
+			prefix, first = indent, false
+			continue
+		}
+		for !strings.HasPrefix(indent, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, prefix)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// demoSource 返回 demo 函数（或 main 函数）的源码
+func demoSource(c chapter.Chapter, d chapter.Demo) (string, error) {
+	path := filepath.Join(c.Dir, d.File)
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return "", err
+	}
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Name.Name != d.Name {
+			continue
+		}
+		start := fn.Pos()
+		if fn.Doc != nil {
+			start = fn.Doc.Pos()
+		}
+		return string(src[fset.Position(start).Offset:fset.Position(fn.End()).Offset]), nil
+	}
+	return "", os.ErrNotExist
+}
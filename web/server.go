@@ -0,0 +1,319 @@
+/*
+Package web 提供浏览课程的本地 HTTP 服务，完全离线运行
+
+	页面：
+		* `/`，章节列表
+		* `/<chapter>`，章节课程，比如 `/07-slices`：
+			** doc comment 渲染为 HTML，```go 代码块使用 go/scanner 进行语法高亮
+			** main 函数以及每个 demo 函数都有一个 Run 按钮
+		* `POST /run/<chapter>/<demo>`，编译并运行 demo 函数（demo 为 main 时运行整个章节），
+			以纯文本的形式流式返回程序的标准输出以及标准错误
+
+	运行限制：
+		* 每次运行都在临时目录中编译（参考 chapter.Workspace），不依赖远程 playground
+		* 程序运行超过 Timeout 会被终止
+		* 输出超过 MaxOutput 字节时会被截断，并终止程序
+		* 同时运行的程序数量不超过 CPU 数量
+*/
+package web
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SamHwang1990/go-tour/chapter"
+	"github.com/SamHwang1990/go-tour/lesson"
+	"github.com/SamHwang1990/go-tour/lesson/i18n"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+//go:embed static
+var staticFS embed.FS
+
+// Server 为课程的 HTTP 服务
+type Server struct {
+	Timeout      time.Duration // 单次运行的超时时间，不包括编译时间
+	BuildTimeout time.Duration // 单次编译的超时时间
+	MaxOutput    int           // 单次运行的最大输出字节数
+	Lang         string        // 页面的默认语言，可以使用 ?lang= 切换
+
+	chapters []chapter.Chapter
+	tmpl     *template.Template
+	mux      *http.ServeMux
+	slots    chan struct{} // 限制同时运行的程序数量
+}
+
+// New 创建 Server
+func New(chapters []chapter.Chapter) *Server {
+	s := &Server{
+		Timeout:      10 * time.Second,
+		BuildTimeout: 2 * time.Minute,
+		MaxOutput:    64 << 10,
+		Lang:         i18n.Source,
+
+		chapters: chapters,
+		mux:      http.NewServeMux(),
+		slots:    make(chan struct{}, runtime.NumCPU()),
+	}
+
+	s.tmpl = template.Must(template.New("").Funcs(template.FuncMap{
+		"renderBlock": renderBlock,
+		"highlight":   Highlight,
+	}).ParseFS(templateFS, "templates/*.html"))
+
+	static, _ := fs.Sub(staticFS, "static")
+	s.mux.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.FS(static))))
+	s.mux.HandleFunc("/run/", s.handleRun)
+	s.mux.HandleFunc("/", s.handlePage)
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+type demoView struct {
+	Name   string
+	File   string
+	Line   int
+	Source string
+	RunURL string
+}
+
+// chapterView 为章节列表中的一项，Title 为当前语言下的标题
+type chapterView struct {
+	Name   string
+	Number int
+	Title  string
+	Demos  int
+	URL    string
+}
+
+type pageView struct {
+	Path     string
+	Langs    []langView
+	Chapters []chapterView
+	Chapter  chapter.Chapter
+	Lesson   *lesson.Lesson
+	Content  lessonView
+	Demos    []demoView
+	Prev     *chapterView
+	Next     *chapterView
+}
+
+func (s *Server) handlePage(w http.ResponseWriter, r *http.Request) {
+	lang := s.lang(r)
+	v := pageView{Path: r.URL.Path, Langs: langs(lang)}
+	for _, c := range s.chapters {
+		l, err := i18n.LoadLesson(c, lang)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		v.Chapters = append(v.Chapters, chapterView{
+			Name:   c.Name(),
+			Number: c.Number,
+			Title:  l.Title,
+			Demos:  len(c.Demos),
+			URL:    "/" + c.Name() + s.query(lang),
+		})
+	}
+
+	name := strings.Trim(r.URL.Path, "/")
+	if name == "" {
+		s.render(w, "index.html", v)
+		return
+	}
+
+	index := -1
+	for i, c := range s.chapters {
+		if c.Name() == name {
+			index = i
+		}
+	}
+	if index < 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	c := s.chapters[index]
+	l, content, err := loadLesson(c, lang)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	v.Chapter, v.Lesson, v.Content = c, l, content
+	if index > 0 {
+		v.Prev = &v.Chapters[index-1]
+	}
+	if index+1 < len(s.chapters) {
+		v.Next = &v.Chapters[index+1]
+	}
+
+	for _, d := range append([]chapter.Demo{c.Main}, c.Demos...) {
+		if d.Name == "" {
+			continue
+		}
+		src, err := demoSource(c, d)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		v.Demos = append(v.Demos, demoView{
+			Name:   d.Name,
+			File:   d.File,
+			Line:   d.Line,
+			Source: src,
+			RunURL: "/run/" + c.Name() + "/" + d.Name,
+		})
+	}
+
+	s.render(w, "chapter.html", v)
+}
+
+func (s *Server) render(w http.ResponseWriter, name string, data interface{}) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.tmpl.ExecuteTemplate(w, name, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// handleRun 编译并运行 demo，输出以 chunked 的方式流式返回，最后一行为运行结果，比如 `[exit status 2]`
+func (s *Server) handleRun(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/run/"), "/")
+	if len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	c, err := chapter.Find(s.chapters, parts[0])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	demo := parts[1]
+	if demo == "main" {
+		demo = ""
+	} else if _, ok := c.Demo(demo); !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	case <-r.Context().Done():
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "no-store")
+
+	out := &streamWriter{w: w}
+	if err := s.run(r.Context(), c, demo, out); err != nil {
+		fmt.Fprintf(out, "\n[%v]\n", err)
+	} else {
+		fmt.Fprint(out, "\n[program exited]\n")
+	}
+}
+
+// run 在临时目录中编译 demo，并在超时以及输出限制下运行
+func (s *Server) run(ctx context.Context, c chapter.Chapter, demo string, out io.Writer) error {
+	dir, err := os.MkdirTemp("", "gotour-serve-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	binary := filepath.Join(dir, "demo")
+	buildCtx, cancelBuild := context.WithTimeout(ctx, s.BuildTimeout)
+	defer cancelBuild()
+	if err := chapter.Build(buildCtx, c, demo, binary, out); err != nil {
+		if buildCtx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("build timed out after %v", s.BuildTimeout)
+		}
+		return fmt.Errorf("build failed: %v", err)
+	}
+
+	runCtx, cancelRun := context.WithTimeout(ctx, s.Timeout)
+	defer cancelRun()
+
+	limited := &limitWriter{w: out, n: s.MaxOutput, exceeded: cancelRun}
+	cmd := exec.CommandContext(runCtx, binary)
+	cmd.Dir = c.Dir
+	cmd.Stdout = limited
+	cmd.Stderr = limited
+	cmd.WaitDelay = time.Second
+	err = cmd.Run()
+
+	switch {
+	case limited.truncated:
+		return fmt.Errorf("output truncated after %d bytes", s.MaxOutput)
+	case runCtx.Err() == context.DeadlineExceeded:
+		return fmt.Errorf("timed out after %v", s.Timeout)
+	}
+	return err
+}
+
+// streamWriter 将每次写入立即发送给客户端，stdout、stderr 会并发写入，所以需要加锁
+type streamWriter struct {
+	mu sync.Mutex
+	w  http.ResponseWriter
+}
+
+func (s *streamWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, err := s.w.Write(p)
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return n, err
+}
+
+// limitWriter 最多写入 n 字节，超出时调用 exceeded 终止程序，之后的写入都会被丢弃
+type limitWriter struct {
+	mu        sync.Mutex
+	w         io.Writer
+	n         int
+	truncated bool
+	exceeded  func()
+}
+
+func (l *limitWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.truncated {
+		return len(p), nil
+	}
+	if len(p) > l.n {
+		l.w.Write(p[:l.n])
+		l.n = 0
+		l.truncated = true
+		l.exceeded()
+		return len(p), nil
+	}
+	l.n -= len(p)
+	return l.w.Write(p)
+}
@@ -0,0 +1,24 @@
+package web
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLimitWriter(t *testing.T) {
+	var buf bytes.Buffer
+	exceeded := 0
+	w := &limitWriter{w: &buf, n: 8, exceeded: func() { exceeded++ }}
+
+	for _, s := range []string{"0123", "4567", "89", "ab"} {
+		if n, err := w.Write([]byte(s)); n != len(s) || err != nil {
+			t.Fatalf("Write(%q) = %d, %v", s, n, err)
+		}
+	}
+	if got := buf.String(); got != "01234567" {
+		t.Errorf("written %q, want %q", got, "01234567")
+	}
+	if !w.truncated || exceeded != 1 {
+		t.Errorf("truncated = %v, exceeded called %d times, want true, 1", w.truncated, exceeded)
+	}
+}
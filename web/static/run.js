@@ -0,0 +1,55 @@
+// Run 按钮：POST 到 data-url，流式读取程序输出，运行中再次点击则终止运行
+document.querySelectorAll("button.run").forEach(function (button) {
+	var controller = null;
+
+	button.addEventListener("click", function () {
+		if (controller) {
+			controller.abort();
+			return;
+		}
+
+		var output = button.closest(".demo").querySelector(".output");
+		output.hidden = false;
+		output.textContent = "";
+
+		controller = new AbortController();
+		button.textContent = "Stop";
+		button.classList.add("running");
+
+		var done = function (message) {
+			if (message) {
+				var status = document.createElement("span");
+				status.className = "status";
+				status.textContent = message;
+				output.appendChild(status);
+			}
+			controller = null;
+			button.textContent = "Run";
+			button.classList.remove("running");
+		};
+
+		fetch(button.dataset.url, { method: "POST", signal: controller.signal })
+			.then(function (resp) {
+				if (!resp.ok) {
+					return resp.text().then(function (text) { done("\n[" + resp.status + " " + text.trim() + "]\n"); });
+				}
+				var reader = resp.body.getReader();
+				var decoder = new TextDecoder();
+				var read = function () {
+					return reader.read().then(function (chunk) {
+						if (chunk.done) {
+							done();
+							return;
+						}
+						output.appendChild(document.createTextNode(decoder.decode(chunk.value, { stream: true })));
+						output.scrollTop = output.scrollHeight;
+						return read();
+					});
+				};
+				return read();
+			})
+			.catch(function (err) {
+				done(err.name === "AbortError" ? "\n[stopped]\n" : "\n[" + err + "]\n");
+			});
+	});
+});